@@ -33,14 +33,24 @@ func main() {
 	repos := postgres.NewRepositories(db)
 
 	// Initialize WebSocket hubs
-	hub := websocket.NewHub(repos.User, repos.RoomPlayer, repos.Champion, repos.Room, repos.DraftAction)
+	messageLimits := websocket.MessageLimits{
+		Message:       cfg.WSMessageLimit,
+		Hover:         cfg.WSHoverLimit,
+		MaxViolations: cfg.WSMaxViolations,
+	}
+
+	hub := websocket.NewHub(repos.User, repos.RoomPlayer, repos.Champion, repos.Room, repos.DraftAction, repos.FearlessBan, repos.DraftTemplate, repos.DraftState, repos.PickAssignment, repos.RoomChatMessage)
+	hub.SetMessageLimits(messageLimits)
 	go hub.Run()
 
 	lobbyHub := websocket.NewLobbyHub(repos.Lobby, repos.LobbyPlayer, repos.MatchOption, repos.User)
+	lobbyHub.SetMessageLimits(messageLimits)
 	go lobbyHub.Run()
 
 	// Initialize services
 	services := service.NewServices(repos, cfg)
+	lobbyHub.SetCaptainPicker(services.Lobby)
+	lobbyHub.SetLobbyChat(services.Lobby)
 
 	// Sync champions on startup (in background)
 	go func() {
@@ -54,6 +64,33 @@ func main() {
 		}
 	}()
 
+	// Seed built-in draft templates before rooms can reference them
+	templateCtx, templateCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := services.DraftTemplate.EnsureBuiltInTemplates(templateCtx); err != nil {
+		log.Printf("Warning: failed to seed draft templates: %v", err)
+	}
+	templateCancel()
+
+	// Promote the configured admins on a fresh install so roles can be managed from the admin API
+	if len(cfg.AdminUserIDs) > 0 {
+		adminCtx, adminCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if promoted, err := services.Admin.EnsureAdmins(adminCtx, cfg.AdminUserIDs); err != nil {
+			log.Printf("Warning: failed to promote admin users: %v", err)
+		} else if promoted > 0 {
+			log.Printf("Promoted %d users to admin", promoted)
+		}
+		adminCancel()
+	}
+
+	// Bring back drafts that were in progress before the last shutdown
+	restoreCtx, restoreCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if restored, err := hub.RestoreRooms(restoreCtx); err != nil {
+		log.Printf("Warning: failed to restore in-progress rooms: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d in-progress rooms", restored)
+	}
+	restoreCancel()
+
 	// Initialize router
 	router := api.NewRouter(services, hub, lobbyHub, repos, cfg)
 
@@ -114,7 +114,7 @@ func (c *APIClient) Login(displayName, password string) (string, error) {
 }
 
 // RegisterUser creates a new user account
-func (c *APIClient) RegisterUser(baseName string) (*User, string, error) {
+func (c *APIClient) RegisterUser(baseName string) (*AuthResponse, error) {
 	displayName := fmt.Sprintf("%s_%d", baseName, time.Now().UnixNano()%100000)
 
 	body := map[string]string{
@@ -124,21 +124,21 @@ func (c *APIClient) RegisterUser(baseName string) (*User, string, error) {
 
 	resp, err := c.post("/auth/register", body, "")
 	if err != nil {
-		return nil, "", fmt.Errorf("register request failed: %w", err)
+		return nil, fmt.Errorf("register request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("register failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("register failed (status %d): %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result AuthResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, "", fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &result.User, result.AccessToken, nil
+	return &result, nil
 }
 
 // CreateLobbyOptions specifies options for creating a lobby
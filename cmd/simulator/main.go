@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 )
@@ -136,10 +137,25 @@ EXAMPLES:
   simulator end-voting --lobby=ABC123 --force=3`)
 }
 
-// SimUser tracks a simulated user and their token for URL generation
+// SimUser tracks a simulated user and their tokens for URL generation
 type SimUser struct {
-	DisplayName string
-	Token       string
+	DisplayName  string
+	Token        string
+	RefreshToken string
+}
+
+// newSimUser tracks a freshly registered user
+func newSimUser(auth *AuthResponse) SimUser {
+	return SimUser{DisplayName: auth.User.DisplayName, Token: auth.AccessToken, RefreshToken: auth.RefreshToken}
+}
+
+// LoginURL links to a lobby signed in as the user. The refresh token keeps the session
+// alive once the short-lived access token expires
+func (u SimUser) LoginURL(lobbyCode string) string {
+	query := url.Values{}
+	query.Set("token", u.Token)
+	query.Set("refreshToken", u.RefreshToken)
+	return fmt.Sprintf("%s/lobby/%s?%s", frontendURL, lobbyCode, query.Encode())
 }
 
 func fullCmd(apiURL string, args []string) {
@@ -163,11 +179,12 @@ func fullCmd(apiURL string, args []string) {
 
 	// 1. Create lobby with admin user
 	fmt.Print("Creating admin user and lobby... ")
-	admin, adminToken, err := client.RegisterUser("LobbyAdmin")
+	adminAuth, err := client.RegisterUser("LobbyAdmin")
 	if err != nil {
 		fmt.Printf("FAILED\n  Error: %v\n", err)
 		os.Exit(1)
 	}
+	admin, adminToken := adminAuth.User, adminAuth.AccessToken
 	fmt.Printf("OK (user: %s)\n", admin.DisplayName)
 
 	lobby, err := client.CreateLobbyWithOptions(adminToken, CreateLobbyOptions{
@@ -195,7 +212,7 @@ func fullCmd(apiURL string, args []string) {
 	}
 
 	// Track all users with their tokens for URL generation
-	users := []SimUser{{DisplayName: admin.DisplayName, Token: adminToken}}
+	users := []SimUser{newSimUser(adminAuth)}
 
 	// 2. Create and join additional users
 	tokens := []string{adminToken}
@@ -204,11 +221,12 @@ func fullCmd(apiURL string, args []string) {
 
 	for i := 1; i < *count; i++ {
 		displayName := fmt.Sprintf("Player%d", i)
-		user, token, err := client.RegisterUser(displayName)
+		auth, err := client.RegisterUser(displayName)
 		if err != nil {
 			fmt.Printf("  [%d/%d] FAILED to create user: %v\n", i+1, *count, err)
 			os.Exit(1)
 		}
+		user, token := auth.User, auth.AccessToken
 
 		if err := client.JoinLobby(token, lobby.ID); err != nil {
 			fmt.Printf("  [%d/%d] FAILED to join lobby: %v\n", i+1, *count, err)
@@ -224,7 +242,7 @@ func fullCmd(apiURL string, args []string) {
 		}
 
 		tokens = append(tokens, token)
-		users = append(users, SimUser{DisplayName: user.DisplayName, Token: token})
+		users = append(users, newSimUser(auth))
 		fmt.Printf("  [%d/%d] %s joined\n", i+1, *count, user.DisplayName)
 	}
 
@@ -240,7 +258,7 @@ func fullCmd(apiURL string, args []string) {
 		fmt.Println()
 		fmt.Println("  Click any link to auto-login as that user:")
 		for _, u := range users {
-			fmt.Printf("  - %s: %s/lobby/%s?token=%s\n", u.DisplayName, frontendURL, lobby.ShortCode, u.Token)
+			fmt.Printf("  - %s: %s\n", u.DisplayName, u.LoginURL(lobby.ShortCode))
 		}
 		fmt.Println()
 		fmt.Println("  Next steps:")
@@ -278,7 +296,7 @@ func fullCmd(apiURL string, args []string) {
 		fmt.Println()
 		fmt.Println("  Click any link to auto-login as that user:")
 		for _, u := range users {
-			fmt.Printf("  - %s: %s/lobby/%s?token=%s\n", u.DisplayName, frontendURL, lobby.ShortCode, u.Token)
+			fmt.Printf("  - %s: %s\n", u.DisplayName, u.LoginURL(lobby.ShortCode))
 		}
 		fmt.Println()
 		return
@@ -323,7 +341,7 @@ func fullCmd(apiURL string, args []string) {
 	fmt.Println()
 	fmt.Println("  Click any link to auto-login as that user:")
 	for _, u := range users {
-		fmt.Printf("  - %s: %s/lobby/%s?token=%s\n", u.DisplayName, frontendURL, lobby.ShortCode, u.Token)
+		fmt.Printf("  - %s: %s\n", u.DisplayName, u.LoginURL(lobby.ShortCode))
 	}
 	fmt.Println()
 	fmt.Println("  Click 'Start Draft' in the UI to begin!")
@@ -349,11 +367,12 @@ func populateCmd(apiURL string, args []string) {
 	var users []SimUser
 	for i := 0; i < *count; i++ {
 		displayName := fmt.Sprintf("Player%d", i+1)
-		user, token, err := client.RegisterUser(displayName)
+		auth, err := client.RegisterUser(displayName)
 		if err != nil {
 			fmt.Printf("  [%d/%d] FAILED to create user: %v\n", i+1, *count, err)
 			continue
 		}
+		user, token := auth.User, auth.AccessToken
 
 		if err := client.JoinLobby(token, *lobbyCode); err != nil {
 			fmt.Printf("  [%d/%d] FAILED to join: %v\n", i+1, *count, err)
@@ -368,14 +387,14 @@ func populateCmd(apiURL string, args []string) {
 			fmt.Printf("Warning: Failed to set profiles for %s\n", user.DisplayName)
 		}
 
-		users = append(users, SimUser{DisplayName: user.DisplayName, Token: token})
+		users = append(users, newSimUser(auth))
 		fmt.Printf("  [%d/%d] %s joined\n", i+1, *count, user.DisplayName)
 	}
 
 	fmt.Println()
 	fmt.Println("Done! Click any link to auto-login as that user:")
 	for _, u := range users {
-		fmt.Printf("  - %s: %s/lobby/%s?token=%s\n", u.DisplayName, frontendURL, *lobbyCode, u.Token)
+		fmt.Printf("  - %s: %s\n", u.DisplayName, u.LoginURL(*lobbyCode))
 	}
 	fmt.Println()
 }
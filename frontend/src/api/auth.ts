@@ -1,6 +1,11 @@
 import { api } from './client'
 import { User } from '@/types'
 
+interface WSTicketResponse {
+  ticket: string
+  expiresAt: string
+}
+
 interface AuthResponse {
   user: User
   accessToken: string
@@ -19,4 +24,20 @@ export const authApi = {
 
   logout: (): Promise<{ success: boolean }> =>
     api.post('/auth/logout'),
+
+  changePassword: (oldPassword: string, newPassword: string): Promise<AuthResponse> =>
+    api.put('/auth/password', { oldPassword, newPassword }),
+
+  resetPassword: (token: string, newPassword: string): Promise<AuthResponse> =>
+    api.post('/auth/reset-password', { token, newPassword }),
+
+  changeDisplayName: (displayName: string): Promise<User> =>
+    api.put('/auth/display-name', { displayName }),
+
+  deleteAccount: (password: string): Promise<{ success: boolean }> =>
+    api.delete('/auth/account', { password }),
+
+  // Single-use, short-lived ticket for opening a websocket without putting the access token in the URL
+  wsTicket: (purpose: 'draft' | 'lobby'): Promise<WSTicketResponse> =>
+    api.post('/ws-ticket', { purpose }),
 }
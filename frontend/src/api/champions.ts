@@ -14,5 +14,5 @@ export const championsApi = {
     api.get(`/champions/${id}`),
 
   sync: (): Promise<{ synced: number; version: string }> =>
-    api.post('/champions/sync'),
+    api.post('/admin/champions/sync'),
 }
@@ -6,7 +6,35 @@ interface RequestOptions {
   headers?: Record<string, string>
 }
 
-async function request<T>(endpoint: string, options: RequestOptions = {}): Promise<T> {
+let refreshing: Promise<boolean> | null = null
+
+// Exchanges the stored refresh token for new tokens. Concurrent callers share one
+// request, since a refresh token can only be used once
+function refreshTokens(): Promise<boolean> {
+  if (!refreshing) {
+    refreshing = (async () => {
+      const refreshToken = localStorage.getItem('refreshToken')
+      if (!refreshToken) return false
+
+      const response = await fetch(`${API_BASE}/auth/refresh`, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ refreshToken }),
+      })
+      if (!response.ok) return false
+
+      const data = await response.json()
+      localStorage.setItem('accessToken', data.accessToken)
+      localStorage.setItem('refreshToken', data.refreshToken)
+      return true
+    })().finally(() => {
+      refreshing = null
+    })
+  }
+  return refreshing
+}
+
+async function request<T>(endpoint: string, options: RequestOptions = {}, retried = false): Promise<T> {
   const token = localStorage.getItem('accessToken')
 
   const headers: Record<string, string> = {
@@ -31,9 +59,12 @@ async function request<T>(endpoint: string, options: RequestOptions = {}): Promi
   console.log(`   Response: ${response.status} ${response.statusText}`)
 
   if (!response.ok) {
-    // Handle expired/invalid token - redirect to login
+    // Handle expired/invalid token - try a refresh, then redirect to login
     // But don't redirect for auth endpoints (login/register) - let them show error
     const isAuthEndpoint = endpoint.startsWith('/auth/login') || endpoint.startsWith('/auth/register')
+    if (response.status === 401 && !isAuthEndpoint && !retried && (await refreshTokens())) {
+      return request<T>(endpoint, options, true)
+    }
     if (response.status === 401 && !isAuthEndpoint) {
       localStorage.removeItem('accessToken')
       localStorage.removeItem('refreshToken')
@@ -54,5 +85,5 @@ export const api = {
   get: <T>(endpoint: string) => request<T>(endpoint),
   post: <T>(endpoint: string, body?: unknown) => request<T>(endpoint, { method: 'POST', body }),
   put: <T>(endpoint: string, body?: unknown) => request<T>(endpoint, { method: 'PUT', body }),
-  delete: <T>(endpoint: string) => request<T>(endpoint, { method: 'DELETE' }),
+  delete: <T>(endpoint: string, body?: unknown) => request<T>(endpoint, { method: 'DELETE', body }),
 }
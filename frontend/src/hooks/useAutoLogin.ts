@@ -7,8 +7,8 @@ import { useLocation } from 'react-router-dom'
  *
  * When a ?token=JWT parameter is present in the URL:
  * 1. Clears any existing session (localStorage and cookies)
- * 2. Stores the new token in localStorage
- * 3. Removes the token from the URL for security
+ * 2. Stores the new token, and the refresh token if one came with it, in localStorage
+ * 3. Removes the tokens from the URL for security
  * 4. Reloads the page to ensure fresh auth state
  */
 export function useAutoLogin() {
@@ -17,6 +17,7 @@ export function useAutoLogin() {
   useEffect(() => {
     const params = new URLSearchParams(location.search)
     const token = params.get('token')
+    const refreshToken = params.get('refreshToken')
 
     if (token) {
       // Clear any existing session data
@@ -31,11 +32,15 @@ export function useAutoLogin() {
         }
       })
 
-      // Store the new token
+      // Store the new tokens; the refresh token keeps the session alive past the access token
       localStorage.setItem('accessToken', token)
+      if (refreshToken) {
+        localStorage.setItem('refreshToken', refreshToken)
+      }
 
-      // Remove token from URL for security (keep other params if any)
+      // Remove tokens from URL for security (keep other params if any)
       params.delete('token')
+      params.delete('refreshToken')
       const newSearch = params.toString()
       const newUrl = location.pathname + (newSearch ? `?${newSearch}` : '')
 
@@ -44,6 +44,7 @@ import {
   toVotingStatus,
 } from '@/types/lobbyWebSocket'
 import { Lobby, LobbyStatus, VotingMode } from '@/types'
+import { authApi } from '@/api/auth'
 
 const WS_URL = `${window.location.protocol === 'https:' ? 'wss:' : 'ws:'}//${window.location.host}/api/v1/lobby-ws`
 
@@ -52,6 +53,8 @@ export function useLobbyWebSocket(lobbyId: string | undefined) {
   const { accessToken, user } = useSelector((state: RootState) => state.auth)
   const wsRef = useRef<WebSocket | null>(null)
   const reconnectTimeoutRef = useRef<ReturnType<typeof setTimeout> | null>(null)
+  // Bumped on cleanup so a connect still waiting on its ticket doesn't open a socket
+  const connectAttemptRef = useRef(0)
   const handleMessageRef = useRef<((msg: LobbyWSMessage) => void) | null>(null)
   const [isConnected, setIsConnected] = useState(false)
   const [error, setError] = useState<string | null>(null)
@@ -302,7 +305,7 @@ export function useLobbyWebSocket(lobbyId: string | undefined) {
   }, [handleMessage])
 
   // Connect to WebSocket
-  const connect = useCallback(() => {
+  const connect = useCallback(async () => {
     if (!accessToken || !lobbyId) return
 
     // Validate lobbyId is a valid UUID before connecting
@@ -312,7 +315,20 @@ export function useLobbyWebSocket(lobbyId: string | undefined) {
       return
     }
 
-    const ws = new WebSocket(`${WS_URL}?token=${accessToken}`)
+    const attempt = ++connectAttemptRef.current
+    let ticket: string
+    try {
+      ({ ticket } = await authApi.wsTicket('lobby'))
+    } catch (err) {
+      console.error('[LobbyWS] Failed to get ticket:', err)
+      reconnectTimeoutRef.current = setTimeout(() => {
+        connect()
+      }, 3000)
+      return
+    }
+    if (attempt !== connectAttemptRef.current) return
+
+    const ws = new WebSocket(`${WS_URL}?ticket=${ticket}`)
     wsRef.current = ws
 
     ws.onopen = () => {
@@ -360,6 +376,7 @@ export function useLobbyWebSocket(lobbyId: string | undefined) {
     }
 
     return () => {
+      connectAttemptRef.current++
       if (reconnectTimeoutRef.current) {
         clearTimeout(reconnectTimeoutRef.current)
       }
@@ -20,6 +20,7 @@ import { syncRoom, playerUpdate, updateRoomStatus, setConnectionStatus, setError
 import { WSMessage, StateSyncPayload } from '@/types'
 import { MessageRouter } from '@/utils/MessageRouter'
 import type { CommandAction } from '@/types/websocket'
+import { authApi } from '@/api/auth'
 
 const WS_URL = `${window.location.protocol === 'https:' ? 'wss:' : 'ws:'}//${window.location.host}/api/v1/ws`
 
@@ -28,6 +29,8 @@ export function useWebSocket(roomId: string, side: string) {
   const { accessToken } = useSelector((state: RootState) => state.auth)
   const wsRef = useRef<WebSocket | null>(null)
   const reconnectTimeoutRef = useRef<ReturnType<typeof setTimeout> | null>(null)
+  // Bumped on cleanup so a connect still waiting on its ticket doesn't open a socket
+  const connectAttemptRef = useRef(0)
   const [isConnected, setIsConnected] = useState(false)
 
   // Create message router with handlers for server responses (still old format)
@@ -132,12 +135,25 @@ export function useWebSocket(roomId: string, side: string) {
     }
   }, [])
 
-  const connect = useCallback(() => {
+  const connect = useCallback(async () => {
     if (!accessToken || !roomId || !side) return
 
+    const attempt = ++connectAttemptRef.current
     dispatch(setConnectionStatus('connecting'))
 
-    const ws = new WebSocket(`${WS_URL}?token=${accessToken}`)
+    let ticket: string
+    try {
+      ({ ticket } = await authApi.wsTicket('draft'))
+    } catch (err) {
+      console.error('Failed to get WebSocket ticket:', err)
+      reconnectTimeoutRef.current = setTimeout(() => {
+        connect()
+      }, 3000)
+      return
+    }
+    if (attempt !== connectAttemptRef.current) return
+
+    const ws = new WebSocket(`${WS_URL}?ticket=${ticket}`)
     wsRef.current = ws
 
     ws.onopen = () => {
@@ -218,6 +234,7 @@ export function useWebSocket(roomId: string, side: string) {
     connect()
 
     return () => {
+      connectAttemptRef.current++
       if (reconnectTimeoutRef.current) {
         clearTimeout(reconnectTimeoutRef.current)
       }
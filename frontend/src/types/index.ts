@@ -1,6 +1,9 @@
+export type UserRole = 'player' | 'organizer' | 'admin'
+
 export interface User {
   id: string
   displayName: string
+  role?: UserRole
 }
 
 export interface Champion {
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/api/middleware"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type AdminHandler struct {
+	adminService *service.AdminService
+	authService  *service.AuthService
+	hub          *websocket.Hub
+	lobbyHub     *websocket.LobbyHub
+}
+
+func NewAdminHandler(adminService *service.AdminService, authService *service.AuthService, hub *websocket.Hub, lobbyHub *websocket.LobbyHub) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		authService:  authService,
+		hub:          hub,
+		lobbyHub:     lobbyHub,
+	}
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type ActiveRoomsResponse struct {
+	Rooms []websocket.ActiveRoomInfo `json:"rooms"`
+}
+
+type PasswordResetResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// ListActiveRooms returns every draft room currently running on the hub
+func (h *AdminHandler) ListActiveRooms(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ActiveRoomsResponse{Rooms: h.hub.ActiveRooms()})
+}
+
+// CloseRoom stops a draft room that hasn't finished and disconnects its clients from it
+func (h *AdminHandler) CloseRoom(w http.ResponseWriter, r *http.Request) {
+	roomID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid room ID", http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.adminService.CloseRoom(r.Context(), roomID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRoomNotFound):
+			http.Error(w, "Room not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrRoomNotOpen):
+			http.Error(w, "Room has already finished", http.StatusConflict)
+		default:
+			log.Printf("ERROR [admin.CloseRoom] failed: %v", err)
+			http.Error(w, "Failed to close room", http.StatusInternalServerError)
+		}
+		return
+	}
+	h.hub.CloseRoom(roomID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"id": room.ID.String(), "status": string(room.Status)})
+}
+
+// DeleteLobby removes a lobby and everything in it
+func (h *AdminHandler) DeleteLobby(w http.ResponseWriter, r *http.Request) {
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.adminService.DeleteLobby(r.Context(), lobbyID); err != nil {
+		if errors.Is(err, service.ErrLobbyNotFound) {
+			http.Error(w, "Lobby not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [admin.DeleteLobby] failed: %v", err)
+		http.Error(w, "Failed to delete lobby", http.StatusInternalServerError)
+		return
+	}
+	h.lobbyHub.CloseLobby(lobbyID, "This lobby was deleted by an admin")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"success": true})
+}
+
+// ResetUserMMR discards a user's earned ratings on every role
+func (h *AdminHandler) ResetUserMMR(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	profiles, err := h.adminService.ResetUserMMR(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [admin.ResetUserMMR] failed: %v", err)
+		http.Error(w, "Failed to reset MMR", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+// SetUserRole grants or removes a user's organizer or admin role
+func (h *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req SetUserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.adminService.SetUserRole(r.Context(), userID, domain.UserRole(req.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidUserRole):
+			http.Error(w, "Invalid role", http.StatusBadRequest)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("ERROR [admin.SetUserRole] failed: %v", err)
+			http.Error(w, "Failed to set role", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(UserResponse{
+		ID:          user.ID.String(),
+		DisplayName: user.DisplayName,
+		Role:        string(user.Role),
+	})
+}
+
+// IssuePasswordReset creates a one-time password reset token for a user. The admin passes
+// the token on to the user, who redeems it at /auth/reset-password
+func (h *AdminHandler) IssuePasswordReset(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	reset, err := h.authService.IssuePasswordReset(r.Context(), userID, adminID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [admin.IssuePasswordReset] failed: %v", err)
+		http.Error(w, "Failed to issue password reset", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PasswordResetResponse{
+		Token:     reset.Token,
+		ExpiresAt: reset.ExpiresAt.Format(time.RFC3339),
+	})
+}
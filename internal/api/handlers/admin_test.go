@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/api/handlers"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_RequiresAdmin(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	login := func(role domain.UserRole) string {
+		user, password := testutil.NewUserBuilder().WithRole(role).Build(t, ts.DB.DB)
+		result, err := ts.Services.Auth.Login(ctx, service.LoginInput{DisplayName: user.DisplayName, Password: password})
+		require.NoError(t, err)
+		return result.AccessToken
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			name:           "admin",
+			token:          login(domain.UserRoleAdmin),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "organizer is not an admin",
+			token:          login(domain.UserRoleOrganizer),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "player",
+			token:          login(domain.UserRolePlayer),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "unauthenticated",
+			token:          "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutil.CreateAuthenticatedRequest(t, "GET", ts.APIURL("/admin/rooms"), nil, tt.token)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestAdminHandler_CloseRoom(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	admin, password := testutil.NewUserBuilder().WithRole(domain.UserRoleAdmin).Build(t, ts.DB.DB)
+	result, err := ts.Services.Auth.Login(ctx, service.LoginInput{DisplayName: admin.DisplayName, Password: password})
+	require.NoError(t, err)
+
+	room := testutil.NewRoomBuilder().WithCreator(admin).BuildWithHub(t, ts)
+	require.NotNil(t, ts.Hub.GetRoom(room.ID.String()))
+
+	req := testutil.CreateAuthenticatedRequest(t, "POST", ts.APIURL("/admin/rooms/"+room.ID.String()+"/close"), nil, result.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Nil(t, ts.Hub.GetRoom(room.ID.String()))
+	assert.Nil(t, ts.Hub.GetRoom(room.ShortCode))
+
+	saved, err := ts.Repos.Room.GetByID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoomStatusClosed, saved.Status)
+}
+
+func TestAdminHandler_IssuePasswordReset(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	admin, password := testutil.NewUserBuilder().WithRole(domain.UserRoleAdmin).Build(t, ts.DB.DB)
+	result, err := ts.Services.Auth.Login(ctx, service.LoginInput{DisplayName: admin.DisplayName, Password: password})
+	require.NoError(t, err)
+	user, _ := testutil.NewUserBuilder().WithDisplayName("lockedout").Build(t, ts.DB.DB)
+
+	req := testutil.CreateAuthenticatedRequest(t, "POST", ts.APIURL("/admin/users/"+user.ID.String()+"/password-reset"), nil, result.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var reset handlers.PasswordResetResponse
+	testutil.AssertJSONResponse(t, resp, &reset)
+	resp.Body.Close()
+	require.NotEmpty(t, reset.Token)
+
+	redeem := func() *http.Response {
+		body, _ := json.Marshal(map[string]string{"token": reset.Token, "newPassword": "newpassword456"})
+		resp, err := http.Post(ts.APIURL("/auth/reset-password"), "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = redeem()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var signedIn testutil.AuthResponse
+	testutil.AssertJSONResponse(t, resp, &signedIn)
+	resp.Body.Close()
+	assert.Equal(t, "lockedout", signedIn.User.DisplayName)
+
+	resp = redeem()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -28,6 +28,28 @@ type LoginRequest struct {
 	Password    string `json:"password"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+type ChangeDisplayNameRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
 type AuthResponse struct {
 	User         UserResponse `json:"user"`
 	AccessToken  string       `json:"accessToken"`
@@ -37,6 +59,7 @@ type AuthResponse struct {
 type UserResponse struct {
 	ID          string `json:"id"`
 	DisplayName string `json:"displayName"`
+	Role        string `json:"role"`
 }
 
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -62,6 +85,10 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Display name already exists", http.StatusConflict)
 			return
 		}
+		if errors.Is(err, service.ErrDisplayNameReserved) {
+			http.Error(w, "Display name is reserved", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -70,6 +97,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		User: UserResponse{
 			ID:          result.User.ID.String(),
 			DisplayName: result.User.DisplayName,
+			Role:        string(result.User.Role),
 		},
 		AccessToken:  result.AccessToken,
 		RefreshToken: result.RefreshToken,
@@ -108,6 +136,49 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		User: UserResponse{
 			ID:          result.User.ID.String(),
 			DisplayName: result.User.DisplayName,
+			Role:        string(result.User.Role),
+		},
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.RefreshToken == "" {
+		http.Error(w, "Refresh token is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.authService.RefreshTokens(r.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			log.Printf("WARN [auth.Refresh] refresh token reuse detected, session revoked")
+			http.Error(w, "Refresh token was already used", http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+			return
+		}
+		log.Printf("ERROR [auth.Refresh] failed to refresh tokens: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := AuthResponse{
+		User: UserResponse{
+			ID:          result.User.ID.String(),
+			DisplayName: result.User.DisplayName,
+			Role:        string(result.User.Role),
 		},
 		AccessToken:  result.AccessToken,
 		RefreshToken: result.RefreshToken,
@@ -133,6 +204,7 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	resp := UserResponse{
 		ID:          user.ID.String(),
 		DisplayName: user.DisplayName,
+		Role:        string(user.Role),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -146,7 +218,13 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.authService.Logout(r.Context(), userID); err != nil {
+	var err error
+	if sessionID, ok := middleware.GetSessionID(r.Context()); ok {
+		err = h.authService.LogoutSession(r.Context(), userID, sessionID)
+	} else {
+		err = h.authService.Logout(r.Context(), userID)
+	}
+	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -154,3 +232,166 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]bool{"success": true})
 }
+
+// ChangePassword sets a new password and signs out every other session
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.OldPassword == "" || req.NewPassword == "" {
+		http.Error(w, "Old and new passwords are required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.authService.ChangePassword(r.Context(), userID, req.OldPassword, req.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			http.Error(w, "Old password is incorrect", http.StatusForbidden)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("ERROR [auth.ChangePassword] failed to change password: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := AuthResponse{
+		User: UserResponse{
+			ID:          result.User.ID.String(),
+			DisplayName: result.User.DisplayName,
+			Role:        string(result.User.Role),
+		},
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ResetPassword sets a new password with a one-time token issued by an admin
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" || req.NewPassword == "" {
+		http.Error(w, "Token and new password are required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.authService.ResetPassword(r.Context(), req.Token, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			http.Error(w, "Invalid or expired reset token", http.StatusBadRequest)
+			return
+		}
+		log.Printf("ERROR [auth.ResetPassword] failed to reset password: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := AuthResponse{
+		User: UserResponse{
+			ID:          result.User.ID.String(),
+			DisplayName: result.User.DisplayName,
+			Role:        string(result.User.Role),
+		},
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ChangeDisplayName renames the current user
+func (h *AuthHandler) ChangeDisplayName(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ChangeDisplayNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authService.ChangeDisplayName(r.Context(), userID, req.DisplayName)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDisplayName):
+			http.Error(w, "Display name must be 1 to 32 characters", http.StatusBadRequest)
+		case errors.Is(err, service.ErrDisplayNameExists):
+			http.Error(w, "Display name already exists", http.StatusConflict)
+		case errors.Is(err, service.ErrDisplayNameReserved):
+			http.Error(w, "Display name is reserved", http.StatusBadRequest)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("ERROR [auth.ChangeDisplayName] failed to rename user: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := UserResponse{
+		ID:          user.ID.String(),
+		DisplayName: user.DisplayName,
+		Role:        string(user.Role),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteAccount deletes the current user's account after confirming their password
+func (h *AuthHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Password == "" {
+		http.Error(w, "Password is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.DeleteAccount(r.Context(), userID, req.Password); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			http.Error(w, "Password is incorrect", http.StatusForbidden)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("ERROR [auth.DeleteAccount] failed to delete account: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"success": true})
+}
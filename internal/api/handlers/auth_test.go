@@ -270,3 +270,44 @@ func TestAuthHandler_Logout(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	body, _ := json.Marshal(map[string]string{"displayName": "refreshuser", "password": "password123"})
+	resp, err := http.Post(ts.APIURL("/auth/register"), "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	var registered testutil.AuthResponse
+	testutil.AssertJSONResponse(t, resp, &registered)
+	resp.Body.Close()
+
+	refresh := func(token string) *http.Response {
+		body, _ := json.Marshal(map[string]string{"refreshToken": token})
+		resp, err := http.Post(ts.APIURL("/auth/refresh"), "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = refresh(registered.RefreshToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rotated testutil.AuthResponse
+	testutil.AssertJSONResponse(t, resp, &rotated)
+	resp.Body.Close()
+	assert.Equal(t, "refreshuser", rotated.User.DisplayName)
+	assert.NotEqual(t, registered.RefreshToken, rotated.RefreshToken)
+
+	// The new access token works, and the old refresh token can't be used again
+	req := testutil.CreateAuthenticatedRequest(t, "GET", ts.APIURL("/auth/me"), nil, rotated.AccessToken)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = refresh(registered.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = refresh("")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
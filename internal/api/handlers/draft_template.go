@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dom/league-draft-website/internal/api/middleware"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type DraftTemplateHandler struct {
+	templateService *service.DraftTemplateService
+}
+
+func NewDraftTemplateHandler(templateService *service.DraftTemplateService) *DraftTemplateHandler {
+	return &DraftTemplateHandler{templateService: templateService}
+}
+
+type DraftPhaseRequest struct {
+	Team       string `json:"team"`
+	ActionType string `json:"actionType"`
+}
+
+type CreateDraftTemplateRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Kind        string              `json:"kind"`
+	Phases      []DraftPhaseRequest `json:"phases"`
+	PoolSize    int                 `json:"poolSize"`
+}
+
+type DraftTemplateResponse struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Kind        string         `json:"kind"`
+	Phases      []domain.Phase `json:"phases"`
+	PoolSize    int            `json:"poolSize"`
+	IsBuiltIn   bool           `json:"isBuiltIn"`
+}
+
+func (h *DraftTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.ListTemplates(r.Context())
+	if err != nil {
+		log.Printf("ERROR [draftTemplate.List]: %v", err)
+		http.Error(w, "Failed to get draft templates", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]DraftTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, toDraftTemplateResponse(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *DraftTemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	template, err := h.templateService.GetTemplate(r.Context(), chi.URLParam(r, "idOrName"))
+	if err != nil {
+		if errors.Is(err, service.ErrDraftTemplateNotFound) {
+			http.Error(w, "Draft template not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [draftTemplate.Get]: %v", err)
+		http.Error(w, "Failed to get draft template", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toDraftTemplateResponse(template))
+}
+
+func (h *DraftTemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateDraftTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	phases := make([]domain.Phase, len(req.Phases))
+	for i, p := range req.Phases {
+		phases[i] = domain.Phase{
+			Index:      i,
+			Team:       domain.Side(p.Team),
+			ActionType: domain.ActionType(p.ActionType),
+		}
+	}
+
+	template, err := h.templateService.CreateCustomTemplate(r.Context(), service.CreateDraftTemplateInput{
+		CreatedBy:   userID,
+		Name:        req.Name,
+		Description: req.Description,
+		Kind:        domain.DraftTemplateKind(req.Kind),
+		Phases:      phases,
+		PoolSize:    req.PoolSize,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDraftTemplateNameTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrInvalidDraftTemplateName),
+			errors.Is(err, domain.ErrInvalidDraftTemplateKind),
+			errors.Is(err, domain.ErrInvalidDraftPhases),
+			errors.Is(err, domain.ErrInvalidDraftPickCount),
+			errors.Is(err, domain.ErrInvalidDraftPoolSize):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("ERROR [draftTemplate.Create]: %v", err)
+			http.Error(w, "Failed to create draft template", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toDraftTemplateResponse(template))
+}
+
+func toDraftTemplateResponse(t *domain.DraftTemplate) DraftTemplateResponse {
+	phases, err := t.GetPhases()
+	if err != nil {
+		log.Printf("ERROR [draftTemplate] failed to decode phases for %s: %v", t.Name, err)
+		phases = []domain.Phase{}
+	}
+
+	return DraftTemplateResponse{
+		ID:          t.ID.String(),
+		Name:        t.Name,
+		Description: t.Description,
+		Kind:        string(t.Kind),
+		Phases:      phases,
+		PoolSize:    t.PoolSize,
+		IsBuiltIn:   t.IsBuiltIn,
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 
@@ -32,25 +34,76 @@ func NewLobbyHandler(lobbyService *service.LobbyService, matchmakingService *ser
 
 // Request/Response types
 type CreateLobbyRequest struct {
-	DraftMode            string `json:"draftMode"`
-	TimerDurationSeconds int    `json:"timerDurationSeconds"`
-	VotingEnabled        bool   `json:"votingEnabled"`
-	VotingMode           string `json:"votingMode"`
+	DraftMode             string `json:"draftMode"`
+	TimerDurationSeconds  int    `json:"timerDurationSeconds"`
+	TradePhaseSeconds     int    `json:"tradePhaseSeconds"`
+	SpectatorDelaySeconds int    `json:"spectatorDelaySeconds"`
+	VotingEnabled         bool   `json:"votingEnabled"`
+	VotingMode            string `json:"votingMode"`
+	// TeamSize picks a 2v2 to 5v5 format, 5v5 when unset
+	TeamSize int `json:"teamSize"`
+	// Matchmaking optionally chooses the algorithms and weights that generate match options
+	Matchmaking *MatchmakingSettingsRequest `json:"matchmaking"`
+}
+
+// MatchmakingSettingsRequest chooses how a lobby's match options are generated.
+// No algorithms uses the default one, and no weights leaves every algorithm's tuning unchanged.
+type MatchmakingSettingsRequest struct {
+	Algorithms []string        `json:"algorithms"`
+	Weights    *BalanceWeights `json:"weights"`
+}
+
+// BalanceWeights scale how much the algorithms care about each balance measure, from 0 to 10
+type BalanceWeights struct {
+	MMRDiff  float64 `json:"mmrDiff"`
+	Comfort  float64 `json:"comfort"`
+	LaneDiff float64 `json:"laneDiff"`
+}
+
+// AddPreferenceRequest declares a matchmaking preference: same_team and not_same_team take a
+// targetUserId, only_roles takes one or more roles and never_role exactly one.
+// Hard preferences are never broken; soft ones only lower an option's balance score.
+type AddPreferenceRequest struct {
+	Type         string   `json:"type"`
+	TargetUserID *string  `json:"targetUserId"`
+	Roles        []string `json:"roles"`
+	IsHard       bool     `json:"isHard"`
+}
+
+type PreferenceResponse struct {
+	ID           string   `json:"id"`
+	UserID       string   `json:"userId"`
+	Type         string   `json:"type"`
+	TargetUserID *string  `json:"targetUserId,omitempty"`
+	Roles        []string `json:"roles"`
+	IsHard       bool     `json:"isHard"`
+	CreatedAt    string   `json:"createdAt"`
+}
+
+type MatchmakingSettingsResponse struct {
+	Algorithms []string       `json:"algorithms"`
+	Weights    BalanceWeights `json:"weights"`
 }
 
 type LobbyResponse struct {
-	ID                   string                `json:"id"`
-	ShortCode            string                `json:"shortCode"`
-	CreatedBy            string                `json:"createdBy"`
-	Status               string                `json:"status"`
-	SelectedMatchOption  *int                  `json:"selectedMatchOption"`
-	DraftMode            string                `json:"draftMode"`
-	TimerDurationSeconds int                   `json:"timerDurationSeconds"`
-	RoomID               *string               `json:"roomId"`
-	VotingEnabled        bool                  `json:"votingEnabled"`
-	VotingMode           string                `json:"votingMode"`
-	VotingDeadline       *string               `json:"votingDeadline,omitempty"`
-	Players              []LobbyPlayerResponse `json:"players"`
+	ID                    string                      `json:"id"`
+	ShortCode             string                      `json:"shortCode"`
+	CreatedBy             string                      `json:"createdBy"`
+	Status                string                      `json:"status"`
+	SelectedMatchOption   *int                        `json:"selectedMatchOption"`
+	DraftMode             string                      `json:"draftMode"`
+	TimerDurationSeconds  int                         `json:"timerDurationSeconds"`
+	TradePhaseSeconds     int                         `json:"tradePhaseSeconds"`
+	SpectatorDelaySeconds int                         `json:"spectatorDelaySeconds"`
+	TeamSize              int                         `json:"teamSize"`
+	Roles                 []string                    `json:"roles"`
+	MaxPlayers            int                         `json:"maxPlayers"`
+	RoomID                *string                     `json:"roomId"`
+	VotingEnabled         bool                        `json:"votingEnabled"`
+	VotingMode            string                      `json:"votingMode"`
+	VotingDeadline        *string                     `json:"votingDeadline,omitempty"`
+	Matchmaking           MatchmakingSettingsResponse `json:"matchmaking"`
+	Players               []LobbyPlayerResponse       `json:"players"`
 }
 
 type LobbyPlayerResponse struct {
@@ -61,6 +114,7 @@ type LobbyPlayerResponse struct {
 	AssignedRole *string `json:"assignedRole"`
 	IsReady      bool    `json:"isReady"`
 	IsCaptain    bool    `json:"isCaptain"`
+	ChatMuted    bool    `json:"chatMuted"`
 	JoinOrder    int     `json:"joinOrder"`
 }
 
@@ -92,6 +146,12 @@ type SwapRequest struct {
 	SwapType  string `json:"swapType"` // "players" or "roles"
 }
 
+// SubstituteRequest replaces a player on the captain's team with a bench player
+type SubstituteRequest struct {
+	OutUserID   string `json:"outUserId"`
+	BenchUserID string `json:"benchUserId"`
+}
+
 type PromoteCaptainRequest struct {
 	UserID string `json:"userId"`
 }
@@ -100,6 +160,17 @@ type KickPlayerRequest struct {
 	UserID string `json:"userId"`
 }
 
+// MuteRequest mutes or unmutes a player in the lobby chat
+type MuteRequest struct {
+	UserID string `json:"userId"`
+	Muted  bool   `json:"muted"`
+}
+
+// StartCaptainsDraftRequest chooses how the captains of a captain's draft are picked
+type StartCaptainsDraftRequest struct {
+	CaptainSelection string `json:"captainSelection"` // "highest_mmr", "volunteer" or "random"
+}
+
 type MatchOptionResponse struct {
 	OptionNumber     int                  `json:"optionNumber"`
 	AlgorithmType    string               `json:"algorithmType"`
@@ -112,6 +183,9 @@ type MatchOptionResponse struct {
 	MaxLaneDiff      int                  `json:"maxLaneDiff"`
 	UsedMmrThreshold int                  `json:"usedMmrThreshold"`
 	Assignments      []AssignmentResponse `json:"assignments"`
+	// IDs of the lobby preferences the option keeps and breaks
+	SatisfiedPreferences   []string `json:"satisfiedPreferences"`
+	UnsatisfiedPreferences []string `json:"unsatisfiedPreferences"`
 }
 
 type AssignmentResponse struct {
@@ -191,6 +265,21 @@ func (h *LobbyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		draftMode = domain.DraftModeFearless
 	}
 
+	if req.TradePhaseSeconds < 0 || req.TradePhaseSeconds > maxTradePhaseSeconds {
+		http.Error(w, "Trade phase must be between 0 and 300 seconds", http.StatusBadRequest)
+		return
+	}
+
+	if req.SpectatorDelaySeconds < 0 || req.SpectatorDelaySeconds > maxSpectatorDelaySeconds {
+		http.Error(w, "Spectator delay must be between 0 and 120 seconds", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamSize != 0 && (req.TeamSize < domain.MinTeamSize || req.TeamSize > domain.MaxTeamSize) {
+		http.Error(w, "Team size must be between 2 and 5", http.StatusBadRequest)
+		return
+	}
+
 	votingMode := domain.VotingModeMajority
 	switch req.VotingMode {
 	case "unanimous":
@@ -199,13 +288,30 @@ func (h *LobbyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		votingMode = domain.VotingModeCaptainOverride
 	}
 
+	var matchmaking domain.MatchmakingSettings
+	if req.Matchmaking != nil {
+		matchmaking = toMatchmakingSettings(*req.Matchmaking)
+	}
+
 	lobby, err := h.lobbyService.CreateLobby(r.Context(), userID, service.CreateLobbyInput{
-		DraftMode:            draftMode,
-		TimerDurationSeconds: req.TimerDurationSeconds,
-		VotingEnabled:        req.VotingEnabled,
-		VotingMode:           votingMode,
+		DraftMode:             draftMode,
+		TimerDurationSeconds:  req.TimerDurationSeconds,
+		TradePhaseSeconds:     req.TradePhaseSeconds,
+		SpectatorDelaySeconds: req.SpectatorDelaySeconds,
+		TeamSize:              req.TeamSize,
+		VotingEnabled:         req.VotingEnabled,
+		VotingMode:            votingMode,
+		MatchmakingSettings:   matchmaking,
 	})
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownMatchmakingAlgorithm) {
+			http.Error(w, "Unknown matchmaking algorithm", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidBalanceWeights) {
+			http.Error(w, "Balance weights must be between 0 and 10", http.StatusBadRequest)
+			return
+		}
 		log.Printf("ERROR [lobby.Create] failed to create lobby: %v", err)
 		http.Error(w, "Failed to create lobby", http.StatusInternalServerError)
 		return
@@ -322,6 +428,10 @@ func (h *LobbyHandler) Leave(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Not in lobby", http.StatusBadRequest)
 			return
 		}
+		if errors.Is(err, service.ErrInvalidLobbyState) {
+			http.Error(w, "Only bench players can leave once teams are selected", http.StatusConflict)
+			return
+		}
 		log.Printf("ERROR [lobby.Leave] failed to leave lobby: %v", err)
 		http.Error(w, "Failed to leave lobby", http.StatusInternalServerError)
 		return
@@ -413,8 +523,8 @@ func (h *LobbyHandler) GenerateTeams(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(lobby.Players) != 10 {
-		http.Error(w, "Lobby needs exactly 10 players", http.StatusBadRequest)
+	if !lobby.HasEnoughPlayers() {
+		http.Error(w, fmt.Sprintf("Lobby needs at least %d players", lobby.PlayersPerMatch()), http.StatusBadRequest)
 		return
 	}
 
@@ -427,6 +537,10 @@ func (h *LobbyHandler) GenerateTeams(w http.ResponseWriter, r *http.Request) {
 	oldStatus := string(lobby.Status)
 	options, err := h.matchmakingService.GenerateMatchOptions(r.Context(), lobbyID, players, 8)
 	if err != nil {
+		if errors.Is(err, service.ErrPreferencesUnsatisfiable) {
+			http.Error(w, "No team composition keeps every hard preference", http.StatusConflict)
+			return
+		}
 		log.Printf("ERROR [lobby.GenerateTeams] failed to generate teams: %v", err)
 		http.Error(w, "Failed to generate teams", http.StatusInternalServerError)
 		return
@@ -839,6 +953,146 @@ func (h *LobbyHandler) KickPlayer(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
 }
 
+// StartCaptainsDraft has two captains pick the teams in turn instead of generating options.
+// Picks are made over the lobby WebSocket
+func (h *LobbyHandler) StartCaptainsDraft(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	var req StartCaptainsDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.lobbyService.StartCaptainsDraft(r.Context(), lobbyID, userID, domain.CaptainSelection(req.CaptainSelection))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCaptainSelection):
+			http.Error(w, "Invalid captain selection", http.StatusBadRequest)
+		case errors.Is(err, service.ErrLobbyNotFound):
+			http.Error(w, "Lobby not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotInLobby):
+			http.Error(w, "Not in lobby", http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotCaptain):
+			http.Error(w, "Only captain can start a captain's draft", http.StatusForbidden)
+		case errors.Is(err, service.ErrInvalidLobbyState):
+			http.Error(w, "Cannot start a captain's draft in current state", http.StatusConflict)
+		case errors.Is(err, service.ErrNotEnoughPlayers):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotEnoughVolunteers):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			log.Printf("ERROR [lobby.StartCaptainsDraft] failed: %v", err)
+			http.Error(w, "Failed to start captain's draft", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.lobbyHub.BroadcastStatusChanged(lobbyID, string(domain.LobbyStatusWaitingForPlayers), string(domain.LobbyStatusCaptainsDraft))
+	h.lobbyHub.StartCaptainsDraft(r.Context(), lobbyID, draft)
+
+	lobby, _ := h.lobbyService.GetLobby(r.Context(), lobbyID.String())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
+}
+
+// SetChatMuted lets a captain mute or unmute a player in the lobby chat
+func (h *LobbyHandler) SetChatMuted(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	var req MuteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	targetUserID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.lobbyService.SetChatMuted(r.Context(), lobbyID, userID, targetUserID, req.Muted); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotInLobby):
+			http.Error(w, "Not in lobby", http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotCaptain):
+			http.Error(w, "Only captain can mute", http.StatusForbidden)
+		case errors.Is(err, service.ErrPlayerNotFound):
+			http.Error(w, "Player not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrCannotMuteCaptain):
+			http.Error(w, "Cannot mute a captain", http.StatusBadRequest)
+		default:
+			log.Printf("ERROR [lobby.SetChatMuted] failed: %v", err)
+			http.Error(w, "Failed to mute player", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	lobby, _ := h.lobbyService.GetLobby(r.Context(), lobbyID.String())
+	var mutedName, captainName string
+	for _, p := range lobby.Players {
+		if p.User == nil {
+			continue
+		}
+		if p.UserID == targetUserID {
+			mutedName = p.User.DisplayName
+		}
+		if p.UserID == userID {
+			captainName = p.User.DisplayName
+		}
+	}
+	h.lobbyHub.BroadcastChatMuted(r.Context(), lobbyID, mutedName, captainName, req.Muted)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
+}
+
+// GetChat returns the lobby chat history the user can read, so the draft room can show it
+func (h *LobbyHandler) GetChat(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	messages, err := h.lobbyService.GetChatHistory(r.Context(), lobbyID, userID)
+	if err != nil {
+		log.Printf("ERROR [lobby.GetChat] failed: %v", err)
+		http.Error(w, "Failed to get chat", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(messages)
+}
+
 // ==================== Pending Actions ====================
 
 func (h *LobbyHandler) ProposeSwap(w http.ResponseWriter, r *http.Request) {
@@ -920,6 +1174,72 @@ func (h *LobbyHandler) ProposeSwap(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(toPendingActionResponse(action))
 }
 
+func (h *LobbyHandler) ProposeSubstitute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	var req SubstituteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	outUserID, err := uuid.Parse(req.OutUserID)
+	if err != nil {
+		http.Error(w, "Invalid outgoing player ID", http.StatusBadRequest)
+		return
+	}
+	benchUserID, err := uuid.Parse(req.BenchUserID)
+	if err != nil {
+		http.Error(w, "Invalid bench player ID", http.StatusBadRequest)
+		return
+	}
+
+	action, err := h.lobbyService.ProposeSubstitute(r.Context(), lobbyID, userID, outUserID, benchUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotCaptain):
+			http.Error(w, "Only captain can propose a substitute", http.StatusForbidden)
+		case errors.Is(err, service.ErrNotInLobby):
+			http.Error(w, "You are not in this lobby", http.StatusForbidden)
+		case errors.Is(err, service.ErrPendingActionExists):
+			http.Error(w, "A pending action already exists", http.StatusConflict)
+		case errors.Is(err, service.ErrNotOnTeam):
+			http.Error(w, "Player not on your team", http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotOnBench):
+			http.Error(w, "Substitute must be on the bench", http.StatusBadRequest)
+		case errors.Is(err, service.ErrPlayerNotFound):
+			http.Error(w, "Player not found in lobby", http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidLobbyState):
+			http.Error(w, "Substitutes can only be made once teams are selected", http.StatusBadRequest)
+		case errors.Is(err, service.ErrLobbyNotFound):
+			http.Error(w, "Lobby not found", http.StatusNotFound)
+		default:
+			log.Printf("ERROR [lobby.ProposeSubstitute] failed: %v", err)
+			http.Error(w, "Failed to propose substitute", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Store in lobby state and broadcast to all clients
+	inMemoryAction := domainToInMemoryAction(action)
+	state := h.lobbyHub.GetLobbyState(lobbyID)
+	state.SetPendingAction(inMemoryAction)
+	h.lobbyHub.BroadcastActionProposed(lobbyID, inMemoryAction)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPendingActionResponse(action))
+}
+
 func (h *LobbyHandler) ProposeMatchmake(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -944,7 +1264,7 @@ func (h *LobbyHandler) ProposeMatchmake(w http.ResponseWriter, r *http.Request)
 			return
 		}
 		if errors.Is(err, service.ErrNotEnoughPlayers) {
-			http.Error(w, "Need 10 players", http.StatusBadRequest)
+			http.Error(w, "Not enough players for the lobby's format", http.StatusBadRequest)
 			return
 		}
 		if errors.Is(err, service.ErrPlayersNotReady) {
@@ -1111,6 +1431,10 @@ func (h *LobbyHandler) ApprovePendingAction(w http.ResponseWriter, r *http.Reque
 			http.Error(w, "Action not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, service.ErrNotOnTeam) || errors.Is(err, service.ErrNotOnBench) {
+			http.Error(w, "Players changed since the substitute was proposed", http.StatusConflict)
+			return
+		}
 		log.Printf("ERROR [lobby.ApprovePendingAction] failed: %v", err)
 		http.Error(w, "Failed to approve action", http.StatusInternalServerError)
 		return
@@ -1122,6 +1446,10 @@ func (h *LobbyHandler) ApprovePendingAction(w http.ResponseWriter, r *http.Reque
 		h.lobbyHub.BroadcastActionApproved(lobbyID, actionID, approvingSide, pendingAction.ApprovedByBlue || approvingSide == "blue", pendingAction.ApprovedByRed || approvingSide == "red")
 
 		if fullyApproved {
+			if pendingAction.ActionType == string(domain.PendingActionSubstitutePlayer) {
+				h.substituteInRoom(r.Context(), lobbyID, pendingAction)
+			}
+
 			// Action was executed by the service, broadcast execution and clear state
 			h.lobbyHub.BroadcastActionExecuted(lobbyID, pendingAction.ActionType, nil)
 			// Broadcast full lobby update to sync all clients with the new state
@@ -1134,6 +1462,29 @@ func (h *LobbyHandler) ApprovePendingAction(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
 }
 
+// substituteInRoom updates a running team draft after a substitution was approved
+func (h *LobbyHandler) substituteInRoom(ctx context.Context, lobbyID uuid.UUID, action *websocket.InMemoryPendingAction) {
+	if action.Player1ID == nil || action.Player2ID == nil {
+		return
+	}
+
+	lobby, err := h.lobbyService.GetLobby(ctx, lobbyID.String())
+	if err != nil || lobby.RoomID == nil {
+		return
+	}
+	room := h.hub.GetRoom(lobby.RoomID.String())
+	if room == nil {
+		return
+	}
+
+	substitute, err := h.lobbyService.GetRoomPlayer(ctx, *lobby.RoomID, *action.Player2ID)
+	if err != nil {
+		log.Printf("ERROR [lobby.substituteInRoom] failed to get room player: %v", err)
+		return
+	}
+	room.SubstitutePlayer(*action.Player1ID, substitute)
+}
+
 func (h *LobbyHandler) CancelPendingAction(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -1383,6 +1734,180 @@ func (h *LobbyHandler) StartVoting(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
 }
 
+// UpdateMatchmakingSettings changes the algorithms and weights used the next time teams are generated
+func (h *LobbyHandler) UpdateMatchmakingSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	var req MatchmakingSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lobby, err := h.lobbyService.UpdateMatchmakingSettings(r.Context(), lobbyID, userID, toMatchmakingSettings(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLobbyNotFound):
+			http.Error(w, "Lobby not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotInLobby):
+			http.Error(w, "Not in lobby", http.StatusForbidden)
+		case errors.Is(err, service.ErrNotCaptain):
+			http.Error(w, "Only captain can change matchmaking settings", http.StatusForbidden)
+		case errors.Is(err, service.ErrInvalidLobbyState):
+			http.Error(w, "Matchmaking settings can only change before a team is selected", http.StatusConflict)
+		case errors.Is(err, service.ErrUnknownMatchmakingAlgorithm):
+			http.Error(w, "Unknown matchmaking algorithm", http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidBalanceWeights):
+			http.Error(w, "Balance weights must be between 0 and 10", http.StatusBadRequest)
+		default:
+			log.Printf("ERROR [lobby.UpdateMatchmakingSettings] failed: %v", err)
+			http.Error(w, "Failed to update matchmaking settings", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.lobbyHub.BroadcastLobbyUpdate(r.Context(), lobbyID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toLobbyResponse(lobby))
+}
+
+func (h *LobbyHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	prefs, err := h.lobbyService.GetPreferences(r.Context(), lobbyID)
+	if err != nil {
+		log.Printf("ERROR [lobby.GetPreferences] failed: %v", err)
+		http.Error(w, "Failed to get preferences", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]PreferenceResponse, len(prefs))
+	for i, pref := range prefs {
+		resp[i] = toPreferenceResponse(pref)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *LobbyHandler) AddPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	var req AddPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := service.AddPreferenceInput{
+		Type:   domain.PreferenceType(req.Type),
+		IsHard: req.IsHard,
+	}
+	if req.TargetUserID != nil {
+		targetUserID, err := uuid.Parse(*req.TargetUserID)
+		if err != nil {
+			http.Error(w, "Invalid target user ID", http.StatusBadRequest)
+			return
+		}
+		input.TargetUserID = &targetUserID
+	}
+	for _, role := range req.Roles {
+		input.Roles = append(input.Roles, domain.Role(role))
+	}
+
+	pref, err := h.lobbyService.AddPreference(r.Context(), lobbyID, userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLobbyNotFound):
+			http.Error(w, "Lobby not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotInLobby):
+			http.Error(w, "Not in lobby", http.StatusForbidden)
+		case errors.Is(err, service.ErrPlayerNotFound):
+			http.Error(w, "Target player is not in the lobby", http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidLobbyState):
+			http.Error(w, "Preferences can only change before a team is selected", http.StatusConflict)
+		case errors.Is(err, service.ErrTooManyPreferences):
+			http.Error(w, "Too many preferences", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidPreference):
+			http.Error(w, "Invalid preference", http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidRole):
+			http.Error(w, "Invalid role", http.StatusBadRequest)
+		default:
+			log.Printf("ERROR [lobby.AddPreference] failed: %v", err)
+			http.Error(w, "Failed to add preference", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.lobbyHub.BroadcastLobbyUpdate(r.Context(), lobbyID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toPreferenceResponse(pref))
+}
+
+func (h *LobbyHandler) RemovePreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	lobbyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid lobby ID", http.StatusBadRequest)
+		return
+	}
+
+	preferenceID, err := uuid.Parse(chi.URLParam(r, "preferenceId"))
+	if err != nil {
+		http.Error(w, "Invalid preference ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.lobbyService.RemovePreference(r.Context(), lobbyID, userID, preferenceID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrPreferenceNotFound):
+			http.Error(w, "Preference not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotPreferenceOwner):
+			http.Error(w, "Only the player, the lobby creator or a captain can remove this preference", http.StatusForbidden)
+		default:
+			log.Printf("ERROR [lobby.RemovePreference] failed: %v", err)
+			http.Error(w, "Failed to remove preference", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.lobbyHub.BroadcastLobbyUpdate(r.Context(), lobbyID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *LobbyHandler) EndVoting(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -1508,6 +2033,7 @@ func toLobbyResponse(lobby *domain.Lobby) LobbyResponse {
 			AssignedRole: role,
 			IsReady:      p.IsReady,
 			IsCaptain:    p.IsCaptain,
+			ChatMuted:    p.ChatMuted,
 			JoinOrder:    p.JoinOrder,
 		}
 	}
@@ -1518,19 +2044,71 @@ func toLobbyResponse(lobby *domain.Lobby) LobbyResponse {
 		votingDeadline = &s
 	}
 
+	roles := make([]string, 0, lobby.PlayersPerTeam())
+	for _, role := range lobby.Roles() {
+		roles = append(roles, string(role))
+	}
+
 	return LobbyResponse{
-		ID:                   lobby.ID.String(),
-		ShortCode:            lobby.ShortCode,
-		CreatedBy:            lobby.CreatedBy.String(),
-		Status:               string(lobby.Status),
-		SelectedMatchOption:  lobby.SelectedMatchOption,
-		DraftMode:            string(lobby.DraftMode),
-		TimerDurationSeconds: lobby.TimerDurationSeconds,
-		RoomID:               roomID,
-		VotingEnabled:        lobby.VotingEnabled,
-		VotingMode:           string(lobby.VotingMode),
-		VotingDeadline:       votingDeadline,
-		Players:              players,
+		ID:                    lobby.ID.String(),
+		ShortCode:             lobby.ShortCode,
+		CreatedBy:             lobby.CreatedBy.String(),
+		Status:                string(lobby.Status),
+		SelectedMatchOption:   lobby.SelectedMatchOption,
+		DraftMode:             string(lobby.DraftMode),
+		TimerDurationSeconds:  lobby.TimerDurationSeconds,
+		TradePhaseSeconds:     lobby.TradePhaseSeconds,
+		SpectatorDelaySeconds: lobby.SpectatorDelaySeconds,
+		TeamSize:              lobby.PlayersPerTeam(),
+		Roles:                 roles,
+		MaxPlayers:            lobby.MaxPlayers(),
+		RoomID:                roomID,
+		VotingEnabled:         lobby.VotingEnabled,
+		VotingMode:            string(lobby.VotingMode),
+		VotingDeadline:        votingDeadline,
+		Matchmaking:           toMatchmakingSettingsResponse(lobby),
+		Players:               players,
+	}
+}
+
+func toMatchmakingSettings(req MatchmakingSettingsRequest) domain.MatchmakingSettings {
+	var settings domain.MatchmakingSettings
+	for _, a := range req.Algorithms {
+		settings.Algorithms = append(settings.Algorithms, domain.AlgorithmType(a))
+	}
+	if req.Weights != nil {
+		settings.Weights = &domain.BalanceWeights{
+			MMRDiff:  req.Weights.MMRDiff,
+			Comfort:  req.Weights.Comfort,
+			LaneDiff: req.Weights.LaneDiff,
+		}
+	}
+	return settings
+}
+
+// toMatchmakingSettingsResponse reports the settings in effect, filling in the defaults
+func toMatchmakingSettingsResponse(lobby *domain.Lobby) MatchmakingSettingsResponse {
+	settings, err := lobby.GetMatchmakingSettings()
+	if err != nil {
+		log.Printf("ERROR [lobby] invalid matchmaking settings for lobby %s: %v", lobby.ID, err)
+	}
+
+	algorithms := []string{string(service.DefaultMatchmakingAlgorithm)}
+	if len(settings.Algorithms) > 0 {
+		algorithms = make([]string, len(settings.Algorithms))
+		for i, a := range settings.Algorithms {
+			algorithms[i] = string(a)
+		}
+	}
+
+	weights := settings.BalanceWeights()
+	return MatchmakingSettingsResponse{
+		Algorithms: algorithms,
+		Weights: BalanceWeights{
+			MMRDiff:  weights.MMRDiff,
+			Comfort:  weights.Comfort,
+			LaneDiff: weights.LaneDiff,
+		},
 	}
 }
 
@@ -1594,6 +2172,10 @@ func toMatchOptionResponse(opt *domain.MatchOption) MatchOptionResponse {
 		}
 	}
 
+	satisfiedIDs, unsatisfiedIDs, _ := opt.GetPreferenceResults()
+	satisfied := toPreferenceIDs(satisfiedIDs)
+	unsatisfied := toPreferenceIDs(unsatisfiedIDs)
+
 	return MatchOptionResponse{
 		OptionNumber:     opt.OptionNumber,
 		AlgorithmType:    string(opt.AlgorithmType),
@@ -1606,5 +2188,36 @@ func toMatchOptionResponse(opt *domain.MatchOption) MatchOptionResponse {
 		MaxLaneDiff:      opt.MaxLaneDiff,
 		UsedMmrThreshold: opt.UsedMmrThreshold,
 		Assignments:      assignments,
+
+		SatisfiedPreferences:   satisfied,
+		UnsatisfiedPreferences: unsatisfied,
+	}
+}
+
+func toPreferenceIDs(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
+
+func toPreferenceResponse(pref *domain.LobbyPreference) PreferenceResponse {
+	resp := PreferenceResponse{
+		ID:        pref.ID.String(),
+		UserID:    pref.UserID.String(),
+		Type:      string(pref.Type),
+		Roles:     []string{},
+		IsHard:    pref.IsHard,
+		CreatedAt: pref.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if pref.TargetUserID != nil {
+		target := pref.TargetUserID.String()
+		resp.TargetUserID = &target
+	}
+	roles, _ := pref.GetRoles()
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, string(role))
 	}
+	return resp
 }
@@ -18,6 +18,8 @@ type MatchHistoryHandler struct {
 	draftStateRepo  repository.DraftStateRepository
 	draftActionRepo repository.DraftActionRepository
 	roomPlayerRepo  repository.RoomPlayerRepository
+	assignmentRepo  repository.PickAssignmentRepository
+	chatRepo        repository.RoomChatMessageRepository
 }
 
 func NewMatchHistoryHandler(
@@ -25,27 +27,33 @@ func NewMatchHistoryHandler(
 	draftStateRepo repository.DraftStateRepository,
 	draftActionRepo repository.DraftActionRepository,
 	roomPlayerRepo repository.RoomPlayerRepository,
+	assignmentRepo repository.PickAssignmentRepository,
+	chatRepo repository.RoomChatMessageRepository,
 ) *MatchHistoryHandler {
 	return &MatchHistoryHandler{
 		roomRepo:        roomRepo,
 		draftStateRepo:  draftStateRepo,
 		draftActionRepo: draftActionRepo,
 		roomPlayerRepo:  roomPlayerRepo,
+		assignmentRepo:  assignmentRepo,
+		chatRepo:        chatRepo,
 	}
 }
 
 // MatchHistoryItem represents a summary of a completed match for list view
 type MatchHistoryItem struct {
-	ID          string           `json:"id"`
-	ShortCode   string           `json:"shortCode"`
-	DraftMode   string           `json:"draftMode"`
-	CompletedAt string           `json:"completedAt"`
-	IsTeamDraft bool             `json:"isTeamDraft"`
-	YourSide    string           `json:"yourSide"`
-	BluePicks   []string         `json:"bluePicks"`
-	RedPicks    []string         `json:"redPicks"`
-	BlueTeam    []MatchPlayerDTO `json:"blueTeam,omitempty"`
-	RedTeam     []MatchPlayerDTO `json:"redTeam,omitempty"`
+	ID           string           `json:"id"`
+	ShortCode    string           `json:"shortCode"`
+	DraftMode    string           `json:"draftMode"`
+	CompletedAt  string           `json:"completedAt"`
+	IsTeamDraft  bool             `json:"isTeamDraft"`
+	YourSide     string           `json:"yourSide"`
+	BluePicks    []string         `json:"bluePicks"`
+	RedPicks     []string         `json:"redPicks"`
+	BlueTeam     []MatchPlayerDTO `json:"blueTeam,omitempty"`
+	RedTeam      []MatchPlayerDTO `json:"redTeam,omitempty"`
+	WinnerSide   *string          `json:"winnerSide"`
+	ResultStatus string           `json:"resultStatus"`
 }
 
 // MatchPlayerDTO represents a player in a match
@@ -54,26 +62,31 @@ type MatchPlayerDTO struct {
 	DisplayName  string `json:"displayName"`
 	AssignedRole string `json:"assignedRole"`
 	IsCaptain    bool   `json:"isCaptain"`
+	// ChampionID and PlayedRole are what the player actually played after any post-draft trades
+	ChampionID string `json:"championId,omitempty"`
+	PlayedRole string `json:"playedRole,omitempty"`
 }
 
 // MatchDetailResponse represents the full detail of a completed match
 type MatchDetailResponse struct {
-	ID                   string            `json:"id"`
-	ShortCode            string            `json:"shortCode"`
-	DraftMode            string            `json:"draftMode"`
-	TimerDurationSeconds int               `json:"timerDurationSeconds"`
-	CreatedAt            string            `json:"createdAt"`
-	StartedAt            string            `json:"startedAt,omitempty"`
-	CompletedAt          string            `json:"completedAt,omitempty"`
-	IsTeamDraft          bool              `json:"isTeamDraft"`
-	YourSide             string            `json:"yourSide"`
-	BluePicks            []string          `json:"bluePicks"`
-	RedPicks             []string          `json:"redPicks"`
-	BlueBans             []string          `json:"blueBans"`
-	RedBans              []string          `json:"redBans"`
-	BlueTeam             []MatchPlayerDTO  `json:"blueTeam,omitempty"`
-	RedTeam              []MatchPlayerDTO  `json:"redTeam,omitempty"`
-	Actions              []DraftActionDTO  `json:"actions"`
+	ID                   string              `json:"id"`
+	ShortCode            string              `json:"shortCode"`
+	DraftMode            string              `json:"draftMode"`
+	TimerDurationSeconds int                 `json:"timerDurationSeconds"`
+	CreatedAt            string              `json:"createdAt"`
+	StartedAt            string              `json:"startedAt,omitempty"`
+	CompletedAt          string              `json:"completedAt,omitempty"`
+	IsTeamDraft          bool                `json:"isTeamDraft"`
+	YourSide             string              `json:"yourSide"`
+	BluePicks            []string            `json:"bluePicks"`
+	RedPicks             []string            `json:"redPicks"`
+	BlueBans             []string            `json:"blueBans"`
+	RedBans              []string            `json:"redBans"`
+	BlueTeam             []MatchPlayerDTO    `json:"blueTeam,omitempty"`
+	RedTeam              []MatchPlayerDTO    `json:"redTeam,omitempty"`
+	Actions              []DraftActionDTO    `json:"actions"`
+	Chat                 []ChatMessageDTO    `json:"chat"`
+	Result               MatchResultResponse `json:"result"`
 }
 
 // DraftActionDTO represents a single pick/ban action
@@ -85,6 +98,16 @@ type DraftActionDTO struct {
 	ActionTime string `json:"actionTime"`
 }
 
+// ChatMessageDTO represents a draft room chat message
+type ChatMessageDTO struct {
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Channel     string `json:"channel"`
+	Side        string `json:"side"`
+	Body        string `json:"body"`
+	CreatedAt   string `json:"createdAt"`
+}
+
 // List returns all completed matches
 func (h *MatchHistoryHandler) List(w http.ResponseWriter, r *http.Request) {
 	userID, _ := middleware.GetUserID(r.Context())
@@ -132,13 +155,17 @@ func (h *MatchHistoryHandler) List(w http.ResponseWriter, r *http.Request) {
 			RedPicks:    jsonToStringSlice(draftState.RedPicks),
 		}
 
+		result := toMatchResultResponse(room)
+		item.WinnerSide = result.WinnerSide
+		item.ResultStatus = result.Status
+
 		if room.CompletedAt != nil {
 			item.CompletedAt = room.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
 		}
 
 		// Add team players for team draft
 		if room.IsTeamDraft && len(room.Players) > 0 {
-			item.BlueTeam, item.RedTeam = categorizeTeamPlayers(room.Players)
+			item.BlueTeam, item.RedTeam = categorizeTeamPlayers(room.Players, h.getAssignments(r, room.ID))
 		}
 
 		items = append(items, item)
@@ -183,6 +210,7 @@ func (h *MatchHistoryHandler) GetDetail(w http.ResponseWriter, r *http.Request)
 		CreatedAt:            room.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		IsTeamDraft:          room.IsTeamDraft,
 		YourSide:             yourSide,
+		Result:               toMatchResultResponse(room),
 	}
 
 	if room.StartedAt != nil {
@@ -200,7 +228,7 @@ func (h *MatchHistoryHandler) GetDetail(w http.ResponseWriter, r *http.Request)
 	}
 
 	if room.IsTeamDraft && len(room.Players) > 0 {
-		resp.BlueTeam, resp.RedTeam = categorizeTeamPlayers(room.Players)
+		resp.BlueTeam, resp.RedTeam = categorizeTeamPlayers(room.Players, h.getAssignments(r, room.ID))
 	}
 
 	resp.Actions = make([]DraftActionDTO, 0, len(actions))
@@ -214,12 +242,58 @@ func (h *MatchHistoryHandler) GetDetail(w http.ResponseWriter, r *http.Request)
 		})
 	}
 
+	resp.Chat = h.getChat(r, room.ID, yourSide)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
 // Helper functions
 
+// getChat returns the room's chat that a player on the given side could read during the draft
+func (h *MatchHistoryHandler) getChat(r *http.Request, roomID uuid.UUID, side string) []ChatMessageDTO {
+	chat := []ChatMessageDTO{}
+	messages, err := h.chatRepo.GetByRoomID(r.Context(), roomID)
+	if err != nil {
+		log.Printf("WARN [matchHistory] failed to get chat for room %s: %v", roomID, err)
+		return chat
+	}
+
+	for _, msg := range messages {
+		if !msg.VisibleTo(side) {
+			continue
+		}
+		displayName := ""
+		if msg.User != nil {
+			displayName = msg.User.DisplayName
+		}
+		chat = append(chat, ChatMessageDTO{
+			UserID:      msg.UserID.String(),
+			DisplayName: displayName,
+			Channel:     string(msg.Channel),
+			Side:        string(msg.Side),
+			Body:        msg.Body,
+			CreatedAt:   msg.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return chat
+}
+
+// getAssignments returns who played each pick in a team draft, keyed by user
+func (h *MatchHistoryHandler) getAssignments(r *http.Request, roomID uuid.UUID) map[uuid.UUID]*domain.PickAssignment {
+	assignments, err := h.assignmentRepo.GetByRoomID(r.Context(), roomID)
+	if err != nil {
+		log.Printf("WARN [matchHistory] failed to get pick assignments for room %s: %v", roomID, err)
+		return nil
+	}
+
+	byUser := make(map[uuid.UUID]*domain.PickAssignment, len(assignments))
+	for _, a := range assignments {
+		byUser[a.UserID] = a
+	}
+	return byUser
+}
+
 func determineSide(userID uuid.UUID, room *domain.Room) string {
 	// Check if user is in team players
 	for _, p := range room.Players {
@@ -262,7 +336,7 @@ func userHasAccessToRoom(userID uuid.UUID, room *domain.Room) bool {
 	return false
 }
 
-func categorizeTeamPlayers(players []domain.RoomPlayer) ([]MatchPlayerDTO, []MatchPlayerDTO) {
+func categorizeTeamPlayers(players []domain.RoomPlayer, assignments map[uuid.UUID]*domain.PickAssignment) ([]MatchPlayerDTO, []MatchPlayerDTO) {
 	var blueTeam, redTeam []MatchPlayerDTO
 
 	for _, p := range players {
@@ -272,6 +346,10 @@ func categorizeTeamPlayers(players []domain.RoomPlayer) ([]MatchPlayerDTO, []Mat
 			AssignedRole: string(p.AssignedRole),
 			IsCaptain:    p.IsCaptain,
 		}
+		if a, ok := assignments[p.UserID]; ok {
+			dto.ChampionID = a.ChampionID
+			dto.PlayedRole = string(a.Role)
+		}
 		if p.Team == domain.SideBlue {
 			blueTeam = append(blueTeam, dto)
 		} else if p.Team == domain.SideRed {
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dom/league-draft-website/internal/api/middleware"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type MatchResultHandler struct {
+	roomService   *service.RoomService
+	resultService *service.MatchResultService
+}
+
+func NewMatchResultHandler(roomService *service.RoomService, resultService *service.MatchResultService) *MatchResultHandler {
+	return &MatchResultHandler{
+		roomService:   roomService,
+		resultService: resultService,
+	}
+}
+
+type ReportResultRequest struct {
+	// Winner is "blue" or "red"
+	Winner string `json:"winner"`
+}
+
+// MatchResultResponse is the reported outcome of a room's game
+type MatchResultResponse struct {
+	RoomID       string  `json:"roomId"`
+	Status       string  `json:"status"`
+	WinnerSide   *string `json:"winnerSide"`
+	ReportedBy   *string `json:"reportedBy"`
+	ReporterSide *string `json:"reporterSide"`
+	ReportedAt   string  `json:"reportedAt,omitempty"`
+	ConfirmedAt  string  `json:"confirmedAt,omitempty"`
+}
+
+// Report submits the winning side of a completed draft's game
+func (h *MatchResultHandler) Report(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ReportResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.handle(w, r, "report", func(roomID uuid.UUID) (*domain.Room, error) {
+		return h.resultService.ReportResult(r.Context(), roomID, userID, domain.Side(req.Winner))
+	})
+}
+
+// Confirm accepts the result reported by the other side
+func (h *MatchResultHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	h.handle(w, r, "confirm", func(roomID uuid.UUID) (*domain.Room, error) {
+		return h.resultService.ConfirmResult(r.Context(), roomID, userID)
+	})
+}
+
+// Dispute flags the result reported by the other side as disputed
+func (h *MatchResultHandler) Dispute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	h.handle(w, r, "dispute", func(roomID uuid.UUID) (*domain.Room, error) {
+		return h.resultService.DisputeResult(r.Context(), roomID, userID)
+	})
+}
+
+// handle resolves the room, runs the result action and writes the updated result
+func (h *MatchResultHandler) handle(w http.ResponseWriter, r *http.Request, action string, fn func(roomID uuid.UUID) (*domain.Room, error)) {
+	room, err := h.roomService.GetRoom(r.Context(), chi.URLParam(r, "idOrCode"))
+	if err != nil {
+		if errors.Is(err, service.ErrRoomNotFound) {
+			http.Error(w, "Room not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	updated, err := fn(room.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRoomNotFound):
+			http.Error(w, "Room not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrInvalidGameWinner):
+			http.Error(w, "Winner must be blue or red", http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotRoomCaptain):
+			http.Error(w, "Only a captain of either side can report results", http.StatusForbidden)
+		case errors.Is(err, service.ErrDraftNotCompleted):
+			http.Error(w, "Draft is not completed", http.StatusConflict)
+		case errors.Is(err, service.ErrResultAlreadyConfirmed):
+			http.Error(w, "Result is already confirmed", http.StatusConflict)
+		case errors.Is(err, service.ErrNoPendingResult):
+			http.Error(w, "No result is awaiting confirmation", http.StatusConflict)
+		case errors.Is(err, service.ErrOwnResultReport):
+			http.Error(w, "The other side must confirm the result", http.StatusConflict)
+		default:
+			log.Printf("ERROR [matchResult.%s] failed for room %s: %v", action, room.ID, err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toMatchResultResponse(updated))
+}
+
+func toMatchResultResponse(room *domain.Room) MatchResultResponse {
+	resp := MatchResultResponse{
+		RoomID: room.ID.String(),
+		Status: string(room.ResultStatus),
+	}
+	if resp.Status == "" {
+		resp.Status = string(domain.ResultStatusUnreported)
+	}
+	if room.WinnerSide != nil {
+		winner := string(*room.WinnerSide)
+		resp.WinnerSide = &winner
+	}
+	if room.ResultReportedBy != nil {
+		id := room.ResultReportedBy.String()
+		resp.ReportedBy = &id
+	}
+	if room.ResultReporterSide != nil {
+		side := string(*room.ResultReporterSide)
+		resp.ReporterSide = &side
+	}
+	if room.ResultReportedAt != nil {
+		resp.ReportedAt = room.ResultReportedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if room.ResultConfirmedAt != nil {
+		resp.ConfirmedAt = room.ResultConfirmedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
@@ -77,6 +77,7 @@ func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		User: UserResponse{
 			ID:          profile.User.ID.String(),
 			DisplayName: profile.User.DisplayName,
+			Role:        string(profile.User.Role),
 		},
 		RoleProfiles: roleProfiles,
 	}
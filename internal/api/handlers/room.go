@@ -15,33 +15,57 @@ import (
 	"gorm.io/gorm"
 )
 
+// maxReserveTimeSeconds caps the chess-clock reserve a room can be created with
+const maxReserveTimeSeconds = 600
+
+// maxTradePhaseSeconds caps the post-draft trade phase of team drafts
+const maxTradePhaseSeconds = 300
+
+// maxSpectatorDelaySeconds caps how far behind the draft spectators can be shown
+const maxSpectatorDelaySeconds = 120
+
 type RoomHandler struct {
-	roomService    *service.RoomService
-	hub            *websocket.Hub
-	roomPlayerRepo repository.RoomPlayerRepository
+	roomService     *service.RoomService
+	templateService *service.DraftTemplateService
+	hub             *websocket.Hub
+	roomPlayerRepo  repository.RoomPlayerRepository
 }
 
-func NewRoomHandler(roomService *service.RoomService, hub *websocket.Hub, roomPlayerRepo repository.RoomPlayerRepository) *RoomHandler {
+func NewRoomHandler(roomService *service.RoomService, templateService *service.DraftTemplateService, hub *websocket.Hub, roomPlayerRepo repository.RoomPlayerRepository) *RoomHandler {
 	return &RoomHandler{
-		roomService:    roomService,
-		hub:            hub,
-		roomPlayerRepo: roomPlayerRepo,
+		roomService:     roomService,
+		templateService: templateService,
+		hub:             hub,
+		roomPlayerRepo:  roomPlayerRepo,
 	}
 }
 
 type CreateRoomRequest struct {
 	DraftMode     string `json:"draftMode"`
 	TimerDuration int    `json:"timerDuration"`
+	// DraftTemplate is a template name or ID; empty uses the default pro play order
+	DraftTemplate string `json:"draftTemplate,omitempty"`
+	// ReserveTime is each side's chess-clock reserve in seconds; 0 disables it
+	ReserveTime int `json:"reserveTime,omitempty"`
+	// BotSide is "blue" or "red" to practice against the bot on that side
+	BotSide string `json:"botSide,omitempty"`
+	// BotOnTimeout lets the bot draft for a player whose timer runs out
+	BotOnTimeout bool `json:"botOnTimeout,omitempty"`
+	// SpectatorDelay holds spectators this many seconds behind the draft; 0 shows it live
+	SpectatorDelay int `json:"spectatorDelay,omitempty"`
 }
 
 type RoomResponse struct {
-	ID                   string  `json:"id"`
-	ShortCode            string  `json:"shortCode"`
-	DraftMode            string  `json:"draftMode"`
-	TimerDurationSeconds int     `json:"timerDurationSeconds"`
-	Status               string  `json:"status"`
-	BlueSideUserID       *string `json:"blueSideUserId"`
-	RedSideUserID        *string `json:"redSideUserId"`
+	ID                    string  `json:"id"`
+	ShortCode             string  `json:"shortCode"`
+	DraftMode             string  `json:"draftMode"`
+	TimerDurationSeconds  int     `json:"timerDurationSeconds"`
+	ReserveTimeSeconds    int     `json:"reserveTimeSeconds"`
+	SpectatorDelaySeconds int     `json:"spectatorDelaySeconds"`
+	Status                string  `json:"status"`
+	BlueSideUserID        *string `json:"blueSideUserId"`
+	RedSideUserID         *string `json:"redSideUserId"`
+	DraftTemplateID       *string `json:"draftTemplateId"`
 }
 
 type JoinRoomRequest struct {
@@ -77,10 +101,49 @@ func (h *RoomHandler) Create(w http.ResponseWriter, r *http.Request) {
 		timerDuration = req.TimerDuration
 	}
 
+	if req.ReserveTime < 0 || req.ReserveTime > maxReserveTimeSeconds {
+		http.Error(w, "Reserve time must be between 0 and 600 seconds", http.StatusBadRequest)
+		return
+	}
+
+	if req.SpectatorDelay < 0 || req.SpectatorDelay > maxSpectatorDelaySeconds {
+		http.Error(w, "Spectator delay must be between 0 and 120 seconds", http.StatusBadRequest)
+		return
+	}
+
+	var botSide *domain.Side
+	if req.BotSide != "" {
+		side := domain.Side(req.BotSide)
+		if side != domain.SideBlue && side != domain.SideRed {
+			http.Error(w, "Bot side must be blue or red", http.StatusBadRequest)
+			return
+		}
+		botSide = &side
+	}
+
+	var templateID *uuid.UUID
+	if req.DraftTemplate != "" {
+		template, err := h.templateService.GetTemplate(r.Context(), req.DraftTemplate)
+		if err != nil {
+			if errors.Is(err, service.ErrDraftTemplateNotFound) {
+				http.Error(w, "Draft template not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to create room", http.StatusInternalServerError)
+			return
+		}
+		templateID = &template.ID
+	}
+
 	room, err := h.roomService.CreateRoom(r.Context(), service.CreateRoomInput{
-		CreatedBy:     userID,
-		DraftMode:     draftMode,
-		TimerDuration: timerDuration,
+		CreatedBy:       userID,
+		DraftMode:       draftMode,
+		TimerDuration:   timerDuration,
+		DraftTemplateID: templateID,
+		ReserveTime:     req.ReserveTime,
+		BotSide:         botSide,
+		BotOnTimeout:    req.BotOnTimeout,
+		SpectatorDelay:  req.SpectatorDelay,
 	})
 	if err != nil {
 		http.Error(w, "Failed to create room", http.StatusInternalServerError)
@@ -91,11 +154,14 @@ func (h *RoomHandler) Create(w http.ResponseWriter, r *http.Request) {
 	h.hub.CreateRoom(room.ID, room.ShortCode, timerDuration*1000)
 
 	resp := RoomResponse{
-		ID:                   room.ID.String(),
-		ShortCode:            room.ShortCode,
-		DraftMode:            string(room.DraftMode),
-		TimerDurationSeconds: room.TimerDurationSeconds,
-		Status:               string(room.Status),
+		ID:                    room.ID.String(),
+		ShortCode:             room.ShortCode,
+		DraftMode:             string(room.DraftMode),
+		TimerDurationSeconds:  room.TimerDurationSeconds,
+		ReserveTimeSeconds:    room.ReserveTimeSeconds,
+		SpectatorDelaySeconds: room.SpectatorDelaySeconds,
+		Status:                string(room.Status),
+		DraftTemplateID:       draftTemplateIDString(room),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -126,13 +192,16 @@ func (h *RoomHandler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := RoomResponse{
-		ID:                   room.ID.String(),
-		ShortCode:            room.ShortCode,
-		DraftMode:            string(room.DraftMode),
-		TimerDurationSeconds: room.TimerDurationSeconds,
-		Status:               string(room.Status),
-		BlueSideUserID:       blueSideUserID,
-		RedSideUserID:        redSideUserID,
+		ID:                    room.ID.String(),
+		ShortCode:             room.ShortCode,
+		DraftMode:             string(room.DraftMode),
+		TimerDurationSeconds:  room.TimerDurationSeconds,
+		ReserveTimeSeconds:    room.ReserveTimeSeconds,
+		SpectatorDelaySeconds: room.SpectatorDelaySeconds,
+		Status:                string(room.Status),
+		BlueSideUserID:        blueSideUserID,
+		RedSideUserID:         redSideUserID,
+		DraftTemplateID:       draftTemplateIDString(room),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -220,11 +289,13 @@ func (h *RoomHandler) Join(w http.ResponseWriter, r *http.Request) {
 
 	resp := JoinRoomResponse{
 		Room: RoomResponse{
-			ID:                   room.ID.String(),
-			ShortCode:            room.ShortCode,
-			DraftMode:            string(room.DraftMode),
-			TimerDurationSeconds: room.TimerDurationSeconds,
-			Status:               string(room.Status),
+			ID:                    room.ID.String(),
+			ShortCode:             room.ShortCode,
+			DraftMode:             string(room.DraftMode),
+			TimerDurationSeconds:  room.TimerDurationSeconds,
+			ReserveTimeSeconds:    room.ReserveTimeSeconds,
+			SpectatorDelaySeconds: room.SpectatorDelaySeconds,
+			Status:                string(room.Status),
 		},
 		YourSide:     string(assignedSide),
 		WebsocketURL: "/api/v1/ws",
@@ -260,13 +331,15 @@ func (h *RoomHandler) GetUserRooms(w http.ResponseWriter, r *http.Request) {
 		}
 
 		resp[i] = RoomResponse{
-			ID:                   room.ID.String(),
-			ShortCode:            room.ShortCode,
-			DraftMode:            string(room.DraftMode),
-			TimerDurationSeconds: room.TimerDurationSeconds,
-			Status:               string(room.Status),
-			BlueSideUserID:       blueSideUserID,
-			RedSideUserID:        redSideUserID,
+			ID:                    room.ID.String(),
+			ShortCode:             room.ShortCode,
+			DraftMode:             string(room.DraftMode),
+			TimerDurationSeconds:  room.TimerDurationSeconds,
+			ReserveTimeSeconds:    room.ReserveTimeSeconds,
+			SpectatorDelaySeconds: room.SpectatorDelaySeconds,
+			Status:                string(room.Status),
+			BlueSideUserID:        blueSideUserID,
+			RedSideUserID:         redSideUserID,
 		}
 	}
 
@@ -284,11 +357,13 @@ func (h *RoomHandler) GetByCode(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := RoomResponse{
-		ID:                   room.ID.String(),
-		ShortCode:            room.ShortCode,
-		DraftMode:            string(room.DraftMode),
-		TimerDurationSeconds: room.TimerDurationSeconds,
-		Status:               string(room.Status),
+		ID:                    room.ID.String(),
+		ShortCode:             room.ShortCode,
+		DraftMode:             string(room.DraftMode),
+		TimerDurationSeconds:  room.TimerDurationSeconds,
+		ReserveTimeSeconds:    room.ReserveTimeSeconds,
+		SpectatorDelaySeconds: room.SpectatorDelaySeconds,
+		Status:                string(room.Status),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -299,3 +374,11 @@ func parseUUID(s string) uuid.UUID {
 	id, _ := uuid.Parse(s)
 	return id
 }
+
+func draftTemplateIDString(room *domain.Room) *string {
+	if room.DraftTemplateID == nil {
+		return nil
+	}
+	id := room.DraftTemplateID.String()
+	return &id
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dom/league-draft-website/internal/api/middleware"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type SeriesHandler struct {
+	seriesService   *service.SeriesService
+	templateService *service.DraftTemplateService
+	hub             *websocket.Hub
+}
+
+func NewSeriesHandler(seriesService *service.SeriesService, templateService *service.DraftTemplateService, hub *websocket.Hub) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService:   seriesService,
+		templateService: templateService,
+		hub:             hub,
+	}
+}
+
+type CreateSeriesRequest struct {
+	Format        string  `json:"format"`
+	DraftMode     string  `json:"draftMode"`
+	TimerDuration int     `json:"timerDuration"`
+	RoomID        *string `json:"roomId,omitempty"`
+	DraftTemplate string  `json:"draftTemplate,omitempty"`
+	ReserveTime   int     `json:"reserveTime,omitempty"`
+}
+
+type NextGameRequest struct {
+	// Winner is optional; if given it must match the game's confirmed result
+	Winner string `json:"winner"`
+}
+
+type SeriesResponse struct {
+	ID                   string               `json:"id"`
+	Format               string               `json:"format"`
+	DraftMode            string               `json:"draftMode"`
+	TimerDurationSeconds int                  `json:"timerDurationSeconds"`
+	Status               string               `json:"status"`
+	BlueSideUserID       *string              `json:"blueSideUserId"`
+	RedSideUserID        *string              `json:"redSideUserId"`
+	IsTeamDraft          bool                 `json:"isTeamDraft"`
+	DraftTemplateID      *string              `json:"draftTemplateId"`
+	CurrentGame          int                  `json:"currentGame"`
+	BlueWins             int                  `json:"blueWins"`
+	RedWins              int                  `json:"redWins"`
+	WinnerSide           *string              `json:"winnerSide"`
+	Games                []SeriesGameResponse `json:"games"`
+}
+
+type SeriesGameResponse struct {
+	GameNumber int    `json:"gameNumber"`
+	RoomID     string `json:"roomId"`
+	ShortCode  string `json:"shortCode"`
+	Status     string `json:"status"`
+}
+
+func (h *SeriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("ERROR [series.Create] failed to decode request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	format := domain.SeriesFormatBo1
+	if req.Format != "" {
+		format = domain.SeriesFormat(req.Format)
+	}
+
+	draftMode := domain.DraftModeProPlay
+	if req.DraftMode == "fearless" {
+		draftMode = domain.DraftModeFearless
+	}
+
+	timerDuration := 30
+	if req.TimerDuration > 0 {
+		timerDuration = req.TimerDuration
+	}
+
+	var roomID *uuid.UUID
+	if req.RoomID != nil {
+		parsed, err := uuid.Parse(*req.RoomID)
+		if err != nil {
+			http.Error(w, "Invalid room ID", http.StatusBadRequest)
+			return
+		}
+		roomID = &parsed
+	}
+
+	if req.ReserveTime < 0 || req.ReserveTime > maxReserveTimeSeconds {
+		http.Error(w, "Reserve time must be between 0 and 600 seconds", http.StatusBadRequest)
+		return
+	}
+
+	var templateID *uuid.UUID
+	if req.DraftTemplate != "" {
+		template, err := h.templateService.GetTemplate(r.Context(), req.DraftTemplate)
+		if err != nil {
+			if errors.Is(err, service.ErrDraftTemplateNotFound) {
+				http.Error(w, "Draft template not found", http.StatusBadRequest)
+				return
+			}
+			log.Printf("ERROR [series.Create] failed to get draft template: %v", err)
+			http.Error(w, "Failed to create series", http.StatusInternalServerError)
+			return
+		}
+		templateID = &template.ID
+	}
+
+	series, room, err := h.seriesService.CreateSeries(r.Context(), service.CreateSeriesInput{
+		CreatedBy:       userID,
+		Format:          format,
+		DraftMode:       draftMode,
+		TimerDuration:   timerDuration,
+		DraftTemplateID: templateID,
+		ReserveTime:     req.ReserveTime,
+		RoomID:          roomID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSeriesFormat):
+			http.Error(w, "Format must be bo1, bo3 or bo5", http.StatusBadRequest)
+		case errors.Is(err, service.ErrRoomNotFound):
+			http.Error(w, "Room not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrNotSeriesParticipant):
+			http.Error(w, "Only room participants can create a series from it", http.StatusForbidden)
+		case errors.Is(err, service.ErrRoomAlreadyInSeries):
+			http.Error(w, "Room already belongs to a series", http.StatusConflict)
+		default:
+			log.Printf("ERROR [series.Create] failed to create series: %v", err)
+			http.Error(w, "Failed to create series", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Create the WebSocket room for game 1 unless it is already live. A live room that hadn't
+	// finished when it was wrapped needs to learn its series; finished ones were backfilled
+	if h.hub.GetRoom(room.ID.String()) == nil {
+		h.hub.CreateRoom(room.ID, room.ShortCode, room.TimerDurationSeconds*1000)
+	} else if room.Status != domain.RoomStatusCompleted {
+		h.hub.SetRoomSeries(room.ID, series.ID, room.GameNumber)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toSeriesResponse(series, []*domain.Room{room}))
+}
+
+func (h *SeriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		return
+	}
+
+	series, games, err := h.seriesService.GetSeries(r.Context(), seriesID)
+	if err != nil {
+		if errors.Is(err, service.ErrSeriesNotFound) {
+			http.Error(w, "Series not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [series.Get] failed to get series: %v", err)
+		http.Error(w, "Failed to get series", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSeriesResponse(series, games))
+}
+
+func (h *SeriesHandler) NextGame(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	seriesID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		return
+	}
+
+	var req NextGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("ERROR [series.NextGame] failed to decode request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Remember the game being finished so its players can be moved on
+	current, games, err := h.seriesService.GetSeries(r.Context(), seriesID)
+	if err != nil {
+		if errors.Is(err, service.ErrSeriesNotFound) {
+			http.Error(w, "Series not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ERROR [series.NextGame] failed to get series: %v", err)
+		http.Error(w, "Failed to start next game", http.StatusInternalServerError)
+		return
+	}
+
+	series, next, err := h.seriesService.StartNextGame(r.Context(), seriesID, userID, domain.Side(req.Winner))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSeriesNotFound):
+			http.Error(w, "Series not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrInvalidGameWinner):
+			http.Error(w, "Winner must be blue or red", http.StatusBadRequest)
+		case errors.Is(err, service.ErrNotSeriesParticipant):
+			http.Error(w, "Only series participants can start the next game", http.StatusForbidden)
+		case errors.Is(err, service.ErrSeriesCompleted):
+			http.Error(w, "Series is already completed", http.StatusConflict)
+		case errors.Is(err, service.ErrGameNotFinished):
+			http.Error(w, "Current game draft is not finished", http.StatusConflict)
+		case errors.Is(err, service.ErrResultNotConfirmed):
+			http.Error(w, "Both sides must confirm the current game's result first", http.StatusConflict)
+		case errors.Is(err, service.ErrSeriesAlreadyMovedOn):
+			http.Error(w, "The series has already moved on to the next game", http.StatusConflict)
+		case errors.Is(err, service.ErrResultConflict):
+			http.Error(w, "Winner does not match the game's confirmed result", http.StatusConflict)
+		default:
+			log.Printf("ERROR [series.NextGame] failed to start next game: %v", err)
+			http.Error(w, "Failed to start next game", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	payload := websocket.SeriesUpdatedPayload{
+		SeriesID: series.ID.String(),
+		Format:   string(series.Format),
+		Status:   string(series.Status),
+		BlueWins: series.BlueWins,
+		RedWins:  series.RedWins,
+	}
+	if series.WinnerSide != nil {
+		payload.WinnerSide = string(*series.WinnerSide)
+	}
+
+	if next != nil {
+		// Create WebSocket room for the next game before moving players into it
+		h.hub.CreateRoom(next.ID, next.ShortCode, next.TimerDurationSeconds*1000)
+		payload.NextGame = &websocket.SeriesGameInfo{
+			GameNumber: next.GameNumber,
+			RoomID:     next.ID.String(),
+			ShortCode:  next.ShortCode,
+		}
+		games = append(games, next)
+	}
+
+	// Notify the finished game's room (and move its players on)
+	for _, game := range games {
+		if game.GameNumber == current.CurrentGame {
+			h.hub.NotifySeriesUpdated(game.ID, payload)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSeriesResponse(series, games))
+}
+
+func toSeriesResponse(series *domain.Series, games []*domain.Room) SeriesResponse {
+	resp := SeriesResponse{
+		ID:                   series.ID.String(),
+		Format:               string(series.Format),
+		DraftMode:            string(series.DraftMode),
+		TimerDurationSeconds: series.TimerDurationSeconds,
+		Status:               string(series.Status),
+		IsTeamDraft:          series.IsTeamDraft,
+		CurrentGame:          series.CurrentGame,
+		BlueWins:             series.BlueWins,
+		RedWins:              series.RedWins,
+		Games:                make([]SeriesGameResponse, 0, len(games)),
+	}
+
+	if series.BlueSideUserID != nil {
+		id := series.BlueSideUserID.String()
+		resp.BlueSideUserID = &id
+	}
+	if series.RedSideUserID != nil {
+		id := series.RedSideUserID.String()
+		resp.RedSideUserID = &id
+	}
+	if series.WinnerSide != nil {
+		winner := string(*series.WinnerSide)
+		resp.WinnerSide = &winner
+	}
+	if series.DraftTemplateID != nil {
+		id := series.DraftTemplateID.String()
+		resp.DraftTemplateID = &id
+	}
+
+	for _, game := range games {
+		resp.Games = append(resp.Games, SeriesGameResponse{
+			GameNumber: game.GameNumber,
+			RoomID:     game.ID.String(),
+			ShortCode:  game.ShortCode,
+			Status:     string(game.Status),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/api/handlers"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesHandler_CreateRequiresOrganizer(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	login := func(role domain.UserRole) string {
+		user, password := testutil.NewUserBuilder().WithRole(role).Build(t, ts.DB.DB)
+		result, err := ts.Services.Auth.Login(ctx, service.LoginInput{DisplayName: user.DisplayName, Password: password})
+		require.NoError(t, err)
+		return result.AccessToken
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			name:           "organizer",
+			token:          login(domain.UserRoleOrganizer),
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "admin",
+			token:          login(domain.UserRoleAdmin),
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "player",
+			token:          login(domain.UserRolePlayer),
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := handlers.CreateSeriesRequest{Format: "bo3"}
+			req := testutil.CreateAuthenticatedRequest(t, "POST", ts.APIURL("/series"), body, tt.token)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
@@ -1,73 +1,99 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/dom/league-draft-website/internal/api/middleware"
 	"github.com/dom/league-draft-website/internal/service"
 	"github.com/dom/league-draft-website/internal/websocket"
 	"github.com/google/uuid"
 	ws "github.com/gorilla/websocket"
 )
 
-var upgrader = ws.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow all origins for development
-	},
-}
-
 type WebSocketHandler struct {
-	hub         *websocket.Hub
-	lobbyHub    *websocket.LobbyHub
-	authService *service.AuthService
+	hub           *websocket.Hub
+	lobbyHub      *websocket.LobbyHub
+	ticketService *service.TicketService
+	upgrader      ws.Upgrader
 }
 
-func NewWebSocketHandler(hub *websocket.Hub, lobbyHub *websocket.LobbyHub, authService *service.AuthService) *WebSocketHandler {
+func NewWebSocketHandler(hub *websocket.Hub, lobbyHub *websocket.LobbyHub, ticketService *service.TicketService, allowedOrigins []string) *WebSocketHandler {
 	return &WebSocketHandler{
-		hub:         hub,
-		lobbyHub:    lobbyHub,
-		authService: authService,
+		hub:           hub,
+		lobbyHub:      lobbyHub,
+		ticketService: ticketService,
+		upgrader: ws.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(allowedOrigins),
+		},
 	}
 }
 
-func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	// Get token from query parameter
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		http.Error(w, "Token required", http.StatusUnauthorized)
+type WSTicketRequest struct {
+	Purpose string `json:"purpose"`
+}
+
+type WSTicketResponse struct {
+	Ticket    string `json:"ticket"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// IssueTicket gives the current user a single-use ticket for opening a draft or lobby websocket
+func (h *WebSocketHandler) IssueTicket(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	// Validate token
-	claims, err := h.authService.ValidateToken(token)
-	if err != nil {
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
+	var req WSTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	userIDStr, ok := (*claims)["sub"].(string)
-	if !ok {
-		http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+	ticket, err := h.ticketService.Issue(userID, service.TicketPurpose(req.Purpose))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTicketPurpose) {
+			http.Error(w, "Purpose must be draft or lobby", http.StatusBadRequest)
+			return
+		}
+		log.Printf("ERROR [websocket.IssueTicket] failed to issue ticket: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusUnauthorized)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(WSTicketResponse{
+		Ticket:    ticket.Ticket,
+		ExpiresAt: ticket.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.redeemTicket(w, r, service.TicketPurposeDraft)
+	if !ok {
 		return
 	}
 
 	// Upgrade to WebSocket
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
 		return
 	}
 
 	// Create client
-	client := websocket.NewClient(h.hub, conn, userID)
+	// Clients opt into the v2 protocol with ?v=2 or a HELLO frame
+	protocol := websocket.ParseProtocolVersion(r.URL.Query().Get("v"))
+	client := websocket.NewClient(h.hub, conn, userID, protocol)
 	h.hub.Register(client)
 
 	// Start goroutines
@@ -77,34 +103,13 @@ func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
 
 // HandleLobby handles WebSocket connections for lobby real-time updates
 func (h *WebSocketHandler) HandleLobby(w http.ResponseWriter, r *http.Request) {
-	// Get token from query parameter
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		http.Error(w, "Token required", http.StatusUnauthorized)
-		return
-	}
-
-	// Validate token
-	claims, err := h.authService.ValidateToken(token)
-	if err != nil {
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
-		return
-	}
-
-	userIDStr, ok := (*claims)["sub"].(string)
+	userID, ok := h.redeemTicket(w, r, service.TicketPurposeLobby)
 	if !ok {
-		http.Error(w, "Invalid token claims", http.StatusUnauthorized)
-		return
-	}
-
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusUnauthorized)
 		return
 	}
 
 	// Upgrade to WebSocket
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("Lobby WebSocket upgrade error: %v", err)
 		return
@@ -118,3 +123,44 @@ func (h *WebSocketHandler) HandleLobby(w http.ResponseWriter, r *http.Request) {
 	go client.WritePump()
 	go client.ReadPump()
 }
+
+// redeemTicket uses up the ?ticket= query parameter and returns its user, writing a 401 if it's missing or invalid
+func (h *WebSocketHandler) redeemTicket(w http.ResponseWriter, r *http.Request, purpose service.TicketPurpose) (uuid.UUID, bool) {
+	ticket := r.URL.Query().Get("ticket")
+	if ticket == "" {
+		http.Error(w, "Ticket required", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	userID, err := h.ticketService.Redeem(ticket, purpose)
+	if err != nil {
+		http.Error(w, "Invalid or expired ticket", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// checkOrigin allows websockets from the API's own origin, from the allowlist, and from
+// non-browser clients that send no Origin header
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if allowed[strings.ToLower(origin)] {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host)
+	}
+}
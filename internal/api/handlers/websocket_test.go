@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/api/handlers"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	ws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketHandler_Tickets(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	user, password := testutil.NewUserBuilder().Build(t, ts.DB.DB)
+	login, err := ts.Services.Auth.Login(ctx, service.LoginInput{DisplayName: user.DisplayName, Password: password})
+	require.NoError(t, err)
+
+	issue := func(purpose string) *http.Response {
+		req := testutil.CreateAuthenticatedRequest(t, "POST", ts.APIURL("/ws-ticket"), map[string]string{"purpose": purpose}, login.AccessToken)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	ticket := func(purpose string) string {
+		resp := issue(purpose)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body handlers.WSTicketResponse
+		testutil.AssertJSONResponse(t, resp, &body)
+		return body.Ticket
+	}
+	dial := func(path, origin string) (*ws.Conn, *http.Response, error) {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		return ws.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.APIURL(path), "http"), header)
+	}
+
+	resp := issue("spectate")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	// Access tokens are no longer accepted in the URL
+	_, resp, err = dial("/ws?token="+login.AccessToken, "")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// A lobby ticket doesn't open the draft websocket
+	_, resp, err = dial("/ws?ticket="+ticket("lobby"), "")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Origins outside the allowlist are rejected
+	_, resp, err = dial("/lobby-ws?ticket="+ticket("lobby"), "http://evil.example.com")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	draftTicket := ticket("draft")
+	conn, _, err := dial("/ws?ticket="+draftTicket, ts.BaseURL())
+	require.NoError(t, err)
+	conn.Close()
+
+	// Tickets only work once
+	_, resp, err = dial("/ws?ticket="+draftTicket, ts.BaseURL())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -13,7 +13,8 @@ import (
 type contextKey string
 
 const (
-	UserIDKey contextKey = "userID"
+	UserIDKey    contextKey = "userID"
+	SessionIDKey contextKey = "sessionID"
 )
 
 func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
@@ -55,6 +56,14 @@ func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
 			}
 
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+
+			// Tokens carry the session family they were issued for, so logout only ends this session
+			if sessionIDStr, ok := (*claims)["sid"].(string); ok {
+				if sessionID, err := uuid.Parse(sessionIDStr); err == nil {
+					ctx = context.WithValue(ctx, SessionIDKey, sessionID)
+				}
+			}
+
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -64,3 +73,8 @@ func GetUserID(ctx context.Context) (uuid.UUID, bool) {
 	userID, ok := ctx.Value(UserIDKey).(uuid.UUID)
 	return userID, ok
 }
+
+func GetSessionID(ctx context.Context) (uuid.UUID, bool) {
+	sessionID, ok := ctx.Value(SessionIDKey).(uuid.UUID)
+	return sessionID, ok
+}
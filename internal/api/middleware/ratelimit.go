@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/dom/league-draft-website/internal/ratelimit"
+)
+
+// RateKeyFunc picks the bucket a request is counted against
+type RateKeyFunc func(r *http.Request) string
+
+// ByIP counts requests against the client's IP address. Behind a reverse proxy this is
+// only the real client's IP if chi's RealIP middleware runs first
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// ByUser counts requests against the signed-in user, falling back to their IP. It must run after Auth
+func ByUser(r *http.Request) string {
+	if userID, ok := GetUserID(r.Context()); ok {
+		return "user:" + userID.String()
+	}
+	return ByIP(r)
+}
+
+// RateLimit rejects requests with 429 once their key has used up its token bucket
+func RateLimit(limiter *ratelimit.Limiter, key RateKeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !limiter.Limit().Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, wait := limiter.Allow(key(r))
+			if !allowed {
+				retryAfter := int(math.Ceil(wait.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
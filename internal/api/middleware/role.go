@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+)
+
+// RequireRole only lets users with at least the given role through. It must run after Auth.
+// The role is read from the database so a demotion takes effect immediately
+func RequireRole(authService *service.AuthService, role domain.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserID(r.Context())
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			user, err := authService.GetUserByID(r.Context(), userID)
+			if err != nil {
+				log.Printf("ERROR [middleware.RequireRole] failed to load user %s: %v", userID, err)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if !user.HasRole(role) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
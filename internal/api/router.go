@@ -6,6 +6,8 @@ import (
 	"github.com/dom/league-draft-website/internal/api/handlers"
 	"github.com/dom/league-draft-website/internal/api/middleware"
 	"github.com/dom/league-draft-website/internal/config"
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/ratelimit"
 	"github.com/dom/league-draft-website/internal/repository"
 	"github.com/dom/league-draft-website/internal/service"
 	"github.com/dom/league-draft-website/internal/websocket"
@@ -17,6 +19,9 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 	r := chi.NewRouter()
 
 	// Global middleware
+	if cfg.TrustProxy {
+		r.Use(chiMiddleware.RealIP)
+	}
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
 	r.Use(chiMiddleware.RequestID)
@@ -29,35 +34,52 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(services.Auth)
-	roomHandler := handlers.NewRoomHandler(services.Room, hub, repos.RoomPlayer)
+	roomHandler := handlers.NewRoomHandler(services.Room, services.DraftTemplate, hub, repos.RoomPlayer)
 	championHandler := handlers.NewChampionHandler(services.Champion)
 	profileHandler := handlers.NewProfileHandler(services.Profile)
 	lobbyHandler := handlers.NewLobbyHandler(services.Lobby, services.Matchmaking, hub, lobbyHub)
-	matchHistoryHandler := handlers.NewMatchHistoryHandler(repos.Room, repos.DraftState, repos.DraftAction, repos.RoomPlayer)
+	matchHistoryHandler := handlers.NewMatchHistoryHandler(repos.Room, repos.DraftState, repos.DraftAction, repos.RoomPlayer, repos.PickAssignment, repos.RoomChatMessage)
 	simulationHandler := handlers.NewSimulationHandler(repos.Room, repos.DraftState, repos.DraftAction, repos.RoomPlayer, cfg)
+	seriesHandler := handlers.NewSeriesHandler(services.Series, services.DraftTemplate, hub)
+	draftTemplateHandler := handlers.NewDraftTemplateHandler(services.DraftTemplate)
+	matchResultHandler := handlers.NewMatchResultHandler(services.Room, services.MatchResult)
 	pendingActionsHandler := handlers.NewPendingActionsHandler(repos.Lobby, repos.PendingAction, hub)
-	wsHandler := handlers.NewWebSocketHandler(hub, lobbyHub, services.Auth)
+	adminHandler := handlers.NewAdminHandler(services.Admin, services.Auth, hub, lobbyHub)
+	wsHandler := handlers.NewWebSocketHandler(hub, lobbyHub, services.Ticket, cfg.AllowedOrigins)
+
+	// Rate limiters
+	authLimit := middleware.RateLimit(ratelimit.NewLimiter(cfg.AuthRateLimit), middleware.ByIP)
+	accountLimit := middleware.RateLimit(ratelimit.NewLimiter(cfg.AuthRateLimit), middleware.ByUser)
+	createRoomLimit := middleware.RateLimit(ratelimit.NewLimiter(cfg.CreateRateLimit), middleware.ByUser)
+	createLobbyLimit := middleware.RateLimit(ratelimit.NewLimiter(cfg.CreateRateLimit), middleware.ByUser)
+
+	// Series and draft templates are set up by organizers
+	requireOrganizer := middleware.RequireRole(services.Auth, domain.UserRoleOrganizer)
 
 	// API v1 routes
 	r.Route("/api/v1", func(r chi.Router) {
 		// Public auth routes
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/register", authHandler.Register)
-			r.Post("/login", authHandler.Login)
+			r.With(authLimit).Post("/register", authHandler.Register)
+			r.With(authLimit).Post("/login", authHandler.Login)
+			r.With(authLimit).Post("/refresh", authHandler.Refresh)
+			r.With(authLimit).Post("/reset-password", authHandler.ResetPassword)
 
 			// Protected auth routes
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.Auth(services.Auth))
 				r.Get("/me", authHandler.Me)
 				r.Post("/logout", authHandler.Logout)
+				r.With(accountLimit).Put("/password", authHandler.ChangePassword)
+				r.Put("/display-name", authHandler.ChangeDisplayName)
+				r.With(accountLimit).Delete("/account", authHandler.DeleteAccount)
 			})
 		})
 
-		// Champion routes (public for now)
+		// Champion routes
 		r.Route("/champions", func(r chi.Router) {
 			r.Get("/", championHandler.GetAll)
 			r.Get("/{id}", championHandler.Get)
-			r.Post("/sync", championHandler.Sync) // Should be admin-only in production
 		})
 
 		// Protected routes
@@ -67,12 +89,34 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 			// Unified pending actions
 			r.Get("/pending-actions", pendingActionsHandler.GetAll)
 
+			// Single-use tickets for opening websockets
+			r.Post("/ws-ticket", wsHandler.IssueTicket)
+
 			// Room routes
 			r.Route("/rooms", func(r chi.Router) {
-				r.Post("/", roomHandler.Create)
+				r.With(createRoomLimit).Post("/", roomHandler.Create)
 				r.Get("/{idOrCode}", roomHandler.Get)
 				r.Post("/{idOrCode}/join", roomHandler.Join)
 				r.Get("/code/{code}", roomHandler.GetByCode)
+
+				// Game result reporting
+				r.Post("/{idOrCode}/result", matchResultHandler.Report)
+				r.Post("/{idOrCode}/result/confirm", matchResultHandler.Confirm)
+				r.Post("/{idOrCode}/result/dispute", matchResultHandler.Dispute)
+			})
+
+			// Series routes
+			r.Route("/series", func(r chi.Router) {
+				r.With(requireOrganizer).Post("/", seriesHandler.Create)
+				r.Get("/{id}", seriesHandler.Get)
+				r.Post("/{id}/next-game", seriesHandler.NextGame)
+			})
+
+			// Draft template routes
+			r.Route("/draft-templates", func(r chi.Router) {
+				r.Get("/", draftTemplateHandler.List)
+				r.With(requireOrganizer).Post("/", draftTemplateHandler.Create)
+				r.Get("/{idOrName}", draftTemplateHandler.Get)
 			})
 
 			// User routes
@@ -94,18 +138,23 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 				r.Get("/{roomId}", matchHistoryHandler.GetDetail)
 			})
 
-			// Simulation endpoint (development only)
-			r.Post("/simulate-match", simulationHandler.SimulateMatch)
+			// Simulation endpoint (admins only, never in production)
+			r.With(middleware.RequireRole(services.Auth, domain.UserRoleAdmin)).
+				Post("/simulate-match", simulationHandler.SimulateMatch)
 
 			// Lobby routes
 			r.Route("/lobbies", func(r chi.Router) {
-				r.Post("/", lobbyHandler.Create)
+				r.With(createLobbyLimit).Post("/", lobbyHandler.Create)
 				r.Get("/{idOrCode}", lobbyHandler.Get)
 				r.Post("/{idOrCode}/join", lobbyHandler.Join)
 				r.Post("/{idOrCode}/leave", lobbyHandler.Leave)
 				r.Post("/{idOrCode}/ready", lobbyHandler.SetReady)
 				r.Post("/{id}/generate-teams", lobbyHandler.GenerateTeams)
 				r.Post("/{id}/load-more-teams", lobbyHandler.LoadMoreTeams)
+				r.Post("/{id}/matchmaking-settings", lobbyHandler.UpdateMatchmakingSettings)
+				r.Get("/{id}/preferences", lobbyHandler.GetPreferences)
+				r.Post("/{id}/preferences", lobbyHandler.AddPreference)
+				r.Delete("/{id}/preferences/{preferenceId}", lobbyHandler.RemovePreference)
 				r.Get("/{id}/match-options", lobbyHandler.GetMatchOptions)
 				r.Post("/{id}/select-option", lobbyHandler.SelectOption)
 				r.Post("/{id}/start-draft", lobbyHandler.StartDraft)
@@ -114,12 +163,18 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 				r.Post("/{id}/take-captain", lobbyHandler.TakeCaptain)
 				r.Post("/{id}/promote-captain", lobbyHandler.PromoteCaptain)
 				r.Post("/{id}/kick", lobbyHandler.KickPlayer)
+				r.Post("/{id}/captains-draft", lobbyHandler.StartCaptainsDraft)
+				r.Post("/{id}/mute", lobbyHandler.SetChatMuted)
+
+				// Chat
+				r.Get("/{id}/chat", lobbyHandler.GetChat)
 
 				// Pending actions
 				r.Post("/{id}/swap", lobbyHandler.ProposeSwap)
 				r.Post("/{id}/propose-matchmake", lobbyHandler.ProposeMatchmake)
 				r.Post("/{id}/propose-select-option", lobbyHandler.ProposeSelectOption)
 				r.Post("/{id}/propose-start-draft", lobbyHandler.ProposeStartDraft)
+				r.Post("/{id}/propose-substitute", lobbyHandler.ProposeSubstitute)
 				r.Get("/{id}/pending-action", lobbyHandler.GetPendingAction)
 				r.Post("/{id}/pending-action/{actionId}/approve", lobbyHandler.ApprovePendingAction)
 				r.Post("/{id}/pending-action/{actionId}/cancel", lobbyHandler.CancelPendingAction)
@@ -135,7 +190,21 @@ func NewRouter(services *service.Services, hub *websocket.Hub, lobbyHub *websock
 			})
 		})
 
-		// WebSocket endpoints
+		// Admin routes
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.Auth(services.Auth))
+			r.Use(middleware.RequireRole(services.Auth, domain.UserRoleAdmin))
+
+			r.Post("/champions/sync", championHandler.Sync)
+			r.Get("/rooms", adminHandler.ListActiveRooms)
+			r.Post("/rooms/{id}/close", adminHandler.CloseRoom)
+			r.Delete("/lobbies/{id}", adminHandler.DeleteLobby)
+			r.Post("/users/{id}/reset-mmr", adminHandler.ResetUserMMR)
+			r.Put("/users/{id}/role", adminHandler.SetUserRole)
+			r.Post("/users/{id}/password-reset", adminHandler.IssuePasswordReset)
+		})
+
+		// WebSocket endpoints (authenticated with a ticket from /ws-ticket)
 		r.Get("/ws", wsHandler.Handle)
 		r.Get("/lobby-ws", wsHandler.HandleLobby)
 	})
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CaptainSelection is how the two captains of a captain's draft are chosen
+type CaptainSelection string
+
+const (
+	// CaptainSelectionHighestMMR makes the two highest rated players captains
+	CaptainSelectionHighestMMR CaptainSelection = "highest_mmr"
+	// CaptainSelectionVolunteer keeps the players who took captain on each team
+	CaptainSelectionVolunteer CaptainSelection = "volunteer"
+	// CaptainSelectionRandom makes two random players captains
+	CaptainSelectionRandom CaptainSelection = "random"
+)
+
+// IsValid returns true if the selection is a known way of choosing captains
+func (s CaptainSelection) IsValid() bool {
+	switch s {
+	case CaptainSelectionHighestMMR, CaptainSelectionVolunteer, CaptainSelectionRandom:
+		return true
+	}
+	return false
+}
+
+// CaptainPickDuration is how long a captain has to pick before the best rated player left is picked for them
+const CaptainPickDuration = 30 * time.Second
+
+// CaptainPickSide returns the side making a pick, counting picks from 0.
+// Blue picks once, then the captains alternate two picks at a time: 1-2-2-2-1 in 5v5
+func CaptainPickSide(pick int) Side {
+	if ((pick+1)/2)%2 == 0 {
+		return SideBlue
+	}
+	return SideRed
+}
+
+// CaptainsDraft is the progress of a lobby's captain's draft, derived from its players.
+// Captains and the players they picked are on a team; everyone else waits on the bench
+type CaptainsDraft struct {
+	BlueCaptainID uuid.UUID `json:"blueCaptainId"`
+	RedCaptainID  uuid.UUID `json:"redCaptainId"`
+	Picks         int       `json:"picks"`
+	TotalPicks    int       `json:"totalPicks"`
+	NextSide      Side      `json:"nextSide,omitempty"` // empty once the draft is complete
+
+	// LastPick is the pick that led to this progress, if any
+	LastPick *CaptainPick `json:"lastPick,omitempty"`
+}
+
+// CaptainPick is a player picked onto a team in a captain's draft
+type CaptainPick struct {
+	UserID uuid.UUID `json:"userId"`
+	Team   Side      `json:"team"`
+	Pick   int       `json:"pick"`
+	Auto   bool      `json:"auto"` // picked for a captain who ran out of time
+}
+
+// NewCaptainsDraft returns the draft progress of a lobby's players
+func NewCaptainsDraft(players []*LobbyPlayer, teamSize int) *CaptainsDraft {
+	draft := &CaptainsDraft{TotalPicks: 2 * (teamSize - 1)}
+	for _, p := range players {
+		if p.Team == nil {
+			continue
+		}
+		if !p.IsCaptain {
+			draft.Picks++
+			continue
+		}
+		if *p.Team == SideBlue {
+			draft.BlueCaptainID = p.UserID
+		} else {
+			draft.RedCaptainID = p.UserID
+		}
+	}
+	if !draft.IsComplete() {
+		draft.NextSide = CaptainPickSide(draft.Picks)
+	}
+	return draft
+}
+
+// IsComplete returns true once both teams are full
+func (d *CaptainsDraft) IsComplete() bool {
+	return d.Picks >= d.TotalPicks
+}
+
+// CaptainFor returns the captain of a side
+func (d *CaptainsDraft) CaptainFor(side Side) uuid.UUID {
+	if side == SideBlue {
+		return d.BlueCaptainID
+	}
+	return d.RedCaptainID
+}
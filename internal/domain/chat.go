@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ChatChannel is who can read a chat message
+type ChatChannel string
+
+const (
+	// ChatChannelAll is read by everyone in the lobby
+	ChatChannelAll ChatChannel = "all"
+	// ChatChannelTeam is read by the sender's team only
+	ChatChannelTeam ChatChannel = "team"
+)
+
+// IsValid returns true if the channel is a known chat channel
+func (c ChatChannel) IsValid() bool {
+	return c == ChatChannelAll || c == ChatChannelTeam
+}
+
+const (
+	// MaxChatMessageLength caps a chat message, in characters
+	MaxChatMessageLength = 500
+	// ChatHistoryLimit is how many recent messages are loaded on join
+	ChatHistoryLimit = 100
+)
+
+// ChatMessage is a message in a lobby's chat. The chat stays with the lobby once its draft starts.
+// System messages have no sender and are always on the all channel
+type ChatMessage struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	LobbyID   uuid.UUID   `json:"lobbyId" gorm:"type:uuid;not null;index"`
+	UserID    *uuid.UUID  `json:"userId" gorm:"type:uuid"`
+	Channel   ChatChannel `json:"channel" gorm:"type:varchar(10);not null;default:'all'"`
+	Team      *Side       `json:"team" gorm:"type:varchar(10)"` // the team of a team channel message
+	Body      string      `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time   `json:"createdAt" gorm:"index"`
+
+	// Relations
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for GORM
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
+
+// IsSystem returns true if the message was generated by the server
+func (m *ChatMessage) IsSystem() bool {
+	return m.UserID == nil
+}
+
+// VisibleTo returns whether a player on the given team, or on no team, can read the message
+func (m *ChatMessage) VisibleTo(team *Side) bool {
+	if m.Channel != ChatChannelTeam {
+		return true
+	}
+	return team != nil && m.Team != nil && *team == *m.Team
+}
+
+// NewChatMessage builds a validated player message.
+// The body is trimmed and must not be empty; team messages need the sender's team
+func NewChatMessage(lobbyID, userID uuid.UUID, channel ChatChannel, team *Side, body string) (*ChatMessage, error) {
+	body, err := normalizeChatBody(channel, body)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &ChatMessage{
+		ID:        uuid.New(),
+		LobbyID:   lobbyID,
+		UserID:    &userID,
+		Channel:   channel,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+	if channel == ChatChannelTeam {
+		if team == nil {
+			return nil, ErrNoChatTeam
+		}
+		t := *team
+		msg.Team = &t
+	}
+	return msg, nil
+}
+
+// NewSystemChatMessage builds a message from the server to the whole lobby
+func NewSystemChatMessage(lobbyID uuid.UUID, body string) *ChatMessage {
+	return &ChatMessage{
+		ID:        uuid.New(),
+		LobbyID:   lobbyID,
+		Channel:   ChatChannelAll,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+}
+
+// RoomChatMessage is a message in a draft room's chat, kept with the room for post-game review
+type RoomChatMessage struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RoomID    uuid.UUID   `json:"roomId" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID   `json:"userId" gorm:"type:uuid;not null"`
+	Channel   ChatChannel `json:"channel" gorm:"type:varchar(10);not null;default:'all'"`
+	Side      Side        `json:"side" gorm:"type:varchar(10);not null"` // the sender's side
+	Body      string      `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time   `json:"createdAt" gorm:"index"`
+
+	// Relations
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for GORM
+func (RoomChatMessage) TableName() string {
+	return "room_chat_messages"
+}
+
+// VisibleTo returns whether a client on the given side, or a spectator, can read the message
+func (m *RoomChatMessage) VisibleTo(side string) bool {
+	return m.Channel != ChatChannelTeam || side == string(m.Side)
+}
+
+// NewRoomChatMessage builds a validated message from a player on one of the room's sides
+func NewRoomChatMessage(roomID, userID uuid.UUID, channel ChatChannel, side Side, body string) (*RoomChatMessage, error) {
+	body, err := normalizeChatBody(channel, body)
+	if err != nil {
+		return nil, err
+	}
+	if side != SideBlue && side != SideRed {
+		return nil, ErrNoChatTeam
+	}
+
+	return &RoomChatMessage{
+		ID:        uuid.New(),
+		RoomID:    roomID,
+		UserID:    userID,
+		Channel:   channel,
+		Side:      side,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// normalizeChatBody trims a message body and checks it and its channel are valid
+func normalizeChatBody(channel ChatChannel, body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if !channel.IsValid() || body == "" || utf8.RuneCountInString(body) > MaxChatMessageLength {
+		return "", ErrInvalidChatMessage
+	}
+	return body, nil
+}
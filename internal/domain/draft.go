@@ -27,6 +27,14 @@ type DraftState struct {
 	BluePicks      datatypes.JSON `json:"bluePicks" gorm:"type:jsonb;default:'[]'"`
 	RedPicks       datatypes.JSON `json:"redPicks" gorm:"type:jsonb;default:'[]'"`
 	IsComplete     bool           `json:"isComplete" gorm:"not null;default:false"`
+	IsStarted      bool           `json:"isStarted" gorm:"not null;default:false"`
+	BlueReady      bool           `json:"blueReady" gorm:"not null;default:false"`
+	RedReady       bool           `json:"redReady" gorm:"not null;default:false"`
+	IsPaused       bool           `json:"isPaused" gorm:"not null;default:false"`
+	TimerDeadline  *time.Time     `json:"timerDeadline"`                                // when the current phase's main timer runs out
+	ChampionPools  datatypes.JSON `json:"championPools" gorm:"type:jsonb;default:'{}'"` // side -> champions in random pool drafts
+	BlueReserveMs  int            `json:"blueReserveMs" gorm:"not null;default:0"`      // blue's remaining chess-clock reserve
+	RedReserveMs   int            `json:"redReserveMs" gorm:"not null;default:0"`       // red's remaining chess-clock reserve
 	UpdatedAt      time.Time      `json:"updatedAt"`
 
 	// Relations
@@ -44,6 +52,17 @@ type DraftAction struct {
 	ActionTime time.Time  `json:"actionTime"`
 }
 
+// PickAssignment records which player played a team's picked champion, and in which role
+type PickAssignment struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RoomID     uuid.UUID `json:"roomId" gorm:"type:uuid;index;not null"`
+	Team       Side      `json:"team" gorm:"type:varchar(10);not null"`
+	PickOrder  int       `json:"pickOrder" gorm:"not null"`
+	ChampionID string    `json:"championId" gorm:"not null"`
+	UserID     uuid.UUID `json:"userId" gorm:"type:uuid;not null"`
+	Role       Role      `json:"role" gorm:"type:varchar(10);not null"`
+}
+
 type FearlessBan struct {
 	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	SeriesID     uuid.UUID `json:"seriesId" gorm:"type:uuid;index;not null"`
@@ -54,9 +73,9 @@ type FearlessBan struct {
 
 // Phase represents a single step in the draft
 type Phase struct {
-	Index      int
-	Team       Side
-	ActionType ActionType
+	Index      int        `json:"index"`
+	Team       Side       `json:"team"`
+	ActionType ActionType `json:"actionType"`
 }
 
 // ProPlayPhases defines the 20-phase pro play draft order
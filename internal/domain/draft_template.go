@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// DraftTemplateKind controls how the draft engine treats a template's phases
+type DraftTemplateKind string
+
+const (
+	// DraftTemplateKindStandard is a visible pick/ban order
+	DraftTemplateKindStandard DraftTemplateKind = "standard"
+	// DraftTemplateKindBlind hides each team's picks from the other side until the draft completes
+	DraftTemplateKindBlind DraftTemplateKind = "blind"
+	// DraftTemplateKindRandomPool gives each team a random champion pool to pick from
+	DraftTemplateKindRandomPool DraftTemplateKind = "random_pool"
+)
+
+// IsValid returns true if the kind is a known template kind
+func (k DraftTemplateKind) IsValid() bool {
+	switch k {
+	case DraftTemplateKindStandard, DraftTemplateKindBlind, DraftTemplateKindRandomPool:
+		return true
+	}
+	return false
+}
+
+// Built-in template names
+const (
+	DraftTemplateProPlay         = "pro_play"
+	DraftTemplateTournament3Bans = "tournament_3_bans"
+	DraftTemplateBlindPick       = "blind_pick"
+	DraftTemplateARAM            = "aram_random"
+)
+
+// DefaultRandomPoolSize is the number of champions offered to each team in a random pool draft
+const DefaultRandomPoolSize = 15
+
+// MaxPicksPerTeam is the number of players on a full team
+const MaxPicksPerTeam = 5
+
+// MaxDraftPhases caps the length of a custom phase order
+const MaxDraftPhases = 40
+
+// DraftTemplate is a named pick/ban order that a room drafts with
+type DraftTemplate struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string            `json:"name" gorm:"uniqueIndex;size:50;not null"`
+	Description string            `json:"description" gorm:"type:varchar(255)"`
+	Kind        DraftTemplateKind `json:"kind" gorm:"type:varchar(20);not null;default:'standard'"`
+	Phases      datatypes.JSON    `json:"phases" gorm:"type:jsonb;not null"`
+	PoolSize    int               `json:"poolSize" gorm:"not null;default:0"`
+	IsBuiltIn   bool              `json:"isBuiltIn" gorm:"not null;default:false"`
+	CreatedBy   *uuid.UUID        `json:"createdBy" gorm:"type:uuid"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// TableName returns the table name for GORM
+func (DraftTemplate) TableName() string {
+	return "draft_templates"
+}
+
+// GetPhases decodes the template's phase order
+func (t *DraftTemplate) GetPhases() ([]Phase, error) {
+	var phases []Phase
+	if err := json.Unmarshal(t.Phases, &phases); err != nil {
+		return nil, err
+	}
+	return phases, nil
+}
+
+// SetPhases validates, re-indexes and stores the phase order
+func (t *DraftTemplate) SetPhases(phases []Phase) error {
+	if err := ValidatePhases(phases); err != nil {
+		return err
+	}
+
+	indexed := make([]Phase, len(phases))
+	for i, p := range phases {
+		indexed[i] = Phase{Index: i, Team: p.Team, ActionType: p.ActionType}
+	}
+
+	data, err := json.Marshal(indexed)
+	if err != nil {
+		return err
+	}
+	t.Phases = data
+	return nil
+}
+
+// ValidatePhases checks that a phase order is playable
+func ValidatePhases(phases []Phase) error {
+	if len(phases) > MaxDraftPhases {
+		return ErrInvalidDraftPhases
+	}
+
+	picks := map[Side]int{}
+	for _, p := range phases {
+		if p.Team != SideBlue && p.Team != SideRed {
+			return ErrInvalidDraftPhases
+		}
+		if p.ActionType != ActionTypeBan && p.ActionType != ActionTypePick {
+			return ErrInvalidDraftPhases
+		}
+		if p.ActionType == ActionTypePick {
+			picks[p.Team]++
+		}
+	}
+
+	for _, side := range []Side{SideBlue, SideRed} {
+		if picks[side] < 1 || picks[side] > MaxPicksPerTeam {
+			return ErrInvalidDraftPickCount
+		}
+	}
+	return nil
+}
+
+// NewDraftTemplate builds a validated template
+func NewDraftTemplate(name, description string, kind DraftTemplateKind, phases []Phase, poolSize int) (*DraftTemplate, error) {
+	if !kind.IsValid() {
+		return nil, ErrInvalidDraftTemplateKind
+	}
+	if kind == DraftTemplateKindRandomPool {
+		if poolSize == 0 {
+			poolSize = DefaultRandomPoolSize
+		}
+		if poolSize < MaxPicksPerTeam || poolSize > 40 {
+			return nil, ErrInvalidDraftPoolSize
+		}
+	} else {
+		poolSize = 0
+	}
+
+	t := &DraftTemplate{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		Kind:        kind,
+		PoolSize:    poolSize,
+	}
+	if err := t.SetPhases(phases); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// alternatingPicks returns the standard B, RR, BB, RR, BB, R pick order
+func alternatingPicks() []Phase {
+	order := []Side{SideBlue, SideRed, SideRed, SideBlue, SideBlue, SideRed, SideRed, SideBlue, SideBlue, SideRed}
+	phases := make([]Phase, len(order))
+	for i, side := range order {
+		phases[i] = Phase{Team: side, ActionType: ActionTypePick}
+	}
+	return phases
+}
+
+// BuiltInDraftTemplates returns the templates that ship with the server
+func BuiltInDraftTemplates() []*DraftTemplate {
+	tournament := []Phase{
+		{0, SideBlue, ActionTypeBan},
+		{1, SideRed, ActionTypeBan},
+		{2, SideBlue, ActionTypeBan},
+		{3, SideRed, ActionTypeBan},
+		{4, SideBlue, ActionTypeBan},
+		{5, SideRed, ActionTypeBan},
+	}
+	tournament = append(tournament, alternatingPicks()...)
+
+	specs := []struct {
+		name        string
+		description string
+		kind        DraftTemplateKind
+		phases      []Phase
+		poolSize    int
+	}{
+		{DraftTemplateProPlay, "Pro play: 3 bans each, 3 picks each, 2 bans each, 2 picks each", DraftTemplateKindStandard, ProPlayPhases, 0},
+		{DraftTemplateTournament3Bans, "Tournament draft: 3 bans each, then all picks", DraftTemplateKindStandard, tournament, 0},
+		{DraftTemplateBlindPick, "Blind pick: no bans, picks hidden from the other team", DraftTemplateKindBlind, alternatingPicks(), 0},
+		{DraftTemplateARAM, "ARAM style: each team picks from its own random pool", DraftTemplateKindRandomPool, alternatingPicks(), DefaultRandomPoolSize},
+	}
+
+	templates := make([]*DraftTemplate, 0, len(specs))
+	for _, spec := range specs {
+		t, err := NewDraftTemplate(spec.name, spec.description, spec.kind, spec.phases, spec.poolSize)
+		if err != nil {
+			// Built-in templates are static; an error here is a programming mistake
+			panic(err)
+		}
+		t.IsBuiltIn = true
+		templates = append(templates, t)
+	}
+	return templates
+}
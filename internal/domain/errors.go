@@ -21,3 +21,22 @@ var (
 	ErrPlayersNotReady  = errors.New("not all players are ready")
 	ErrInvalidLobbyState = errors.New("invalid lobby state for this action")
 )
+
+// Draft template errors
+var (
+	ErrInvalidDraftTemplateKind = errors.New("invalid draft template kind")
+	ErrInvalidDraftPhases       = errors.New("draft phases must be at most 40 steps of blue or red picks and bans")
+	ErrInvalidDraftPickCount    = errors.New("each team must have between 1 and 5 picks")
+	ErrInvalidDraftPoolSize     = errors.New("random pool size must be between 5 and 40")
+)
+
+// Lobby preference errors
+var (
+	ErrInvalidPreference = errors.New("team preferences need another player and role preferences need roles (never_role takes exactly one)")
+)
+
+// Chat errors
+var (
+	ErrInvalidChatMessage = errors.New("chat messages must be between 1 and 500 characters on the all or team channel")
+	ErrNoChatTeam         = errors.New("players on the bench have no team channel")
+)
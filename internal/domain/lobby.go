@@ -1,9 +1,11 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 )
 
 // LobbyStatus represents the current state of a lobby
@@ -12,33 +14,49 @@ type LobbyStatus string
 const (
 	LobbyStatusWaitingForPlayers LobbyStatus = "waiting_for_players"
 	LobbyStatusMatchmaking       LobbyStatus = "matchmaking"
+	LobbyStatusCaptainsDraft     LobbyStatus = "captains_draft"
 	LobbyStatusTeamSelected      LobbyStatus = "team_selected"
 	LobbyStatusDrafting          LobbyStatus = "drafting"
 	LobbyStatusCompleted         LobbyStatus = "completed"
 )
 
-// MaxLobbyPlayers is the maximum number of players in a lobby
-const MaxLobbyPlayers = 10
+const (
+	// MinTeamSize and MaxTeamSize bound a lobby's format, from 2v2 to 5v5
+	MinTeamSize     = 2
+	MaxTeamSize     = 5
+	DefaultTeamSize = MaxTeamSize
+	// MaxSitOutPlayers is how many players beyond a full match a lobby accepts.
+	// Matchmaking chooses who sits out each game, and players who sit out form the bench.
+	MaxSitOutPlayers = 4
+	// MaxLobbyPlayers is the maximum number of players in any lobby
+	MaxLobbyPlayers = 2*MaxTeamSize + MaxSitOutPlayers
+)
 
-// Lobby represents a 10-man lobby for matchmaking
+// Lobby represents a matchmaking lobby, 5v5 unless it chose a smaller format
 type Lobby struct {
-	ID                   uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ShortCode            string      `json:"shortCode" gorm:"uniqueIndex;size:10;not null"`
-	CreatedBy            uuid.UUID   `json:"createdBy" gorm:"type:uuid;not null"`
-	Status               LobbyStatus `json:"status" gorm:"type:varchar(30);not null;default:'waiting_for_players'"`
-	SelectedMatchOption  *int        `json:"selectedMatchOption"`
-	DraftMode            DraftMode   `json:"draftMode" gorm:"type:varchar(20);not null;default:'pro_play'"`
-	TimerDurationSeconds int         `json:"timerDurationSeconds" gorm:"not null;default:30"`
-	RoomID               *uuid.UUID  `json:"roomId" gorm:"type:uuid"`
-	CreatedAt            time.Time   `json:"createdAt"`
-	StartedAt            *time.Time  `json:"startedAt"`
-	CompletedAt          *time.Time  `json:"completedAt"`
+	ID                    uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ShortCode             string      `json:"shortCode" gorm:"uniqueIndex;size:10;not null"`
+	CreatedBy             uuid.UUID   `json:"createdBy" gorm:"type:uuid;not null"`
+	Status                LobbyStatus `json:"status" gorm:"type:varchar(30);not null;default:'waiting_for_players'"`
+	SelectedMatchOption   *int        `json:"selectedMatchOption"`
+	DraftMode             DraftMode   `json:"draftMode" gorm:"type:varchar(20);not null;default:'pro_play'"`
+	TimerDurationSeconds  int         `json:"timerDurationSeconds" gorm:"not null;default:30"`
+	TradePhaseSeconds     int         `json:"tradePhaseSeconds" gorm:"not null;default:0"`
+	SpectatorDelaySeconds int         `json:"spectatorDelaySeconds" gorm:"not null;default:0"`
+	TeamSize              int         `json:"teamSize" gorm:"not null;default:5"`
+	RoomID                *uuid.UUID  `json:"roomId" gorm:"type:uuid"`
+	CreatedAt             time.Time   `json:"createdAt"`
+	StartedAt             *time.Time  `json:"startedAt"`
+	CompletedAt           *time.Time  `json:"completedAt"`
 
 	// Voting settings
 	VotingEnabled  bool        `json:"votingEnabled" gorm:"not null;default:false"`
 	VotingMode     VotingMode  `json:"votingMode" gorm:"type:varchar(20);default:'majority'"`
 	VotingDeadline *time.Time  `json:"votingDeadline,omitempty"`
 
+	// Matchmaking settings, see MatchmakingSettings
+	MatchmakingSettings datatypes.JSON `json:"matchmakingSettings" gorm:"type:jsonb;default:'{}'"`
+
 	// Relations
 	Creator *User         `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
 	Players []LobbyPlayer `json:"players,omitempty" gorm:"foreignKey:LobbyID"`
@@ -51,9 +69,66 @@ func (Lobby) TableName() string {
 	return "lobbies"
 }
 
-// IsFull returns true if the lobby has 10 players
+// LobbyParticipation counts how often a player recently played or sat out in started lobbies
+type LobbyParticipation struct {
+	UserID uuid.UUID
+	Played int
+	SatOut int
+}
+
+// PlayersPerTeam returns the lobby's team size
+func (l *Lobby) PlayersPerTeam() int {
+	if l.TeamSize == 0 {
+		return DefaultTeamSize
+	}
+	return l.TeamSize
+}
+
+// PlayersPerMatch returns how many players one game of the lobby's format needs
+func (l *Lobby) PlayersPerMatch() int {
+	return 2 * l.PlayersPerTeam()
+}
+
+// MaxPlayers returns how many players the lobby accepts, including those who sit out
+func (l *Lobby) MaxPlayers() int {
+	return l.PlayersPerMatch() + MaxSitOutPlayers
+}
+
+// Roles returns the roles played in the lobby's format
+func (l *Lobby) Roles() []Role {
+	return RolesForTeamSize(l.PlayersPerTeam())
+}
+
+// IsFull returns true if the lobby accepts no more players
 func (l *Lobby) IsFull() bool {
-	return len(l.Players) >= MaxLobbyPlayers
+	return len(l.Players) >= l.MaxPlayers()
+}
+
+// HasEnoughPlayers returns true if the lobby has enough players for a game
+func (l *Lobby) HasEnoughPlayers() bool {
+	return len(l.Players) >= l.PlayersPerMatch()
+}
+
+// GetMatchmakingSettings decodes the lobby's matchmaking algorithms and weights
+func (l *Lobby) GetMatchmakingSettings() (MatchmakingSettings, error) {
+	var settings MatchmakingSettings
+	if len(l.MatchmakingSettings) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(l.MatchmakingSettings, &settings); err != nil {
+		return MatchmakingSettings{}, err
+	}
+	return settings, nil
+}
+
+// SetMatchmakingSettings stores the lobby's matchmaking algorithms and weights
+func (l *Lobby) SetMatchmakingSettings(settings MatchmakingSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	l.MatchmakingSettings = data
+	return nil
 }
 
 // CanStartMatchmaking returns true if matchmaking can be started
@@ -61,7 +136,7 @@ func (l *Lobby) CanStartMatchmaking() bool {
 	if l.Status != LobbyStatusWaitingForPlayers {
 		return false
 	}
-	if !l.IsFull() {
+	if !l.HasEnoughPlayers() {
 		return false
 	}
 	// Check all players are ready
@@ -82,6 +157,7 @@ type LobbyPlayer struct {
 	AssignedRole *Role      `json:"assignedRole" gorm:"type:varchar(10)"`
 	IsReady      bool       `json:"isReady" gorm:"not null;default:false"`
 	IsCaptain    bool       `json:"isCaptain" gorm:"not null;default:false"`
+	ChatMuted    bool       `json:"chatMuted" gorm:"not null;default:false"`
 	JoinOrder    int        `json:"joinOrder" gorm:"not null;default:0"`
 	JoinedAt     time.Time  `json:"joinedAt"`
 
@@ -94,3 +170,8 @@ type LobbyPlayer struct {
 func (LobbyPlayer) TableName() string {
 	return "lobby_players"
 }
+
+// IsOnBench returns true if the player is not on a team and can substitute in
+func (p *LobbyPlayer) IsOnBench() bool {
+	return p.Team == nil
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// PreferenceType is what a player asks matchmaking for in a lobby
+type PreferenceType string
+
+const (
+	// PreferenceSameTeam puts the player on the same team as the target player
+	PreferenceSameTeam PreferenceType = "same_team"
+	// PreferenceNotSameTeam keeps the player off the target player's team
+	PreferenceNotSameTeam PreferenceType = "not_same_team"
+	// PreferenceOnlyRoles assigns the player one of the listed roles
+	PreferenceOnlyRoles PreferenceType = "only_roles"
+	// PreferenceNeverRole never assigns the player the listed role
+	PreferenceNeverRole PreferenceType = "never_role"
+)
+
+// IsValid returns true if the type is a known preference type
+func (t PreferenceType) IsValid() bool {
+	switch t {
+	case PreferenceSameTeam, PreferenceNotSameTeam, PreferenceOnlyRoles, PreferenceNeverRole:
+		return true
+	}
+	return false
+}
+
+// IsTeamPreference returns true if the preference is about which team another player is on
+func (t PreferenceType) IsTeamPreference() bool {
+	return t == PreferenceSameTeam || t == PreferenceNotSameTeam
+}
+
+// LobbyPreference is a player's matchmaking preference within a lobby.
+// Hard preferences are never broken by generated options; soft ones lower an option's score when broken
+type LobbyPreference struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	LobbyID      uuid.UUID      `json:"lobbyId" gorm:"type:uuid;not null;index"`
+	UserID       uuid.UUID      `json:"userId" gorm:"type:uuid;not null"`
+	Type         PreferenceType `json:"type" gorm:"type:varchar(20);not null"`
+	TargetUserID *uuid.UUID     `json:"targetUserId" gorm:"type:uuid"`        // team preferences
+	Roles        datatypes.JSON `json:"roles" gorm:"type:jsonb;default:'[]'"` // role preferences
+	IsHard       bool           `json:"isHard" gorm:"not null;default:false"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// TableName returns the table name for GORM
+func (LobbyPreference) TableName() string {
+	return "lobby_preferences"
+}
+
+// GetRoles decodes the roles of a role preference
+func (p *LobbyPreference) GetRoles() ([]Role, error) {
+	var roles []Role
+	if len(p.Roles) == 0 {
+		return roles, nil
+	}
+	if err := json.Unmarshal(p.Roles, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// SetRoles stores the roles of a role preference
+func (p *LobbyPreference) SetRoles(roles []Role) error {
+	if roles == nil {
+		roles = []Role{}
+	}
+	data, err := json.Marshal(roles)
+	if err != nil {
+		return err
+	}
+	p.Roles = data
+	return nil
+}
+
+// AllowsRole returns whether a role preference lets the player be assigned the role
+func (p *LobbyPreference) AllowsRole(role Role) bool {
+	roles, err := p.GetRoles()
+	if err != nil {
+		return true
+	}
+
+	listed := false
+	for _, r := range roles {
+		if r == role {
+			listed = true
+			break
+		}
+	}
+
+	switch p.Type {
+	case PreferenceOnlyRoles:
+		return listed
+	case PreferenceNeverRole:
+		return !listed
+	}
+	return true
+}
+
+// NewLobbyPreference builds a validated preference.
+// Team preferences need a target other than the player; only_roles needs at least one role
+// and never_role exactly one
+func NewLobbyPreference(lobbyID, userID uuid.UUID, prefType PreferenceType, targetUserID *uuid.UUID, roles []Role, isHard bool) (*LobbyPreference, error) {
+	if !prefType.IsValid() {
+		return nil, ErrInvalidPreference
+	}
+
+	pref := &LobbyPreference{
+		ID:        uuid.New(),
+		LobbyID:   lobbyID,
+		UserID:    userID,
+		Type:      prefType,
+		IsHard:    isHard,
+		CreatedAt: time.Now(),
+	}
+
+	if prefType.IsTeamPreference() {
+		if targetUserID == nil || *targetUserID == userID || len(roles) > 0 {
+			return nil, ErrInvalidPreference
+		}
+		pref.TargetUserID = targetUserID
+	} else {
+		if targetUserID != nil || len(roles) == 0 {
+			return nil, ErrInvalidPreference
+		}
+		if prefType == PreferenceNeverRole && len(roles) != 1 {
+			return nil, ErrInvalidPreference
+		}
+		for _, r := range roles {
+			if !r.IsValid() {
+				return nil, ErrInvalidRole
+			}
+		}
+	}
+
+	if err := pref.SetRoles(roles); err != nil {
+		return nil, err
+	}
+	return pref, nil
+}
@@ -1,9 +1,11 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 )
 
 // AlgorithmType represents the matchmaking algorithm used to generate an option
@@ -15,15 +17,58 @@ const (
 	AlgorithmHybrid       AlgorithmType = "hybrid"
 	AlgorithmLaneBalanced AlgorithmType = "lane_balanced"
 	AlgorithmComfortFirst AlgorithmType = "comfort_first"
+	// AlgorithmCaptainsDraft marks the option recording teams picked by captains, with roles by comfort
+	AlgorithmCaptainsDraft AlgorithmType = "captains_draft"
 )
 
 // AlgorithmLabels maps algorithm types to human-readable labels
 var AlgorithmLabels = map[AlgorithmType]string{
-	AlgorithmMMRBalanced:  "Most Balanced",
-	AlgorithmRoleComfort:  "Best Role Fit",
-	AlgorithmHybrid:       "Balanced Overall",
-	AlgorithmLaneBalanced: "Fair Lanes",
-	AlgorithmComfortFirst: "Best Comfort",
+	AlgorithmMMRBalanced:   "Most Balanced",
+	AlgorithmRoleComfort:   "Best Role Fit",
+	AlgorithmHybrid:        "Balanced Overall",
+	AlgorithmLaneBalanced:  "Fair Lanes",
+	AlgorithmComfortFirst:  "Best Comfort",
+	AlgorithmCaptainsDraft: "Captains' Picks",
+}
+
+// MaxBalanceWeight caps each matchmaking balance weight
+const MaxBalanceWeight = 10.0
+
+// BalanceWeights scale how much a matchmaking algorithm cares about each balance measure.
+// 1 keeps an algorithm's own tuning, 0 ignores the measure
+type BalanceWeights struct {
+	MMRDiff  float64 `json:"mmrDiff"`
+	Comfort  float64 `json:"comfort"`
+	LaneDiff float64 `json:"laneDiff"`
+}
+
+// DefaultBalanceWeights leaves every algorithm's scoring unchanged
+var DefaultBalanceWeights = BalanceWeights{MMRDiff: 1, Comfort: 1, LaneDiff: 1}
+
+// IsValid checks that every weight is between 0 and MaxBalanceWeight
+func (w BalanceWeights) IsValid() bool {
+	for _, v := range []float64{w.MMRDiff, w.Comfort, w.LaneDiff} {
+		if v < 0 || v > MaxBalanceWeight {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchmakingSettings is a lobby's choice of algorithms and weights for generating match options
+type MatchmakingSettings struct {
+	// Algorithms produce the match options; empty uses the default algorithm
+	Algorithms []AlgorithmType `json:"algorithms,omitempty"`
+	// Weights is nil when the lobby uses DefaultBalanceWeights
+	Weights *BalanceWeights `json:"weights,omitempty"`
+}
+
+// BalanceWeights returns the lobby's weights, or the defaults if none are set
+func (s MatchmakingSettings) BalanceWeights() BalanceWeights {
+	if s.Weights == nil {
+		return DefaultBalanceWeights
+	}
+	return *s.Weights
 }
 
 // MatchOption represents a possible team composition from matchmaking
@@ -40,7 +85,10 @@ type MatchOption struct {
 	AvgRedComfort    float64       `json:"avgRedComfort" gorm:"type:decimal(3,2)"`
 	MaxLaneDiff      int           `json:"maxLaneDiff" gorm:"default:0"`
 	UsedMmrThreshold int           `json:"usedMmrThreshold" gorm:"default:0"`
-	CreatedAt        time.Time     `json:"createdAt"`
+	// Lobby preferences the option keeps and breaks, as preference IDs; hard preferences are always kept
+	SatisfiedPreferences   datatypes.JSON `json:"satisfiedPreferences" gorm:"type:jsonb;default:'[]'"`
+	UnsatisfiedPreferences datatypes.JSON `json:"unsatisfiedPreferences" gorm:"type:jsonb;default:'[]'"`
+	CreatedAt              time.Time      `json:"createdAt"`
 
 	// Relations
 	Assignments []MatchOptionAssignment `json:"assignments,omitempty" gorm:"foreignKey:MatchOptionID"`
@@ -52,6 +100,44 @@ func (MatchOption) TableName() string {
 	return "match_options"
 }
 
+// GetPreferenceResults decodes the IDs of the lobby preferences the option keeps and breaks
+func (m *MatchOption) GetPreferenceResults() (satisfied, unsatisfied []uuid.UUID, err error) {
+	if len(m.SatisfiedPreferences) > 0 {
+		if err := json.Unmarshal(m.SatisfiedPreferences, &satisfied); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(m.UnsatisfiedPreferences) > 0 {
+		if err := json.Unmarshal(m.UnsatisfiedPreferences, &unsatisfied); err != nil {
+			return nil, nil, err
+		}
+	}
+	return satisfied, unsatisfied, nil
+}
+
+// SetPreferenceResults stores the IDs of the lobby preferences the option keeps and breaks
+func (m *MatchOption) SetPreferenceResults(satisfied, unsatisfied []uuid.UUID) error {
+	if satisfied == nil {
+		satisfied = []uuid.UUID{}
+	}
+	if unsatisfied == nil {
+		unsatisfied = []uuid.UUID{}
+	}
+
+	data, err := json.Marshal(satisfied)
+	if err != nil {
+		return err
+	}
+	m.SatisfiedPreferences = data
+
+	data, err = json.Marshal(unsatisfied)
+	if err != nil {
+		return err
+	}
+	m.UnsatisfiedPreferences = data
+	return nil
+}
+
 // GetBlueTeam returns assignments for the blue team
 func (m *MatchOption) GetBlueTeam() []MatchOptionAssignment {
 	var blue []MatchOptionAssignment
@@ -15,6 +15,8 @@ const (
 	PendingActionMatchmake    PendingActionType = "matchmake"
 	PendingActionSelectOption PendingActionType = "select_option"
 	PendingActionStartDraft   PendingActionType = "start_draft"
+	// PendingActionSubstitutePlayer brings a bench player into a team slot, before or during the draft
+	PendingActionSubstitutePlayer PendingActionType = "substitute_player"
 )
 
 // PendingActionStatus represents the status of a pending action
@@ -41,6 +43,7 @@ type PendingAction struct {
 
 	// For swap_players: Player1ID (team A) <-> Player2ID (team B)
 	// For swap_roles: Player1ID and Player2ID on same team
+	// For substitute_player: Player1ID leaves the team, Player2ID comes in from the bench
 	Player1ID *uuid.UUID `json:"player1Id" gorm:"type:uuid"`
 	Player2ID *uuid.UUID `json:"player2Id" gorm:"type:uuid"`
 
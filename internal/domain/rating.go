@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rating system constants
+const (
+	DefaultRating           = 1500.0
+	DefaultRatingDeviation  = 350.0
+	DefaultRatingVolatility = 0.06
+
+	// ProvisionalRatedGames is how many rated games a role needs before its earned rating
+	// replaces the MMR derived from the self-reported rank
+	ProvisionalRatedGames = 10
+
+	// glicko2Scale converts between the Glicko and Glicko-2 scales
+	glicko2Scale = 173.7178
+	// glicko2Tau constrains how much volatility can change between games
+	glicko2Tau = 0.5
+	// glicko2Epsilon is the convergence tolerance for the volatility iteration
+	glicko2Epsilon = 0.000001
+)
+
+// Glicko2Rating is a player's rating, rating deviation and volatility on the Glicko scale
+type Glicko2Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// CompositeRating combines a team into a single opponent: the mean rating and volatility,
+// and the root mean square of the deviations
+func CompositeRating(ratings []Glicko2Rating) Glicko2Rating {
+	if len(ratings) == 0 {
+		return Glicko2Rating{Rating: DefaultRating, Deviation: DefaultRatingDeviation, Volatility: DefaultRatingVolatility}
+	}
+
+	var rating, variance, volatility float64
+	for _, r := range ratings {
+		rating += r.Rating
+		variance += r.Deviation * r.Deviation
+		volatility += r.Volatility
+	}
+	n := float64(len(ratings))
+	return Glicko2Rating{
+		Rating:     rating / n,
+		Deviation:  math.Sqrt(variance / n),
+		Volatility: volatility / n,
+	}
+}
+
+// Update returns the rating after a single game against opponent, following the
+// Glicko-2 algorithm with the game as its own rating period.
+// score is 1 for a win and 0 for a loss
+func (r Glicko2Rating) Update(opponent Glicko2Rating, score float64) Glicko2Rating {
+	mu := (r.Rating - DefaultRating) / glicko2Scale
+	phi := r.Deviation / glicko2Scale
+	opponentMu := (opponent.Rating - DefaultRating) / glicko2Scale
+	opponentPhi := opponent.Deviation / glicko2Scale
+
+	g := 1 / math.Sqrt(1+3*opponentPhi*opponentPhi/(math.Pi*math.Pi))
+	expected := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+	v := 1 / (g * g * expected * (1 - expected))
+	delta := v * g * (score - expected)
+
+	volatility := newVolatility(phi, v, delta, r.Volatility)
+
+	phiStar := math.Sqrt(phi*phi + volatility*volatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*g*(score-expected)
+
+	return Glicko2Rating{
+		Rating:     newMu*glicko2Scale + DefaultRating,
+		Deviation:  math.Min(newPhi*glicko2Scale, DefaultRatingDeviation),
+		Volatility: volatility,
+	}
+}
+
+// newVolatility finds the updated volatility with the Illinois algorithm (step 5 of Glicko-2)
+func newVolatility(phi, v, delta, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(glicko2Tau*glicko2Tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glicko2Tau) < 0 {
+			k++
+		}
+		B = a - k*glicko2Tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glicko2Epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
+
+// RatingChange records one player's rating update from a game, for auditing
+type RatingChange struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID           uuid.UUID `json:"userId" gorm:"type:uuid;not null;index;uniqueIndex:idx_rating_changes_room_user"`
+	RoomID           uuid.UUID `json:"roomId" gorm:"type:uuid;not null;uniqueIndex:idx_rating_changes_room_user"`
+	Role             Role      `json:"role" gorm:"type:varchar(10);not null"`
+	Won              bool      `json:"won" gorm:"not null"`
+	OpponentRating   float64   `json:"opponentRating" gorm:"not null"`
+	RatingBefore     float64   `json:"ratingBefore" gorm:"not null"`
+	RatingAfter      float64   `json:"ratingAfter" gorm:"not null"`
+	DeviationBefore  float64   `json:"deviationBefore" gorm:"not null"`
+	DeviationAfter   float64   `json:"deviationAfter" gorm:"not null"`
+	VolatilityBefore float64   `json:"volatilityBefore" gorm:"not null"`
+	VolatilityAfter  float64   `json:"volatilityAfter" gorm:"not null"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// TableName returns the table name for GORM
+func (RatingChange) TableName() string {
+	return "rating_changes"
+}
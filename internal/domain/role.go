@@ -14,6 +14,23 @@ const (
 // AllRoles contains all valid roles in order
 var AllRoles = []Role{RoleTop, RoleJungle, RoleMid, RoleADC, RoleSupport}
 
+// RolesForTeamSize returns the roles played in a format, in AllRoles order.
+// 4v4 drops support, 3v3 keeps top, jungle and mid, and 2v2 is bot lane only.
+// It returns nil for unsupported sizes.
+func RolesForTeamSize(size int) []Role {
+	switch size {
+	case 5:
+		return AllRoles
+	case 4:
+		return []Role{RoleTop, RoleJungle, RoleMid, RoleADC}
+	case 3:
+		return []Role{RoleTop, RoleJungle, RoleMid}
+	case 2:
+		return []Role{RoleADC, RoleSupport}
+	}
+	return nil
+}
+
 // IsValid checks if a role is valid
 func (r Role) IsValid() bool {
 	switch r {
@@ -19,24 +19,49 @@ const (
 	RoomStatusWaiting    RoomStatus = "waiting"
 	RoomStatusInProgress RoomStatus = "in_progress"
 	RoomStatusCompleted  RoomStatus = "completed"
+	RoomStatusClosed     RoomStatus = "closed" // force-closed by an admin before the draft completed
+)
+
+// ResultStatus tracks the reported outcome of the game played from a completed draft
+type ResultStatus string
+
+const (
+	ResultStatusUnreported ResultStatus = "unreported"
+	ResultStatusPending    ResultStatus = "pending" // reported by one side, awaiting the other side's confirmation
+	ResultStatusConfirmed  ResultStatus = "confirmed"
+	ResultStatusDisputed   ResultStatus = "disputed"
 )
 
 type Room struct {
-	ID                   uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ShortCode            string     `json:"shortCode" gorm:"uniqueIndex;not null"`
-	CreatedBy            uuid.UUID  `json:"createdBy" gorm:"type:uuid;not null"`
-	DraftMode            DraftMode  `json:"draftMode" gorm:"not null;default:'pro_play'"`
-	TimerDurationSeconds int        `json:"timerDurationSeconds" gorm:"not null;default:30"`
-	Status               RoomStatus `json:"status" gorm:"not null;default:'waiting'"`
-	BlueSideUserID       *uuid.UUID `json:"blueSideUserId" gorm:"type:uuid"`
-	RedSideUserID        *uuid.UUID `json:"redSideUserId" gorm:"type:uuid"`
-	SeriesID             *uuid.UUID `json:"seriesId" gorm:"type:uuid"`
-	GameNumber           int        `json:"gameNumber" gorm:"default:1"`
-	IsTeamDraft          bool       `json:"isTeamDraft" gorm:"default:false"`
-	LobbyID              *uuid.UUID `json:"lobbyId" gorm:"type:uuid"`
-	CreatedAt            time.Time  `json:"createdAt"`
-	StartedAt            *time.Time `json:"startedAt"`
-	CompletedAt          *time.Time `json:"completedAt"`
+	ID                    uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ShortCode             string     `json:"shortCode" gorm:"uniqueIndex;not null"`
+	CreatedBy             uuid.UUID  `json:"createdBy" gorm:"type:uuid;not null"`
+	DraftMode             DraftMode  `json:"draftMode" gorm:"not null;default:'pro_play'"`
+	TimerDurationSeconds  int        `json:"timerDurationSeconds" gorm:"not null;default:30"`
+	ReserveTimeSeconds    int        `json:"reserveTimeSeconds" gorm:"not null;default:0"` // per-side chess-clock reserve, 0 disables
+	Status                RoomStatus `json:"status" gorm:"not null;default:'waiting'"`
+	BlueSideUserID        *uuid.UUID `json:"blueSideUserId" gorm:"type:uuid"`
+	RedSideUserID         *uuid.UUID `json:"redSideUserId" gorm:"type:uuid"`
+	SeriesID              *uuid.UUID `json:"seriesId" gorm:"type:uuid"`
+	GameNumber            int        `json:"gameNumber" gorm:"default:1"`
+	IsTeamDraft           bool       `json:"isTeamDraft" gorm:"default:false"`
+	LobbyID               *uuid.UUID `json:"lobbyId" gorm:"type:uuid"`
+	DraftTemplateID       *uuid.UUID `json:"draftTemplateId" gorm:"type:uuid"`
+	BotSide               *Side      `json:"botSide" gorm:"type:varchar(10)"`                 // side drafted by the bot in practice rooms
+	BotOnTimeout          bool       `json:"botOnTimeout" gorm:"not null;default:false"`      // timed-out turns are drafted by the bot instead of at random
+	TradePhaseSeconds     int        `json:"tradePhaseSeconds" gorm:"not null;default:0"`     // post-draft champion trading in team drafts, 0 disables
+	SpectatorDelaySeconds int        `json:"spectatorDelaySeconds" gorm:"not null;default:0"` // how far spectators lag behind the draft, 0 shows it live
+	CreatedAt             time.Time  `json:"createdAt"`
+	StartedAt             *time.Time `json:"startedAt"`
+	CompletedAt           *time.Time `json:"completedAt"`
+
+	// Game result
+	WinnerSide         *Side        `json:"winnerSide" gorm:"type:varchar(10)"`
+	ResultStatus       ResultStatus `json:"resultStatus" gorm:"type:varchar(20);not null;default:'unreported'"`
+	ResultReportedBy   *uuid.UUID   `json:"resultReportedBy" gorm:"type:uuid"`
+	ResultReporterSide *Side        `json:"resultReporterSide" gorm:"type:varchar(10)"`
+	ResultReportedAt   *time.Time   `json:"resultReportedAt"`
+	ResultConfirmedAt  *time.Time   `json:"resultConfirmedAt"`
 
 	// Relations
 	Creator      *User        `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
@@ -52,3 +77,11 @@ const (
 	SideRed       Side = "red"
 	SideSpectator Side = "spectator"
 )
+
+// ConfirmResult records winner as the agreed result of the game
+func (r *Room) ConfirmResult(winner Side) {
+	now := time.Now()
+	r.WinnerSide = &winner
+	r.ResultStatus = ResultStatusConfirmed
+	r.ResultConfirmedAt = &now
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeriesFormat represents how many games a series is played over
+type SeriesFormat string
+
+const (
+	SeriesFormatBo1 SeriesFormat = "bo1"
+	SeriesFormatBo3 SeriesFormat = "bo3"
+	SeriesFormatBo5 SeriesFormat = "bo5"
+)
+
+// IsValid returns true if the format is a known series format
+func (f SeriesFormat) IsValid() bool {
+	switch f {
+	case SeriesFormatBo1, SeriesFormatBo3, SeriesFormatBo5:
+		return true
+	}
+	return false
+}
+
+// MaxGames returns the maximum number of games in the series
+func (f SeriesFormat) MaxGames() int {
+	switch f {
+	case SeriesFormatBo3:
+		return 3
+	case SeriesFormatBo5:
+		return 5
+	default:
+		return 1
+	}
+}
+
+// WinsNeeded returns the number of game wins required to take the series
+func (f SeriesFormat) WinsNeeded() int {
+	return f.MaxGames()/2 + 1
+}
+
+// SeriesStatus represents the current state of a series
+type SeriesStatus string
+
+const (
+	SeriesStatusInProgress SeriesStatus = "in_progress"
+	SeriesStatusCompleted  SeriesStatus = "completed"
+)
+
+// Series links consecutive rooms played by the same teams into a best-of-N set
+type Series struct {
+	ID                   uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedBy            uuid.UUID    `json:"createdBy" gorm:"type:uuid;not null"`
+	Format               SeriesFormat `json:"format" gorm:"type:varchar(10);not null;default:'bo1'"`
+	DraftMode            DraftMode    `json:"draftMode" gorm:"type:varchar(20);not null;default:'pro_play'"`
+	TimerDurationSeconds int          `json:"timerDurationSeconds" gorm:"not null;default:30"`
+	ReserveTimeSeconds   int          `json:"reserveTimeSeconds" gorm:"not null;default:0"`
+	Status               SeriesStatus `json:"status" gorm:"type:varchar(20);not null;default:'in_progress'"`
+	BlueSideUserID       *uuid.UUID   `json:"blueSideUserId" gorm:"type:uuid"`
+	RedSideUserID        *uuid.UUID   `json:"redSideUserId" gorm:"type:uuid"`
+	IsTeamDraft          bool         `json:"isTeamDraft" gorm:"default:false"`
+	LobbyID              *uuid.UUID   `json:"lobbyId" gorm:"type:uuid"`
+	DraftTemplateID      *uuid.UUID   `json:"draftTemplateId" gorm:"type:uuid"`
+	CurrentGame          int          `json:"currentGame" gorm:"not null;default:1"`
+	BlueWins             int          `json:"blueWins" gorm:"not null;default:0"`
+	RedWins              int          `json:"redWins" gorm:"not null;default:0"`
+	WinnerSide           *Side        `json:"winnerSide" gorm:"type:varchar(10)"`
+	CreatedAt            time.Time    `json:"createdAt"`
+	CompletedAt          *time.Time   `json:"completedAt"`
+}
+
+// TableName returns the table name for GORM
+func (Series) TableName() string {
+	return "series"
+}
+
+// RecordGameWinner adds a game win to the given side and completes the series once a side has won enough games
+func (s *Series) RecordGameWinner(side Side) {
+	switch side {
+	case SideBlue:
+		s.BlueWins++
+	case SideRed:
+		s.RedWins++
+	}
+
+	needed := s.Format.WinsNeeded()
+	if s.BlueWins >= needed || s.RedWins >= needed || s.CurrentGame >= s.Format.MaxGames() {
+		winner := SideBlue
+		if s.RedWins > s.BlueWins {
+			winner = SideRed
+		}
+		now := time.Now()
+		s.Status = SeriesStatusCompleted
+		s.WinnerSide = &winner
+		s.CompletedAt = &now
+	}
+}
+
+// IsComplete returns true if the series has been decided
+func (s *Series) IsComplete() bool {
+	return s.Status == SeriesStatusCompleted
+}
@@ -1,23 +1,110 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserRole is a user's site-wide permission level, separate from the lane Role they play
+type UserRole string
+
+const (
+	UserRolePlayer    UserRole = "player"
+	UserRoleOrganizer UserRole = "organizer"
+	UserRoleAdmin     UserRole = "admin"
+)
+
+// userRoleLevels orders the roles so each one includes the permissions of those below it
+var userRoleLevels = map[UserRole]int{
+	UserRolePlayer:    1,
+	UserRoleOrganizer: 2,
+	UserRoleAdmin:     3,
+}
+
+// IsValid checks if the user role is a known value
+func (r UserRole) IsValid() bool {
+	_, ok := userRoleLevels[r]
+	return ok
+}
+
+// Includes returns true if the role has at least the permissions of required
+func (r UserRole) Includes(required UserRole) bool {
+	return r.IsValid() && userRoleLevels[r] >= userRoleLevels[required]
+}
+
 type User struct {
 	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	PasswordHash string    `json:"-" gorm:"not null"`
 	DisplayName  string    `json:"displayName" gorm:"uniqueIndex;not null"`
+	Role         UserRole  `json:"role" gorm:"type:varchar(20);not null;default:'player'"`
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
+
+	// Deleted accounts keep their row, anonymized, so rooms and match history still resolve.
+	// This is not a gorm soft delete because preloads must keep finding the row
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
+// IsDeleted returns true if the account was deleted and anonymized
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// anonymousDisplayNamePrefix starts every deleted user's name, so no one else may use it
+const anonymousDisplayNamePrefix = "Deleted User"
+
+// AnonymousDisplayName is the name a deleted user is shown as
+func AnonymousDisplayName(userID uuid.UUID) string {
+	return anonymousDisplayNamePrefix + " " + userID.String()[:8]
+}
+
+// IsReservedDisplayName returns true if a name could be mistaken for a deleted user's
+func IsReservedDisplayName(name string) bool {
+	prefix := len(anonymousDisplayNamePrefix)
+	return len(name) >= prefix && strings.EqualFold(name[:prefix], anonymousDisplayNamePrefix)
+}
+
+// HasRole returns true if the user has at least the permissions of the given role
+func (u *User) HasRole(role UserRole) bool {
+	return u.Role.Includes(role)
+}
+
+// UserSession holds one refresh token. Each login starts a new family of sessions,
+// and every refresh rotates to a new session in the same family
 type UserSession struct {
-	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID           uuid.UUID `json:"userId" gorm:"type:uuid;not null"`
-	RefreshTokenHash string    `json:"-" gorm:"not null"`
-	ExpiresAt        time.Time `json:"expiresAt" gorm:"not null"`
-	CreatedAt        time.Time `json:"createdAt"`
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID           uuid.UUID  `json:"userId" gorm:"type:uuid;not null;index"`
+	FamilyID         uuid.UUID  `json:"familyId" gorm:"type:uuid;not null;index"`
+	RefreshTokenHash string     `json:"-" gorm:"not null"`
+	ExpiresAt        time.Time  `json:"expiresAt" gorm:"not null"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// IsRevoked returns true if the session's refresh token was already rotated or revoked
+func (s *UserSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsExpired returns true if the session's refresh token can no longer be used
+func (s *UserSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// PasswordResetToken is a one-time token an admin issues so a user can set a new password
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"userId" gorm:"type:uuid;not null;index"`
+	TokenHash string     `json:"-" gorm:"not null"`
+	CreatedBy uuid.UUID  `json:"createdBy" gorm:"type:uuid;not null"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// IsUsable returns true if the token hasn't been used and hasn't expired
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
 }
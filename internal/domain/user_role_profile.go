@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,8 +15,15 @@ type UserRoleProfile struct {
 	LeagueRank    LeagueRank `json:"leagueRank" gorm:"type:varchar(20);not null;default:'Unranked'"`
 	MMR           int        `json:"mmr" gorm:"not null;default:1200"`
 	ComfortRating int        `json:"comfortRating" gorm:"not null;default:3"`
-	CreatedAt     time.Time  `json:"createdAt"`
-	UpdatedAt     time.Time  `json:"updatedAt"`
+
+	// Earned Glicko-2 rating, updated from confirmed match results
+	Rating           float64 `json:"rating" gorm:"not null;default:1500"`
+	RatingDeviation  float64 `json:"ratingDeviation" gorm:"not null;default:350"`
+	RatingVolatility float64 `json:"ratingVolatility" gorm:"not null;default:0.06"`
+	RatedGames       int     `json:"ratedGames" gorm:"not null;default:0"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 
 	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
@@ -48,6 +56,54 @@ func (p *UserRoleProfile) SetRankAndUpdateMMR(rank LeagueRank) {
 	p.MMR = rank.ToMMR()
 }
 
+// IsProvisional returns whether the role has too few rated games for its earned rating to be trusted
+func (p *UserRoleProfile) IsProvisional() bool {
+	return p.RatedGames < ProvisionalRatedGames
+}
+
+// EffectiveMMR returns the MMR matchmaking should use: the earned rating once the role is
+// out of its provisional period, otherwise the MMR from the self-reported rank
+func (p *UserRoleProfile) EffectiveMMR() int {
+	if p.IsProvisional() {
+		return p.MMR
+	}
+	return int(math.Round(p.Rating))
+}
+
+// Glicko2 returns the role's current rating. Before the first rated game the rating is
+// seeded from the rank-based MMR with the default deviation and volatility
+func (p *UserRoleProfile) Glicko2() Glicko2Rating {
+	if p.RatedGames == 0 {
+		return Glicko2Rating{
+			Rating:     float64(p.MMR),
+			Deviation:  DefaultRatingDeviation,
+			Volatility: DefaultRatingVolatility,
+		}
+	}
+	return Glicko2Rating{
+		Rating:     p.Rating,
+		Deviation:  p.RatingDeviation,
+		Volatility: p.RatingVolatility,
+	}
+}
+
+// ApplyRating stores the rating after a rated game
+func (p *UserRoleProfile) ApplyRating(rating Glicko2Rating) {
+	p.Rating = rating.Rating
+	p.RatingDeviation = rating.Deviation
+	p.RatingVolatility = rating.Volatility
+	p.RatedGames++
+}
+
+// ResetRating discards the earned rating and returns the role to its rank-based MMR
+func (p *UserRoleProfile) ResetRating() {
+	p.MMR = p.LeagueRank.ToMMR()
+	p.Rating = DefaultRating
+	p.RatingDeviation = DefaultRatingDeviation
+	p.RatingVolatility = DefaultRatingVolatility
+	p.RatedGames = 0
+}
+
 // NewDefaultUserRoleProfile creates a new profile with default values
 func NewDefaultUserRoleProfile(userID uuid.UUID, role Role) *UserRoleProfile {
 	return &UserRoleProfile{
@@ -57,6 +113,10 @@ func NewDefaultUserRoleProfile(userID uuid.UUID, role Role) *UserRoleProfile {
 		LeagueRank:    RankUnranked,
 		MMR:           1200,
 		ComfortRating: 3,
+
+		Rating:           DefaultRating,
+		RatingDeviation:  DefaultRatingDeviation,
+		RatingVolatility: DefaultRatingVolatility,
 	}
 }
 
@@ -0,0 +1,124 @@
+// Package ratelimit implements token-bucket rate limiting, for HTTP requests keyed by IP or
+// user and for websocket messages on a single connection
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often a Limiter forgets keys whose buckets have refilled
+const sweepInterval = time.Minute
+
+// Limit is a token bucket's refill rate and capacity. A zero Limit never limits
+type Limit struct {
+	Rate  float64 // tokens added per second
+	Burst int     // most tokens the bucket holds
+}
+
+// PerSecond returns a limit of n events a second with the given burst
+func PerSecond(n, burst int) Limit {
+	return Limit{Rate: float64(n), Burst: burst}
+}
+
+// PerMinute returns a limit of n events a minute with the given burst
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Enabled returns true if the limit actually limits anything
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Bucket is a single token bucket. It isn't safe for concurrent use
+type Bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket returns a full bucket
+func NewBucket(limit Limit) *Bucket {
+	return &Bucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// Allow takes a token if there is one. When there isn't, it returns how long until there will be
+func (b *Bucket) Allow(now time.Time) (bool, time.Duration) {
+	if !b.limit.Enabled() {
+		return true, 0
+	}
+
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	return false, wait
+}
+
+// full returns true if the bucket has refilled, so it's no different from a new one
+func (b *Bucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= float64(b.limit.Burst)
+}
+
+func (b *Bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.limit.Rate
+		if b.tokens > float64(b.limit.Burst) {
+			b.tokens = float64(b.limit.Burst)
+		}
+	}
+	b.last = now
+}
+
+// Limiter keeps a bucket per key, such as per IP address or user
+type Limiter struct {
+	limit Limit
+
+	mu        sync.Mutex
+	buckets   map[string]*Bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:     limit,
+		buckets:   make(map[string]*Bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Limit returns the limit each key gets
+func (l *Limiter) Limit() Limit {
+	return l.limit
+}
+
+// Allow takes a token from the key's bucket. When it's empty, it returns how long until it won't be
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if !l.limit.Enabled() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		for k, bucket := range l.buckets {
+			if bucket.full(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = NewBucket(l.limit)
+		l.buckets[key] = bucket
+	}
+	return bucket.Allow(now)
+}
@@ -0,0 +1,63 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket_Allow(t *testing.T) {
+	bucket := ratelimit.NewBucket(ratelimit.PerSecond(2, 3))
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		allowed, _ := bucket.Allow(now)
+		assert.True(t, allowed, "burst request %d", i)
+	}
+
+	allowed, wait := bucket.Allow(now)
+	assert.False(t, allowed)
+	assert.InDelta(t, 500*time.Millisecond, wait, float64(10*time.Millisecond))
+
+	// Half a second refills one token at 2 a second
+	allowed, _ = bucket.Allow(now.Add(500 * time.Millisecond))
+	assert.True(t, allowed)
+	allowed, _ = bucket.Allow(now.Add(500 * time.Millisecond))
+	assert.False(t, allowed)
+
+	// A long wait never refills past the burst
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		allowed, _ = bucket.Allow(later)
+		assert.True(t, allowed)
+	}
+	allowed, _ = bucket.Allow(later)
+	assert.False(t, allowed)
+}
+
+func TestBucket_ZeroLimitNeverLimits(t *testing.T) {
+	bucket := ratelimit.NewBucket(ratelimit.Limit{})
+	now := time.Now()
+
+	for i := 0; i < 100; i++ {
+		allowed, _ := bucket.Allow(now)
+		assert.True(t, allowed)
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.PerMinute(1, 2))
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := limiter.Allow("ip:1.2.3.4")
+		assert.True(t, allowed)
+	}
+	allowed, wait := limiter.Allow("ip:1.2.3.4")
+	assert.False(t, allowed)
+	assert.Greater(t, wait, 50*time.Second)
+
+	allowed, _ = limiter.Allow("ip:5.6.7.8")
+	assert.True(t, allowed)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/google/uuid"
@@ -12,12 +13,31 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetByDisplayName(ctx context.Context, displayName string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	// HasRole returns true if any user holds the site-wide role
+	HasRole(ctx context.Context, role domain.UserRole) (bool, error)
+	// Anonymize deletes a user's account data but keeps the row that rooms and drafts reference
+	Anonymize(ctx context.Context, userID uuid.UUID) error
 }
 
 type SessionRepository interface {
 	Create(ctx context.Context, session *domain.UserSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.UserSession, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSession, error)
+	// Revoke marks a session as used and returns false if it was already revoked
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByFamilyID(ctx context.Context, userID, familyID uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID) error
+	// PruneFamily deletes a session family's expired sessions and every revoked one except keepID
+	PruneFamily(ctx context.Context, userID, familyID, keepID uuid.UUID) error
+}
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PasswordResetToken, error)
+	// MarkUsed marks a token as used and returns false if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 }
 
@@ -30,6 +50,8 @@ type RoomRepository interface {
 	GetCompletedByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Room, error)
 	GetAllCompleted(ctx context.Context, limit, offset int) ([]*domain.Room, error)
 	GetByIDWithDraftState(ctx context.Context, id uuid.UUID) (*domain.Room, *domain.DraftState, error)
+	GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*domain.Room, error)
+	GetByStatus(ctx context.Context, status domain.RoomStatus) ([]*domain.Room, error)
 }
 
 type DraftStateRepository interface {
@@ -41,6 +63,7 @@ type DraftStateRepository interface {
 type DraftActionRepository interface {
 	Create(ctx context.Context, action *domain.DraftAction) error
 	GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.DraftAction, error)
+	GetRecentPicksByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DraftAction, error)
 }
 
 type ChampionRepository interface {
@@ -50,11 +73,41 @@ type ChampionRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Champion, error)
 }
 
+type PickAssignmentRepository interface {
+	ReplaceForRoom(ctx context.Context, roomID uuid.UUID, assignments []*domain.PickAssignment) error
+	GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.PickAssignment, error)
+}
+
+type RoomChatMessageRepository interface {
+	Create(ctx context.Context, msg *domain.RoomChatMessage) error
+	// GetByRoomID returns a room's messages, oldest first
+	GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.RoomChatMessage, error)
+}
+
 type FearlessBanRepository interface {
 	Create(ctx context.Context, ban *domain.FearlessBan) error
+	// CreateMany records a game's bans in one transaction, so either all of them are saved or none
+	CreateMany(ctx context.Context, bans []*domain.FearlessBan) error
 	GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*domain.FearlessBan, error)
 }
 
+type DraftTemplateRepository interface {
+	Create(ctx context.Context, template *domain.DraftTemplate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.DraftTemplate, error)
+	GetByName(ctx context.Context, name string) (*domain.DraftTemplate, error)
+	GetAll(ctx context.Context) ([]*domain.DraftTemplate, error)
+	Update(ctx context.Context, template *domain.DraftTemplate) error
+}
+
+type SeriesRepository interface {
+	Create(ctx context.Context, series *domain.Series) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Series, error)
+	Update(ctx context.Context, series *domain.Series) error
+	// UpdateFromGame saves the series only if it is still on currentGame, so two requests
+	// can't both move it on. It returns false if another request got there first
+	UpdateFromGame(ctx context.Context, series *domain.Series, currentGame int) (bool, error)
+}
+
 type UserRoleProfileRepository interface {
 	Create(ctx context.Context, profile *domain.UserRoleProfile) error
 	CreateMany(ctx context.Context, profiles []*domain.UserRoleProfile) error
@@ -65,6 +118,11 @@ type UserRoleProfileRepository interface {
 	GetByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]*domain.UserRoleProfile, error)
 }
 
+type RatingChangeRepository interface {
+	Record(ctx context.Context, profiles []*domain.UserRoleProfile, changes []*domain.RatingChange) error
+	GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.RatingChange, error)
+}
+
 type LobbyRepository interface {
 	Create(ctx context.Context, lobby *domain.Lobby) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Lobby, error)
@@ -85,6 +143,20 @@ type LobbyPlayerRepository interface {
 		Team domain.Side
 		Role domain.Role
 	}) error
+	GetRecentParticipation(ctx context.Context, userIDs []uuid.UUID, since time.Time) (map[uuid.UUID]*domain.LobbyParticipation, error)
+}
+
+type LobbyPreferenceRepository interface {
+	Create(ctx context.Context, pref *domain.LobbyPreference) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.LobbyPreference, error)
+	GetByLobbyID(ctx context.Context, lobbyID uuid.UUID) ([]*domain.LobbyPreference, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ChatMessageRepository interface {
+	Create(ctx context.Context, msg *domain.ChatMessage) error
+	// GetRecentByLobbyID returns a lobby's latest messages, oldest first
+	GetRecentByLobbyID(ctx context.Context, lobbyID uuid.UUID, limit int) ([]*domain.ChatMessage, error)
 }
 
 type MatchOptionRepository interface {
@@ -101,6 +173,7 @@ type RoomPlayerRepository interface {
 	GetByRoomID(ctx context.Context, roomId uuid.UUID) ([]*domain.RoomPlayer, error)
 	GetByRoomAndUser(ctx context.Context, roomId, userId uuid.UUID) (*domain.RoomPlayer, error)
 	GetCaptains(ctx context.Context, roomId uuid.UUID) (map[string]*domain.RoomPlayer, error)
+	Update(ctx context.Context, player *domain.RoomPlayer) error
 }
 
 type PendingActionRepository interface {
@@ -125,19 +198,35 @@ type VoteRepository interface {
 	DeleteByLobbyUserAndOption(ctx context.Context, lobbyID, userID uuid.UUID, optionNumber int) error
 }
 
+// Transactor runs changes that span several repositories in one database transaction
+type Transactor interface {
+	// WithinTransaction calls fn with repositories bound to a transaction, committing it
+	// if fn returns nil and rolling it back otherwise
+	WithinTransaction(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
 type Repositories struct {
 	User            UserRepository
 	Session         SessionRepository
+	PasswordReset   PasswordResetTokenRepository
 	Room            RoomRepository
 	DraftState      DraftStateRepository
 	DraftAction     DraftActionRepository
+	PickAssignment  PickAssignmentRepository
+	RoomChatMessage RoomChatMessageRepository
 	Champion        ChampionRepository
 	FearlessBan     FearlessBanRepository
+	Series          SeriesRepository
+	DraftTemplate   DraftTemplateRepository
 	UserRoleProfile UserRoleProfileRepository
+	RatingChange    RatingChangeRepository
 	Lobby           LobbyRepository
 	LobbyPlayer     LobbyPlayerRepository
+	LobbyPreference LobbyPreferenceRepository
+	ChatMessage     ChatMessageRepository
 	MatchOption     MatchOptionRepository
 	RoomPlayer      RoomPlayerRepository
 	PendingAction   PendingActionRepository
 	Vote            VoteRepository
+	Transactor      Transactor
 }
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type chatMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewChatMessageRepository(db *gorm.DB) *chatMessageRepository {
+	return &chatMessageRepository{db: db}
+}
+
+func (r *chatMessageRepository) Create(ctx context.Context, msg *domain.ChatMessage) error {
+	return r.db.WithContext(ctx).Create(msg).Error
+}
+
+func (r *chatMessageRepository) GetRecentByLobbyID(ctx context.Context, lobbyID uuid.UUID, limit int) ([]*domain.ChatMessage, error) {
+	var messages []*domain.ChatMessage
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("lobby_id = ?", lobbyID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Oldest first
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
@@ -20,14 +20,22 @@ func NewConnection(databaseURL string) (*gorm.DB, error) {
 	err = db.AutoMigrate(
 		&domain.User{},
 		&domain.UserSession{},
+		&domain.PasswordResetToken{},
 		&domain.Room{},
 		&domain.DraftState{},
 		&domain.DraftAction{},
+		&domain.PickAssignment{},
+		&domain.RoomChatMessage{},
 		&domain.Champion{},
 		&domain.FearlessBan{},
+		&domain.Series{},
+		&domain.DraftTemplate{},
 		&domain.UserRoleProfile{},
+		&domain.RatingChange{},
 		&domain.Lobby{},
 		&domain.LobbyPlayer{},
+		&domain.LobbyPreference{},
+		&domain.ChatMessage{},
 		&domain.MatchOption{},
 		&domain.MatchOptionAssignment{},
 		&domain.RoomPlayer{},
@@ -45,17 +53,26 @@ func NewRepositories(db *gorm.DB) *repository.Repositories {
 	return &repository.Repositories{
 		User:            NewUserRepository(db),
 		Session:         NewSessionRepository(db),
+		PasswordReset:   NewPasswordResetTokenRepository(db),
 		Room:            NewRoomRepository(db),
 		DraftState:      NewDraftStateRepository(db),
 		DraftAction:     NewDraftActionRepository(db),
+		PickAssignment:  NewPickAssignmentRepository(db),
+		RoomChatMessage: NewRoomChatMessageRepository(db),
 		Champion:        NewChampionRepository(db),
 		FearlessBan:     NewFearlessBanRepository(db),
+		Series:          NewSeriesRepository(db),
+		DraftTemplate:   NewDraftTemplateRepository(db),
 		UserRoleProfile: NewUserRoleProfileRepository(db),
+		RatingChange:    NewRatingChangeRepository(db),
 		Lobby:           NewLobbyRepository(db),
 		LobbyPlayer:     NewLobbyPlayerRepository(db),
+		LobbyPreference: NewLobbyPreferenceRepository(db),
+		ChatMessage:     NewChatMessageRepository(db),
 		MatchOption:     NewMatchOptionRepository(db),
 		RoomPlayer:      NewRoomPlayerRepository(db),
 		PendingAction:   NewPendingActionRepository(db),
 		Vote:            NewVoteRepository(db),
+		Transactor:      NewTransactor(db),
 	}
 }
@@ -56,3 +56,16 @@ func (r *draftActionRepository) GetByRoomID(ctx context.Context, roomID uuid.UUI
 	}
 	return actions, nil
 }
+
+func (r *draftActionRepository) GetRecentPicksByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DraftAction, error) {
+	var actions []*domain.DraftAction
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND action_type = ?", userID, domain.ActionTypePick).
+		Order("action_time DESC").
+		Limit(limit).
+		Find(&actions).Error
+	if err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type draftTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewDraftTemplateRepository(db *gorm.DB) *draftTemplateRepository {
+	return &draftTemplateRepository{db: db}
+}
+
+func (r *draftTemplateRepository) Create(ctx context.Context, template *domain.DraftTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *draftTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.DraftTemplate, error) {
+	var template domain.DraftTemplate
+	err := r.db.WithContext(ctx).First(&template, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *draftTemplateRepository) GetByName(ctx context.Context, name string) (*domain.DraftTemplate, error) {
+	var template domain.DraftTemplate
+	err := r.db.WithContext(ctx).First(&template, "name = ?", name).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *draftTemplateRepository) GetAll(ctx context.Context) ([]*domain.DraftTemplate, error) {
+	var templates []*domain.DraftTemplate
+	err := r.db.WithContext(ctx).
+		Order("is_built_in DESC, name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *draftTemplateRepository) Update(ctx context.Context, template *domain.DraftTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
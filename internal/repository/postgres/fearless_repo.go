@@ -20,6 +20,15 @@ func (r *fearlessBanRepository) Create(ctx context.Context, ban *domain.Fearless
 	return r.db.WithContext(ctx).Create(ban).Error
 }
 
+func (r *fearlessBanRepository) CreateMany(ctx context.Context, bans []*domain.FearlessBan) error {
+	if len(bans) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(bans, 100).Error
+	})
+}
+
 func (r *fearlessBanRepository) GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*domain.FearlessBan, error) {
 	var bans []*domain.FearlessBan
 	err := r.db.WithContext(ctx).
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/google/uuid"
@@ -69,7 +70,9 @@ func (r *lobbyPlayerRepository) UpdateTeamAssignments(ctx context.Context, lobby
 	Role domain.Role
 }) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userIDs := make([]uuid.UUID, 0, len(assignments))
 		for userID, assignment := range assignments {
+			userIDs = append(userIDs, userID)
 			team := assignment.Team
 			role := assignment.Role
 			err := tx.Model(&domain.LobbyPlayer{}).
@@ -82,6 +85,37 @@ func (r *lobbyPlayerRepository) UpdateTeamAssignments(ctx context.Context, lobby
 				return err
 			}
 		}
-		return nil
+
+		// Players left out of the assignments sit out
+		return tx.Model(&domain.LobbyPlayer{}).
+			Where("lobby_id = ? AND user_id NOT IN ?", lobbyID, userIDs).
+			Updates(map[string]interface{}{
+				"team":          nil,
+				"assigned_role": nil,
+			}).Error
 	})
 }
+
+// GetRecentParticipation counts, per user, the lobbies started since the given time that they
+// played in or sat out of
+func (r *lobbyPlayerRepository) GetRecentParticipation(ctx context.Context, userIDs []uuid.UUID, since time.Time) (map[uuid.UUID]*domain.LobbyParticipation, error) {
+	var rows []*domain.LobbyParticipation
+	err := r.db.WithContext(ctx).
+		Model(&domain.LobbyPlayer{}).
+		Select("lobby_players.user_id, "+
+			"COUNT(*) FILTER (WHERE lobby_players.team IS NOT NULL) AS played, "+
+			"COUNT(*) FILTER (WHERE lobby_players.team IS NULL) AS sat_out").
+		Joins("JOIN lobbies ON lobbies.id = lobby_players.lobby_id").
+		Where("lobby_players.user_id IN ? AND lobbies.started_at >= ?", userIDs, since).
+		Group("lobby_players.user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	participation := make(map[uuid.UUID]*domain.LobbyParticipation, len(rows))
+	for _, row := range rows {
+		participation[row.UserID] = row
+	}
+	return participation, nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type lobbyPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewLobbyPreferenceRepository(db *gorm.DB) *lobbyPreferenceRepository {
+	return &lobbyPreferenceRepository{db: db}
+}
+
+func (r *lobbyPreferenceRepository) Create(ctx context.Context, pref *domain.LobbyPreference) error {
+	return r.db.WithContext(ctx).Create(pref).Error
+}
+
+func (r *lobbyPreferenceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.LobbyPreference, error) {
+	var pref domain.LobbyPreference
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&pref).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *lobbyPreferenceRepository) GetByLobbyID(ctx context.Context, lobbyID uuid.UUID) ([]*domain.LobbyPreference, error) {
+	var prefs []*domain.LobbyPreference
+	err := r.db.WithContext(ctx).
+		Where("lobby_id = ?", lobbyID).
+		Order("created_at").
+		Find(&prefs).Error
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func (r *lobbyPreferenceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.LobbyPreference{}, "id = ?", id).Error
+}
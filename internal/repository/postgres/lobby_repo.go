@@ -67,5 +67,26 @@ func (r *lobbyRepository) GetByUserID(ctx context.Context, userID uuid.UUID, lim
 }
 
 func (r *lobbyRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Lobby{}, "id = ?", id).Error
+	// Remove everything that references the lobby before the lobby itself
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		optionIDs := tx.Model(&domain.MatchOption{}).Select("id").Where("lobby_id = ?", id)
+		if err := tx.Where("match_option_id IN (?)", optionIDs).Delete(&domain.MatchOptionAssignment{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&domain.MatchOption{},
+			&domain.Vote{},
+			&domain.PendingAction{},
+			&domain.LobbyPreference{},
+			&domain.ChatMessage{},
+			&domain.LobbyPlayer{},
+		} {
+			if err := tx.Where("lobby_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&domain.Lobby{}, "id = ?", id).Error
+	})
 }
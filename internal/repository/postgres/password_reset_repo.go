@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) *passwordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *passwordResetTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.WithContext(ctx).First(&token, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	// Only one concurrent reset can use the token
+	result := r.db.WithContext(ctx).
+		Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *passwordResetTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.PasswordResetToken{}, "user_id = ?", userID).Error
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type pickAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewPickAssignmentRepository(db *gorm.DB) *pickAssignmentRepository {
+	return &pickAssignmentRepository{db: db}
+}
+
+// ReplaceForRoom swaps a room's pick assignments for the given ones in a single transaction
+func (r *pickAssignmentRepository) ReplaceForRoom(ctx context.Context, roomID uuid.UUID, assignments []*domain.PickAssignment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("room_id = ?", roomID).Delete(&domain.PickAssignment{}).Error; err != nil {
+			return err
+		}
+		if len(assignments) == 0 {
+			return nil
+		}
+		return tx.Create(&assignments).Error
+	})
+}
+
+func (r *pickAssignmentRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.PickAssignment, error) {
+	var assignments []*domain.PickAssignment
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("team, pick_order").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ratingChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewRatingChangeRepository(db *gorm.DB) *ratingChangeRepository {
+	return &ratingChangeRepository{db: db}
+}
+
+// Record saves the updated ratings on the role profiles and their rating changes in a single transaction.
+// Only the rating columns of existing profiles are written, so concurrent rank edits are kept
+func (r *ratingChangeRepository) Record(ctx context.Context, profiles []*domain.UserRoleProfile, changes []*domain.RatingChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(profiles) > 0 {
+			now := time.Now()
+			for _, p := range profiles {
+				p.UpdatedAt = now
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "role"}},
+				DoUpdates: clause.AssignmentColumns([]string{"rating", "rating_deviation", "rating_volatility", "rated_games", "updated_at"}),
+			}).Create(&profiles).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return tx.Create(&changes).Error
+	})
+}
+
+func (r *ratingChangeRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.RatingChange, error) {
+	var changes []*domain.RatingChange
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at").
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type roomChatMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomChatMessageRepository(db *gorm.DB) *roomChatMessageRepository {
+	return &roomChatMessageRepository{db: db}
+}
+
+func (r *roomChatMessageRepository) Create(ctx context.Context, msg *domain.RoomChatMessage) error {
+	return r.db.WithContext(ctx).Create(msg).Error
+}
+
+func (r *roomChatMessageRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*domain.RoomChatMessage, error) {
+	var messages []*domain.RoomChatMessage
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("room_id = ?", roomID).
+		Order("created_at ASC").
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
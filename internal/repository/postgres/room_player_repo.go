@@ -65,3 +65,7 @@ func (r *roomPlayerRepository) GetCaptains(ctx context.Context, roomId uuid.UUID
 	}
 	return captains, nil
 }
+
+func (r *roomPlayerRepository) Update(ctx context.Context, player *domain.RoomPlayer) error {
+	return r.db.WithContext(ctx).Save(player).Error
+}
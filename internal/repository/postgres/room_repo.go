@@ -123,3 +123,27 @@ func (r *roomRepository) GetByIDWithDraftState(ctx context.Context, id uuid.UUID
 
 	return &room, &draftState, nil
 }
+
+func (r *roomRepository) GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*domain.Room, error) {
+	var rooms []*domain.Room
+	err := r.db.WithContext(ctx).
+		Where("series_id = ?", seriesID).
+		Order("game_number ASC").
+		Find(&rooms).Error
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *roomRepository) GetByStatus(ctx context.Context, status domain.RoomStatus) ([]*domain.Room, error) {
+	var rooms []*domain.Room
+	err := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&rooms).Error
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) *seriesRepository {
+	return &seriesRepository{db: db}
+}
+
+func (r *seriesRepository) Create(ctx context.Context, series *domain.Series) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+func (r *seriesRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Series, error) {
+	var series domain.Series
+	err := r.db.WithContext(ctx).First(&series, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *domain.Series) error {
+	return r.db.WithContext(ctx).Save(series).Error
+}
+
+func (r *seriesRepository) UpdateFromGame(ctx context.Context, series *domain.Series, currentGame int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(series).
+		Where("current_game = ?", currentGame).
+		Select("*").
+		Updates(series)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
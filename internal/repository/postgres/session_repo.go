@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/google/uuid"
@@ -20,6 +21,15 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.UserSess
 	return r.db.WithContext(ctx).Create(session).Error
 }
 
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserSession, error) {
+	var session domain.UserSession
+	err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSession, error) {
 	var session domain.UserSession
 	err := r.db.WithContext(ctx).First(&session, "user_id = ?", userID).Error
@@ -29,6 +39,18 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 	return &session, nil
 }
 
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	// Only one concurrent refresh can claim the session
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 func (r *sessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.UserSession{}, "id = ?", id).Error
 }
@@ -36,3 +58,18 @@ func (r *sessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.UserSession{}, "user_id = ?", userID).Error
 }
+
+func (r *sessionRepository) DeleteByFamilyID(ctx context.Context, userID, familyID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.UserSession{}, "user_id = ? AND family_id = ?", userID, familyID).Error
+}
+
+func (r *sessionRepository) DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.UserSession{}, "user_id = ? AND expires_at < ?", userID, time.Now()).Error
+}
+
+func (r *sessionRepository) PruneFamily(ctx context.Context, userID, familyID, keepID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND family_id = ? AND id <> ?", userID, familyID, keepID).
+		Where("revoked_at IS NOT NULL OR expires_at < ?", time.Now()).
+		Delete(&domain.UserSession{}).Error
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/repository"
+	"gorm.io/gorm"
+)
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/google/uuid"
@@ -41,3 +42,59 @@ func (r *userRepository) GetByDisplayName(ctx context.Context, displayName strin
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	return r.db.WithContext(ctx).Save(user).Error
 }
+
+func (r *userRepository) HasRole(ctx context.Context, role domain.UserRole) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("role = ?", role).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	anonymousName := domain.AnonymousDisplayName(userID)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Model(&domain.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"display_name":  anonymousName,
+				"password_hash": "",
+				"role":          domain.UserRolePlayer,
+				"deleted_at":    now,
+				"updated_at":    now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Rooms keep their players and actions, just without the user's name
+		err = tx.Model(&domain.RoomPlayer{}).
+			Where("user_id = ?", userID).
+			Update("display_name", anonymousName).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&domain.DraftAction{}).
+			Where("user_id = ?", userID).
+			Update("user_id", nil).Error
+		if err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&domain.UserRoleProfile{},
+			&domain.UserSession{},
+			&domain.PasswordResetToken{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidUserRole = errors.New("invalid user role")
+	ErrRoomNotOpen     = errors.New("room has already finished")
+)
+
+// AdminService handles site moderation that isn't tied to a lobby or room's own players
+type AdminService struct {
+	userRepo        repository.UserRepository
+	roleProfileRepo repository.UserRoleProfileRepository
+	lobbyRepo       repository.LobbyRepository
+	roomRepo        repository.RoomRepository
+}
+
+func NewAdminService(
+	userRepo repository.UserRepository,
+	roleProfileRepo repository.UserRoleProfileRepository,
+	lobbyRepo repository.LobbyRepository,
+	roomRepo repository.RoomRepository,
+) *AdminService {
+	return &AdminService{
+		userRepo:        userRepo,
+		roleProfileRepo: roleProfileRepo,
+		lobbyRepo:       lobbyRepo,
+		roomRepo:        roomRepo,
+	}
+}
+
+// SetUserRole changes a user's site-wide role
+func (s *AdminService) SetUserRole(ctx context.Context, userID uuid.UUID, role domain.UserRole) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidUserRole
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// EnsureAdmins promotes the given users to admin while the site has no admin yet, so a
+// fresh install has someone who can manage roles. Once an admin exists roles are only
+// changed through the admin API, and unknown or deleted accounts are skipped
+func (s *AdminService) EnsureAdmins(ctx context.Context, userIDs []uuid.UUID) (int, error) {
+	hasAdmin, err := s.userRepo.HasRole(ctx, domain.UserRoleAdmin)
+	if err != nil || hasAdmin {
+		return 0, err
+	}
+
+	promoted := 0
+	for _, id := range userIDs {
+		user, err := s.userRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return promoted, err
+		}
+		if user.IsDeleted() || user.Role == domain.UserRoleAdmin {
+			continue
+		}
+
+		user.Role = domain.UserRoleAdmin
+		user.UpdatedAt = time.Now()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return promoted, err
+		}
+		promoted++
+	}
+	return promoted, nil
+}
+
+// ResetUserMMR discards a user's earned ratings on every role, returning each role to the
+// MMR of its self-reported rank
+func (s *AdminService) ResetUserMMR(ctx context.Context, userID uuid.UUID) ([]*domain.UserRoleProfile, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	profiles, err := s.roleProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		profile.ResetRating()
+		if err := s.roleProfileRepo.Update(ctx, profile); err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+// DeleteLobby removes a lobby along with its players, votes, options and chat
+func (s *AdminService) DeleteLobby(ctx context.Context, lobbyID uuid.UUID) error {
+	if _, err := s.lobbyRepo.GetByID(ctx, lobbyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLobbyNotFound
+		}
+		return err
+	}
+	return s.lobbyRepo.Delete(ctx, lobbyID)
+}
+
+// CloseRoom marks an unfinished room as closed so it is never restored or reported on
+func (s *AdminService) CloseRoom(ctx context.Context, roomID uuid.UUID) (*domain.Room, error) {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, err
+	}
+	if room.Status == domain.RoomStatusCompleted || room.Status == domain.RoomStatusClosed {
+		return nil, ErrRoomNotOpen
+	}
+
+	now := time.Now()
+	room.Status = domain.RoomStatusClosed
+	room.CompletedAt = &now
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
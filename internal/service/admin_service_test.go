@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRole_Includes(t *testing.T) {
+	assert.True(t, domain.UserRoleAdmin.Includes(domain.UserRoleOrganizer))
+	assert.True(t, domain.UserRoleAdmin.Includes(domain.UserRolePlayer))
+	assert.True(t, domain.UserRoleOrganizer.Includes(domain.UserRoleOrganizer))
+	assert.False(t, domain.UserRoleOrganizer.Includes(domain.UserRoleAdmin))
+	assert.False(t, domain.UserRolePlayer.Includes(domain.UserRoleOrganizer))
+	assert.False(t, domain.UserRole("").Includes(domain.UserRolePlayer))
+	assert.False(t, domain.UserRole("superuser").IsValid())
+}
+
+func TestAdminService(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+	db := ts.DB.DB
+	admin := ts.Services.Admin
+
+	player, _ := testutil.NewUserBuilder().WithDisplayName("AdminTarget").Build(t, db)
+
+	t.Run("set user role", func(t *testing.T) {
+		user, err := admin.SetUserRole(ctx, player.ID, domain.UserRoleOrganizer)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserRoleOrganizer, user.Role)
+
+		_, err = admin.SetUserRole(ctx, player.ID, "superuser")
+		assert.ErrorIs(t, err, service.ErrInvalidUserRole)
+		_, err = admin.SetUserRole(ctx, uuid.New(), domain.UserRoleAdmin)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+
+	t.Run("ensure admins", func(t *testing.T) {
+		promoted, err := admin.EnsureAdmins(ctx, []uuid.UUID{player.ID, uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, 1, promoted)
+
+		user, err := ts.Repos.User.GetByID(ctx, player.ID)
+		require.NoError(t, err)
+		assert.True(t, user.HasRole(domain.UserRoleAdmin))
+
+		// Once the site has an admin, no one else is promoted on startup
+		other, _ := testutil.NewUserBuilder().WithDisplayName("LaterAdmin").Build(t, db)
+		promoted, err = admin.EnsureAdmins(ctx, []uuid.UUID{other.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 0, promoted)
+
+		user, err = ts.Repos.User.GetByID(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserRolePlayer, user.Role)
+	})
+
+	t.Run("reset mmr", func(t *testing.T) {
+		rated := createUserUniform(t, db, "RatedPlayer", 1900, 3)
+		profiles, err := ts.Repos.UserRoleProfile.GetByUserID(ctx, rated.ID)
+		require.NoError(t, err)
+		for _, p := range profiles {
+			p.LeagueRank = domain.RankGold1
+			p.ApplyRating(domain.Glicko2Rating{Rating: 2400, Deviation: 80, Volatility: 0.06})
+			require.NoError(t, ts.Repos.UserRoleProfile.Update(ctx, p))
+		}
+
+		reset, err := admin.ResetUserMMR(ctx, rated.ID)
+		require.NoError(t, err)
+		require.Len(t, reset, len(domain.AllRoles))
+		for _, p := range reset {
+			assert.Equal(t, domain.RankGold1.ToMMR(), p.MMR)
+			assert.Equal(t, 0, p.RatedGames)
+			assert.Equal(t, domain.DefaultRating, p.Rating)
+		}
+	})
+
+	t.Run("delete lobby", func(t *testing.T) {
+		lobby := &domain.Lobby{
+			ID:        uuid.New(),
+			ShortCode: "ADMN01",
+			CreatedBy: player.ID,
+			Status:    domain.LobbyStatusWaitingForPlayers,
+		}
+		require.NoError(t, db.Create(lobby).Error)
+		createLobbyPlayer(t, db, lobby.ID, player, true)
+		_, err := ts.Services.Lobby.PostSystemMessage(ctx, lobby.ID, "welcome")
+		require.NoError(t, err)
+
+		require.NoError(t, admin.DeleteLobby(ctx, lobby.ID))
+		_, err = ts.Repos.Lobby.GetByID(ctx, lobby.ID)
+		assert.Error(t, err)
+		count, err := ts.Repos.LobbyPlayer.CountByLobbyID(ctx, lobby.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		assert.ErrorIs(t, admin.DeleteLobby(ctx, lobby.ID), service.ErrLobbyNotFound)
+	})
+
+	t.Run("close room", func(t *testing.T) {
+		room := testutil.NewRoomBuilder().WithCreator(player).Build(t, db)
+
+		closed, err := admin.CloseRoom(ctx, room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoomStatusClosed, closed.Status)
+
+		_, err = admin.CloseRoom(ctx, room.ID)
+		assert.ErrorIs(t, err, service.ErrRoomNotOpen)
+		_, err = admin.CloseRoom(ctx, uuid.New())
+		assert.ErrorIs(t, err, service.ErrRoomNotFound)
+	})
+}
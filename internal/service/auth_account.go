@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// passwordResetTTL is how long an admin-issued reset token can be used
+	passwordResetTTL = 24 * time.Hour
+
+	maxDisplayNameLength = 32
+)
+
+var (
+	ErrInvalidPassword    = errors.New("password is required")
+	ErrInvalidDisplayName = errors.New("display name must be 1 to 32 characters")
+	ErrInvalidResetToken  = errors.New("invalid or expired password reset token")
+)
+
+// PasswordReset is a one-time reset token to hand to the user
+type PasswordReset struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// ChangePassword sets a new password after checking the old one. Every session is signed out,
+// and the caller gets a fresh session on their current device
+func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) (*AuthResult, error) {
+	if newPassword == "" {
+		return nil, ErrInvalidPassword
+	}
+
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	return s.generateTokens(ctx, user, uuid.New())
+}
+
+// ChangeDisplayName renames a user if the new name isn't taken
+func (s *AuthService) ChangeDisplayName(ctx context.Context, userID uuid.UUID, displayName string) (*domain.User, error) {
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" || len([]rune(displayName)) > maxDisplayNameLength {
+		return nil, ErrInvalidDisplayName
+	}
+	if domain.IsReservedDisplayName(displayName) {
+		return nil, ErrDisplayNameReserved
+	}
+
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisplayName == displayName {
+		return user, nil
+	}
+
+	existing, err := s.userRepo.GetByDisplayName(ctx, displayName)
+	if err == nil && existing != nil {
+		return nil, ErrDisplayNameExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user.DisplayName = displayName
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// IssuePasswordReset creates a one-time token an admin can give a user who forgot their password.
+// The token is only returned here; just its hash is stored
+func (s *AuthService) IssuePasswordReset(ctx context.Context, userID, issuedBy uuid.UUID) (*PasswordReset, error) {
+	if _, err := s.getActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	secret, hashed, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: string(hashed),
+		CreatedBy: issuedBy,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+		CreatedAt: time.Now(),
+	}
+	if err := s.resetRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return &PasswordReset{
+		Token:     token.ID.String() + "." + secret,
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+// ResetPassword sets a new password with a reset token, signs out every session
+// and signs the user in
+func (s *AuthService) ResetPassword(ctx context.Context, resetToken, newPassword string) (*AuthResult, error) {
+	if newPassword == "" {
+		return nil, ErrInvalidPassword
+	}
+
+	tokenID, secret, ok := splitToken(resetToken)
+	if !ok {
+		return nil, ErrInvalidResetToken
+	}
+	token, err := s.resetRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(token.TokenHash), []byte(secret)); err != nil {
+		return nil, ErrInvalidResetToken
+	}
+	if !token.IsUsable() {
+		return nil, ErrInvalidResetToken
+	}
+
+	used, err := s.resetRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidResetToken
+	}
+
+	user, err := s.getActiveUser(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	return s.generateTokens(ctx, user, uuid.New())
+}
+
+// DeleteAccount deletes a user's account after checking their password. The user is
+// anonymized rather than removed, so the rooms and match history they took part in stay intact
+func (s *AuthService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	return s.userRepo.Anonymize(ctx, userID)
+}
+
+// getActiveUser returns a user that hasn't deleted their account
+func (s *AuthService) getActiveUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// setPassword stores a new password and signs out every session
+func (s *AuthService) setPassword(ctx context.Context, user *domain.User, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = string(hashedPassword)
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.sessionRepo.DeleteByUserID(ctx, user.ID)
+}
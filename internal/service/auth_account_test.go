@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	_, rawPassword := testutil.NewUserBuilder().
+		WithDisplayName("changepw").
+		WithPassword("password123").
+		Build(t, testDB.DB)
+	other, err := authService.Login(ctx, service.LoginInput{DisplayName: "changepw", Password: rawPassword})
+	require.NoError(t, err)
+
+	_, err = authService.ChangePassword(ctx, other.User.ID, "wrongpassword", "newpassword456")
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	_, err = authService.ChangePassword(ctx, other.User.ID, rawPassword, "")
+	assert.ErrorIs(t, err, service.ErrInvalidPassword)
+
+	result, err := authService.ChangePassword(ctx, other.User.ID, rawPassword, "newpassword456")
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.RefreshToken)
+
+	// Sessions from before the change are signed out, but the new one works
+	_, err = authService.RefreshTokens(ctx, other.RefreshToken)
+	assert.Error(t, err)
+	_, err = authService.RefreshTokens(ctx, result.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = authService.Login(ctx, service.LoginInput{DisplayName: "changepw", Password: rawPassword})
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	_, err = authService.Login(ctx, service.LoginInput{DisplayName: "changepw", Password: "newpassword456"})
+	assert.NoError(t, err)
+}
+
+func TestAuthService_ChangeDisplayName(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	user, _ := testutil.NewUserBuilder().WithDisplayName("oldname").Build(t, testDB.DB)
+	testutil.NewUserBuilder().WithDisplayName("takenname").Build(t, testDB.DB)
+
+	_, err := authService.ChangeDisplayName(ctx, user.ID, "takenname")
+	assert.ErrorIs(t, err, service.ErrDisplayNameExists)
+	_, err = authService.ChangeDisplayName(ctx, user.ID, "   ")
+	assert.ErrorIs(t, err, service.ErrInvalidDisplayName)
+	_, err = authService.ChangeDisplayName(ctx, user.ID, "Deleted User 1234abcd")
+	assert.ErrorIs(t, err, service.ErrDisplayNameReserved)
+
+	updated, err := authService.ChangeDisplayName(ctx, user.ID, "  newname  ")
+	require.NoError(t, err)
+	assert.Equal(t, "newname", updated.DisplayName)
+
+	stored, err := repos.User.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "newname", stored.DisplayName)
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	admin, _ := testutil.NewUserBuilder().WithRole(domain.UserRoleAdmin).Build(t, testDB.DB)
+	user, _ := testutil.NewUserBuilder().WithDisplayName("forgetful").Build(t, testDB.DB)
+
+	_, err := authService.IssuePasswordReset(ctx, uuid.New(), admin.ID)
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+	reset, err := authService.IssuePasswordReset(ctx, user.ID, admin.ID)
+	require.NoError(t, err)
+	assert.True(t, reset.ExpiresAt.After(time.Now()))
+
+	_, err = authService.ResetPassword(ctx, "not-a-token", "newpassword456")
+	assert.ErrorIs(t, err, service.ErrInvalidResetToken)
+
+	result, err := authService.ResetPassword(ctx, reset.Token, "newpassword456")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.User.ID)
+
+	// Reset tokens only work once
+	_, err = authService.ResetPassword(ctx, reset.Token, "anotherpassword")
+	assert.ErrorIs(t, err, service.ErrInvalidResetToken)
+
+	_, err = authService.Login(ctx, service.LoginInput{DisplayName: "forgetful", Password: "newpassword456"})
+	assert.NoError(t, err)
+}
+
+func TestAuthService_DeleteAccount(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	user, rawPassword := testutil.NewUserBuilder().
+		WithDisplayName("leaving").
+		WithPassword("password123").
+		Build(t, testDB.DB)
+	teammate, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	room := testutil.NewRoomBuilder().
+		WithCreator(user).
+		WithTeamPlayer(user, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(teammate, domain.SideRed, domain.RoleTop, true).
+		Build(t, testDB.DB)
+	action := &domain.DraftAction{
+		ID:         uuid.New(),
+		RoomID:     room.ID,
+		Team:       domain.SideBlue,
+		ActionType: domain.ActionTypeBan,
+		ChampionID: "Ahri",
+		UserID:     &user.ID,
+		ActionTime: time.Now(),
+	}
+	require.NoError(t, testDB.DB.Create(action).Error)
+
+	session, err := authService.Login(ctx, service.LoginInput{DisplayName: "leaving", Password: rawPassword})
+	require.NoError(t, err)
+
+	err = authService.DeleteAccount(ctx, user.ID, "wrongpassword")
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+
+	require.NoError(t, authService.DeleteAccount(ctx, user.ID, rawPassword))
+
+	// The room and its history stay, without the user's name
+	players, err := repos.RoomPlayer.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	require.Len(t, players, 2)
+	for _, player := range players {
+		if player.UserID == user.ID {
+			assert.Equal(t, domain.AnonymousDisplayName(user.ID), player.DisplayName)
+		}
+	}
+	var stored domain.DraftAction
+	require.NoError(t, testDB.DB.First(&stored, "id = ?", action.ID).Error)
+	assert.Nil(t, stored.UserID)
+
+	// The account can no longer sign in, and its name is free again
+	_, err = authService.Login(ctx, service.LoginInput{DisplayName: "leaving", Password: rawPassword})
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	_, err = authService.RefreshTokens(ctx, session.RefreshToken)
+	assert.Error(t, err)
+	_, err = authService.Register(ctx, service.RegisterInput{DisplayName: "leaving", Password: "password123"})
+	assert.NoError(t, err)
+}
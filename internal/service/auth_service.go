@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/dom/league-draft-website/internal/config"
@@ -17,19 +20,24 @@ import (
 var (
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrDisplayNameExists    = errors.New("display name already exists")
+	ErrDisplayNameReserved  = errors.New("display name is reserved")
 	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidRefreshToken  = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token was already used")
 )
 
 type AuthService struct {
 	userRepo    repository.UserRepository
 	sessionRepo repository.SessionRepository
+	resetRepo   repository.PasswordResetTokenRepository
 	cfg         *config.Config
 }
 
-func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, cfg *config.Config) *AuthService {
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, resetRepo repository.PasswordResetTokenRepository, cfg *config.Config) *AuthService {
 	return &AuthService{
 		userRepo:    userRepo,
 		sessionRepo: sessionRepo,
+		resetRepo:   resetRepo,
 		cfg:         cfg,
 	}
 }
@@ -51,6 +59,10 @@ type AuthResult struct {
 }
 
 func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthResult, error) {
+	if domain.IsReservedDisplayName(input.DisplayName) {
+		return nil, ErrDisplayNameReserved
+	}
+
 	// Check if display name exists
 	existing, err := s.userRepo.GetByDisplayName(ctx, input.DisplayName)
 	if err == nil && existing != nil {
@@ -67,6 +79,7 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 		ID:           uuid.New(),
 		PasswordHash: string(hashedPassword),
 		DisplayName:  input.DisplayName,
+		Role:         domain.UserRolePlayer,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -75,7 +88,7 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 		return nil, err
 	}
 
-	return s.generateTokens(ctx, user)
+	return s.generateTokens(ctx, user, uuid.New())
 }
 
 func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthResult, error) {
@@ -91,32 +104,34 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthResult,
 		return nil, ErrInvalidCredentials
 	}
 
-	return s.generateTokens(ctx, user)
+	// Each login is its own session family, so other devices stay signed in
+	_ = s.sessionRepo.DeleteExpiredByUserID(ctx, user.ID)
+
+	return s.generateTokens(ctx, user, uuid.New())
 }
 
-func (s *AuthService) generateTokens(ctx context.Context, user *domain.User) (*AuthResult, error) {
+// generateTokens issues an access token and a new refresh token in the given session family.
+// The refresh token is "<session id>.<secret>" so its session can be looked up directly
+func (s *AuthService) generateTokens(ctx context.Context, user *domain.User, familyID uuid.UUID) (*AuthResult, error) {
 	// Generate access token
-	accessToken, err := s.generateAccessToken(user)
+	accessToken, err := s.generateAccessToken(user, familyID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate refresh token
-	refreshToken := uuid.New().String()
-	hashedRefresh, err := bcrypt.GenerateFromPassword([]byte(refreshToken), bcrypt.DefaultCost)
+	secret, hashedRefresh, err := generateSecret()
 	if err != nil {
 		return nil, err
 	}
 
-	// Delete old sessions
-	_ = s.sessionRepo.DeleteByUserID(ctx, user.ID)
-
 	// Store session
 	session := &domain.UserSession{
 		ID:               uuid.New(),
 		UserID:           user.ID,
+		FamilyID:         familyID,
 		RefreshTokenHash: string(hashedRefresh),
-		ExpiresAt:        time.Now().Add(7 * 24 * time.Hour), // 7 days
+		ExpiresAt:        time.Now().Add(s.cfg.RefreshTokenTTL),
 		CreatedAt:        time.Now(),
 	}
 
@@ -127,15 +142,43 @@ func (s *AuthService) generateTokens(ctx context.Context, user *domain.User) (*A
 	return &AuthResult{
 		User:         user,
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: session.ID.String() + "." + secret,
 	}, nil
 }
 
-func (s *AuthService) generateAccessToken(user *domain.User) (string, error) {
+// generateSecret returns a random token secret and its bcrypt hash
+func generateSecret() (string, []byte, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+	secret := hex.EncodeToString(raw)
+	hashed, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, hashed, nil
+}
+
+// splitToken splits an "<id>.<secret>" token into its parts
+func splitToken(token string) (uuid.UUID, string, bool) {
+	idPart, secret, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, "", false
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	return id, secret, true
+}
+
+func (s *AuthService) generateAccessToken(user *domain.User, familyID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
 		"sub":  user.ID.String(),
+		"sid":  familyID.String(),
 		"name": user.DisplayName,
-		"exp":  time.Now().Add(time.Duration(s.cfg.JWTExpirationHours) * time.Hour).Unix(),
+		"exp":  time.Now().Add(s.cfg.AccessTokenTTL).Unix(),
 		"iat":  time.Now().Unix(),
 	}
 
@@ -166,12 +209,71 @@ func (s *AuthService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.Us
 	return s.userRepo.GetByID(ctx, id)
 }
 
+// RefreshTokens exchanges a refresh token for a new access token and refresh token.
+// A refresh token can only be used once. Presenting one that was already rotated means
+// it was stolen or replayed, so the whole session family is revoked
 func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*AuthResult, error) {
-	// This is a simplified implementation
-	// In production, you'd want to look up the session by a token identifier
-	return nil, errors.New("not implemented")
+	sessionID, secret, ok := splitToken(refreshToken)
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(session.RefreshTokenHash), []byte(secret)); err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.IsRevoked() {
+		return nil, s.revokeFamily(ctx, session)
+	}
+	if session.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Another request may have rotated the session since it was read
+	claimed, err := s.sessionRepo.Revoke(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, s.revokeFamily(ctx, session)
+	}
+
+	// Only the session just rotated is kept revoked, so a replay of its token is still caught
+	_ = s.sessionRepo.PruneFamily(ctx, session.UserID, session.FamilyID, session.ID)
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return s.generateTokens(ctx, user, session.FamilyID)
 }
 
+// revokeFamily signs out every session descended from the same login as a reused refresh token
+func (s *AuthService) revokeFamily(ctx context.Context, session *domain.UserSession) error {
+	if err := s.sessionRepo.DeleteByFamilyID(ctx, session.UserID, session.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// Logout signs the user out on every device
 func (s *AuthService) Logout(ctx context.Context, userID uuid.UUID) error {
 	return s.sessionRepo.DeleteByUserID(ctx, userID)
 }
+
+// LogoutSession signs the user out of one session family, leaving their other devices signed in
+func (s *AuthService) LogoutSession(ctx context.Context, userID, familyID uuid.UUID) error {
+	return s.sessionRepo.DeleteByFamilyID(ctx, userID, familyID)
+}
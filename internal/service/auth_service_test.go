@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/dom/league-draft-website/internal/repository/postgres"
 	"github.com/dom/league-draft-website/internal/service"
 	"github.com/dom/league-draft-website/internal/testutil"
@@ -16,7 +17,7 @@ func TestAuthService_Register(t *testing.T) {
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
 	cfg := testutil.TestConfig()
-	authService := service.NewAuthService(repos.User, repos.Session, cfg)
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -48,6 +49,14 @@ func TestAuthService_Register(t *testing.T) {
 			},
 			wantErr: service.ErrDisplayNameExists,
 		},
+		{
+			name: "deleted user name",
+			input: service.RegisterInput{
+				DisplayName: "deleted user 1234abcd",
+				Password:    "password123",
+			},
+			wantErr: service.ErrDisplayNameReserved,
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,7 +90,7 @@ func TestAuthService_Login(t *testing.T) {
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
 	cfg := testutil.TestConfig()
-	authService := service.NewAuthService(repos.User, repos.Session, cfg)
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
 	ctx := context.Background()
 
 	// Create a user for login tests
@@ -142,7 +151,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
 	cfg := testutil.TestConfig()
-	authService := service.NewAuthService(repos.User, repos.Session, cfg)
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
 	ctx := context.Background()
 
 	// Register a user to get a valid token
@@ -198,7 +207,7 @@ func TestAuthService_GetUserByID(t *testing.T) {
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
 	cfg := testutil.TestConfig()
-	authService := service.NewAuthService(repos.User, repos.Session, cfg)
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
 	ctx := context.Background()
 
 	user, _ := testutil.NewUserBuilder().
@@ -242,7 +251,7 @@ func TestAuthService_Logout(t *testing.T) {
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
 	cfg := testutil.TestConfig()
-	authService := service.NewAuthService(repos.User, repos.Session, cfg)
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
 	ctx := context.Background()
 
 	// Register a user to create a session
@@ -260,3 +269,110 @@ func TestAuthService_Logout(t *testing.T) {
 	err = authService.Logout(ctx, result.User.ID)
 	require.NoError(t, err)
 }
+
+func TestAuthService_RefreshTokens(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	_, rawPassword := testutil.NewUserBuilder().
+		WithDisplayName("refreshuser").
+		WithPassword("password123").
+		Build(t, testDB.DB)
+	login := service.LoginInput{DisplayName: "refreshuser", Password: rawPassword}
+
+	// Two logins are two independent sessions, such as a laptop and a phone
+	laptop, err := authService.Login(ctx, login)
+	require.NoError(t, err)
+	phone, err := authService.Login(ctx, login)
+	require.NoError(t, err)
+
+	rotated, err := authService.RefreshTokens(ctx, laptop.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, laptop.User.ID, rotated.User.ID)
+	assert.NotEmpty(t, rotated.AccessToken)
+	assert.NotEqual(t, laptop.RefreshToken, rotated.RefreshToken)
+
+	claims, err := authService.ValidateToken(rotated.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, laptop.User.ID.String(), (*claims)["sub"])
+
+	// Replaying the rotated token revokes every token in the laptop's family
+	_, err = authService.RefreshTokens(ctx, laptop.RefreshToken)
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+	_, err = authService.RefreshTokens(ctx, rotated.RefreshToken)
+	assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+
+	// The phone's session is untouched
+	_, err = authService.RefreshTokens(ctx, phone.RefreshToken)
+	require.NoError(t, err)
+
+	for _, token := range []string{"", "garbage", uuid.New().String() + ".secret", phone.RefreshToken + "x"} {
+		_, err = authService.RefreshTokens(ctx, token)
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken, "token %q", token)
+	}
+}
+
+func TestAuthService_RefreshTokensPrunesRotatedSessions(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	user, rawPassword := testutil.NewUserBuilder().
+		WithDisplayName("rotatinguser").
+		WithPassword("password123").
+		Build(t, testDB.DB)
+
+	result, err := authService.Login(ctx, service.LoginInput{DisplayName: "rotatinguser", Password: rawPassword})
+	require.NoError(t, err)
+	var previous string
+	for i := 0; i < 5; i++ {
+		previous = result.RefreshToken
+		result, err = authService.RefreshTokens(ctx, previous)
+		require.NoError(t, err)
+	}
+
+	// Only the live session and the one it replaced are left
+	var count int64
+	require.NoError(t, testDB.DB.Model(&domain.UserSession{}).Where("user_id = ?", user.ID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// The token just rotated out is still caught as a replay
+	_, err = authService.RefreshTokens(ctx, previous)
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+}
+
+func TestAuthService_LogoutSession(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	cfg := testutil.TestConfig()
+	authService := service.NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg)
+	ctx := context.Background()
+
+	_, rawPassword := testutil.NewUserBuilder().
+		WithDisplayName("sessionuser").
+		WithPassword("password123").
+		Build(t, testDB.DB)
+	login := service.LoginInput{DisplayName: "sessionuser", Password: rawPassword}
+
+	first, err := authService.Login(ctx, login)
+	require.NoError(t, err)
+	second, err := authService.Login(ctx, login)
+	require.NoError(t, err)
+
+	claims, err := authService.ValidateToken(first.AccessToken)
+	require.NoError(t, err)
+	familyID, err := uuid.Parse((*claims)["sid"].(string))
+	require.NoError(t, err)
+
+	require.NoError(t, authService.LogoutSession(ctx, first.User.ID, familyID))
+
+	_, err = authService.RefreshTokens(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+	_, err = authService.RefreshTokens(ctx, second.RefreshToken)
+	assert.NoError(t, err)
+}
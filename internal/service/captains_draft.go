@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	mathrand "math/rand"
+	"sort"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCaptainSelection = errors.New("invalid captain selection")
+	ErrNotEnoughVolunteers     = errors.New("both teams need a volunteer captain")
+	ErrNotYourPick             = errors.New("it is not your turn to pick")
+)
+
+// StartCaptainsDraft chooses two captains and puts every other player on the bench to be picked.
+// With highest_mmr the lower rated of the two captains gets blue side and the first pick
+func (s *LobbyService) StartCaptainsDraft(ctx context.Context, lobbyID, userID uuid.UUID, selection domain.CaptainSelection) (*domain.CaptainsDraft, error) {
+	if !selection.IsValid() {
+		return nil, ErrInvalidCaptainSelection
+	}
+
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLobbyNotFound
+		}
+		return nil, err
+	}
+
+	if lobby.Status != domain.LobbyStatusWaitingForPlayers {
+		return nil, ErrInvalidLobbyState
+	}
+
+	// Verify the caller is a captain
+	captain, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInLobby
+		}
+		return nil, err
+	}
+	if !captain.IsCaptain {
+		return nil, ErrNotCaptain
+	}
+
+	players, err := s.lobbyPlayerRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) < lobby.PlayersPerMatch() {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	var blueCaptain, redCaptain *domain.LobbyPlayer
+	switch selection {
+	case domain.CaptainSelectionVolunteer:
+		for _, p := range players {
+			if !p.IsCaptain || p.Team == nil {
+				continue
+			}
+			if *p.Team == domain.SideBlue {
+				blueCaptain = p
+			} else {
+				redCaptain = p
+			}
+		}
+		if blueCaptain == nil || redCaptain == nil {
+			return nil, ErrNotEnoughVolunteers
+		}
+	case domain.CaptainSelectionHighestMMR:
+		ranked, err := s.rankPlayersByMMR(ctx, lobby, players)
+		if err != nil {
+			return nil, err
+		}
+		blueCaptain, redCaptain = ranked[1], ranked[0]
+	case domain.CaptainSelectionRandom:
+		perm := mathrand.Perm(len(players))
+		blueCaptain, redCaptain = players[perm[0]], players[perm[1]]
+	}
+
+	// Captains start their teams, everyone else waits on the bench
+	for _, p := range players {
+		var team *domain.Side
+		switch p.ID {
+		case blueCaptain.ID:
+			side := domain.SideBlue
+			team = &side
+		case redCaptain.ID:
+			side := domain.SideRed
+			team = &side
+		}
+		p.Team = team
+		p.AssignedRole = nil
+		p.IsCaptain = team != nil
+		if err := s.lobbyPlayerRepo.Update(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.matchOptionRepo.DeleteByLobbyID(ctx, lobbyID); err != nil {
+		return nil, err
+	}
+	lobby.SelectedMatchOption = nil
+	lobby.Status = domain.LobbyStatusCaptainsDraft
+	if err := s.lobbyRepo.Update(ctx, lobby); err != nil {
+		return nil, err
+	}
+
+	return domain.NewCaptainsDraft(players, lobby.PlayersPerTeam()), nil
+}
+
+// GetCaptainsDraft returns the progress of a lobby's captain's draft
+func (s *LobbyService) GetCaptainsDraft(ctx context.Context, lobbyID uuid.UUID) (*domain.CaptainsDraft, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLobbyNotFound
+		}
+		return nil, err
+	}
+	if lobby.Status != domain.LobbyStatusCaptainsDraft {
+		return nil, ErrInvalidLobbyState
+	}
+
+	players, err := s.lobbyPlayerRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewCaptainsDraft(players, lobby.PlayersPerTeam()), nil
+}
+
+// PickPlayer adds a bench player to the team of the captain whose turn it is
+func (s *LobbyService) PickPlayer(ctx context.Context, lobbyID, captainID, pickedUserID uuid.UUID) (*domain.CaptainsDraft, error) {
+	lobby, players, draft, err := s.loadCaptainsDraft(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	captain := findLobbyPlayer(players, captainID)
+	if captain == nil {
+		return nil, ErrNotInLobby
+	}
+	if !captain.IsCaptain || captain.Team == nil {
+		return nil, ErrNotCaptain
+	}
+	if draft.IsComplete() || *captain.Team != draft.NextSide {
+		return nil, ErrNotYourPick
+	}
+
+	picked := findLobbyPlayer(players, pickedUserID)
+	if picked == nil {
+		return nil, ErrPlayerNotFound
+	}
+	if !picked.IsOnBench() {
+		return nil, ErrNotOnBench
+	}
+
+	return s.pickPlayer(ctx, lobby, players, draft, picked, false)
+}
+
+// AutoPickPlayer makes a pick for a captain who ran out of time, taking the best rated player left.
+// It does nothing if the pick was already made, returning a nil draft
+func (s *LobbyService) AutoPickPlayer(ctx context.Context, lobbyID uuid.UUID, pick int) (*domain.CaptainsDraft, error) {
+	lobby, players, draft, err := s.loadCaptainsDraft(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.IsComplete() || draft.Picks != pick {
+		return nil, nil
+	}
+
+	var bench []*domain.LobbyPlayer
+	for _, p := range players {
+		if p.IsOnBench() {
+			bench = append(bench, p)
+		}
+	}
+	if len(bench) == 0 {
+		return nil, ErrNotEnoughPlayers
+	}
+	ranked, err := s.rankPlayersByMMR(ctx, lobby, bench)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pickPlayer(ctx, lobby, players, draft, ranked[0], true)
+}
+
+// loadCaptainsDraft loads a lobby in its captain's draft with its players and draft progress
+func (s *LobbyService) loadCaptainsDraft(ctx context.Context, lobbyID uuid.UUID) (*domain.Lobby, []*domain.LobbyPlayer, *domain.CaptainsDraft, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, ErrLobbyNotFound
+		}
+		return nil, nil, nil, err
+	}
+	if lobby.Status != domain.LobbyStatusCaptainsDraft {
+		return nil, nil, nil, ErrInvalidLobbyState
+	}
+
+	players, err := s.lobbyPlayerRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return lobby, players, domain.NewCaptainsDraft(players, lobby.PlayersPerTeam()), nil
+}
+
+// pickPlayer puts a bench player on the picking team.
+// The last pick assigns roles by comfort and moves the lobby on to team_selected
+func (s *LobbyService) pickPlayer(ctx context.Context, lobby *domain.Lobby, players []*domain.LobbyPlayer, draft *domain.CaptainsDraft, picked *domain.LobbyPlayer, auto bool) (*domain.CaptainsDraft, error) {
+	side := draft.NextSide
+	picked.Team = &side
+	if err := s.lobbyPlayerRepo.Update(ctx, picked); err != nil {
+		return nil, err
+	}
+
+	next := domain.NewCaptainsDraft(players, lobby.PlayersPerTeam())
+	next.LastPick = &domain.CaptainPick{UserID: picked.UserID, Team: side, Pick: draft.Picks, Auto: auto}
+	if !next.IsComplete() {
+		return next, nil
+	}
+
+	var blue, red []*domain.LobbyPlayer
+	for _, p := range players {
+		if p.Team == nil {
+			continue
+		}
+		if *p.Team == domain.SideBlue {
+			blue = append(blue, p)
+		} else {
+			red = append(red, p)
+		}
+	}
+
+	option, err := s.matchmakingService.AssignRolesByComfort(ctx, lobby.ID, blue, red)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCaptainsDraftOption(ctx, lobby, option); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// applyCaptainsDraftOption sets the roles of the drafted teams and selects their option.
+// Unpicked players stay on the bench and captains keep their teams
+func (s *LobbyService) applyCaptainsDraftOption(ctx context.Context, lobby *domain.Lobby, option *domain.MatchOption) error {
+	assignments := make(map[uuid.UUID]struct {
+		Team domain.Side
+		Role domain.Role
+	})
+	for _, a := range option.Assignments {
+		assignments[a.UserID] = struct {
+			Team domain.Side
+			Role domain.Role
+		}{
+			Team: a.Team,
+			Role: a.AssignedRole,
+		}
+	}
+
+	if err := s.lobbyPlayerRepo.UpdateTeamAssignments(ctx, lobby.ID, assignments); err != nil {
+		return err
+	}
+
+	lobby.SelectedMatchOption = &option.OptionNumber
+	lobby.Status = domain.LobbyStatusTeamSelected
+	return s.lobbyRepo.Update(ctx, lobby)
+}
+
+// rankPlayersByMMR orders players by their best MMR in the lobby's roles, highest first
+func (s *LobbyService) rankPlayersByMMR(ctx context.Context, lobby *domain.Lobby, players []*domain.LobbyPlayer) ([]*domain.LobbyPlayer, error) {
+	playerData, err := s.matchmakingService.loadPlayerData(ctx, players)
+	if err != nil {
+		return nil, err
+	}
+
+	mmrs := make(map[uuid.UUID]int, len(playerData))
+	for _, pd := range playerData {
+		mmrs[pd.UserID] = pd.BestMMR(lobby.Roles())
+	}
+
+	ranked := append([]*domain.LobbyPlayer{}, players...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return mmrs[ranked[i].UserID] > mmrs[ranked[j].UserID]
+	})
+	return ranked, nil
+}
+
+// findLobbyPlayer returns the lobby player for a user, or nil if they are not in the lobby
+func findLobbyPlayer(players []*domain.LobbyPlayer, userID uuid.UUID) *domain.LobbyPlayer {
+	for _, p := range players {
+		if p.UserID == userID {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptainPickSide_SnakeOrder(t *testing.T) {
+	// 1-2-2-2-1 for the eight picks of a 5v5
+	expected := []domain.Side{
+		domain.SideBlue,
+		domain.SideRed, domain.SideRed,
+		domain.SideBlue, domain.SideBlue,
+		domain.SideRed, domain.SideRed,
+		domain.SideBlue,
+	}
+	for pick, side := range expected {
+		assert.Equal(t, side, domain.CaptainPickSide(pick), "pick %d", pick)
+	}
+}
+
+func TestLobbyService_CaptainsDraft(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, ts.DB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "CAPT01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+		TeamSize:  3,
+	}
+	require.NoError(t, ts.DB.DB.Create(lobby).Error)
+
+	// Seven players for a 3v3, so one is left on the bench. The weakest player only enjoys mid
+	mmrs := []int{3000, 2800, 2500, 2200, 2000, 1800}
+	var users []*domain.User
+	for i, mmr := range mmrs {
+		users = append(users, createUserUniform(t, ts.DB.DB, "Capt"+string(rune('A'+i)), mmr, 3))
+	}
+	users = append(users, createUserWithProfiles(t, ts.DB.DB, "CaptMid", map[domain.Role]struct {
+		MMR     int
+		Comfort int
+	}{
+		domain.RoleTop:    {MMR: 800, Comfort: 1},
+		domain.RoleJungle: {MMR: 800, Comfort: 1},
+		domain.RoleMid:    {MMR: 800, Comfort: 5},
+	}))
+
+	var captainID, memberID uuid.UUID
+	for _, user := range users {
+		player, err := ts.Services.Lobby.JoinLobby(ctx, lobby.ID, user.ID)
+		require.NoError(t, err)
+		if player.IsCaptain {
+			captainID = player.UserID
+		} else {
+			memberID = player.UserID
+		}
+	}
+
+	_, err := ts.Services.Lobby.StartCaptainsDraft(ctx, lobby.ID, captainID, "coin_flip")
+	assert.ErrorIs(t, err, service.ErrInvalidCaptainSelection)
+	_, err = ts.Services.Lobby.StartCaptainsDraft(ctx, lobby.ID, memberID, domain.CaptainSelectionHighestMMR)
+	assert.ErrorIs(t, err, service.ErrNotCaptain)
+
+	// The two best players captain, and the weaker of them picks first
+	draft, err := ts.Services.Lobby.StartCaptainsDraft(ctx, lobby.ID, captainID, domain.CaptainSelectionHighestMMR)
+	require.NoError(t, err)
+	blueCaptain, redCaptain := users[1], users[0]
+	assert.Equal(t, blueCaptain.ID, draft.BlueCaptainID)
+	assert.Equal(t, redCaptain.ID, draft.RedCaptainID)
+	assert.Equal(t, 4, draft.TotalPicks)
+	assert.Equal(t, domain.SideBlue, draft.NextSide)
+
+	for _, user := range users[2:] {
+		player, err := ts.Services.Lobby.GetLobbyPlayer(ctx, lobby.ID, user.ID)
+		require.NoError(t, err)
+		assert.True(t, player.IsOnBench())
+		assert.False(t, player.IsCaptain)
+	}
+
+	// Captains pick in turn, and only from the bench
+	_, err = ts.Services.Lobby.PickPlayer(ctx, lobby.ID, redCaptain.ID, users[2].ID)
+	assert.ErrorIs(t, err, service.ErrNotYourPick)
+	_, err = ts.Services.Lobby.PickPlayer(ctx, lobby.ID, blueCaptain.ID, redCaptain.ID)
+	assert.ErrorIs(t, err, service.ErrNotOnBench)
+
+	draft, err = ts.Services.Lobby.PickPlayer(ctx, lobby.ID, blueCaptain.ID, users[6].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, draft.Picks)
+	assert.Equal(t, domain.SideRed, draft.NextSide)
+	require.NotNil(t, draft.LastPick)
+	assert.Equal(t, users[6].ID, draft.LastPick.UserID)
+	assert.False(t, draft.LastPick.Auto)
+
+	// A stale timeout does nothing; a current one takes the best rated player left
+	draft, err = ts.Services.Lobby.AutoPickPlayer(ctx, lobby.ID, 0)
+	require.NoError(t, err)
+	assert.Nil(t, draft)
+
+	draft, err = ts.Services.Lobby.AutoPickPlayer(ctx, lobby.ID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, draft.LastPick)
+	assert.Equal(t, users[2].ID, draft.LastPick.UserID)
+	assert.Equal(t, domain.SideRed, draft.LastPick.Team)
+	assert.True(t, draft.LastPick.Auto)
+
+	_, err = ts.Services.Lobby.PickPlayer(ctx, lobby.ID, redCaptain.ID, users[5].ID)
+	require.NoError(t, err)
+	draft, err = ts.Services.Lobby.PickPlayer(ctx, lobby.ID, blueCaptain.ID, users[4].ID)
+	require.NoError(t, err)
+	assert.True(t, draft.IsComplete())
+
+	// The finished draft selects its teams with roles by comfort
+	updated, err := ts.Services.Lobby.GetLobby(ctx, lobby.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.LobbyStatusTeamSelected, updated.Status)
+	require.NotNil(t, updated.SelectedMatchOption)
+
+	options, err := ts.Services.Lobby.GetMatchOptions(ctx, lobby.ID)
+	require.NoError(t, err)
+	require.Len(t, options, 1)
+	assert.Equal(t, domain.AlgorithmCaptainsDraft, options[0].AlgorithmType)
+	assert.Len(t, options[0].Assignments, 6)
+
+	teams := map[uuid.UUID]domain.Side{
+		blueCaptain.ID: domain.SideBlue, users[6].ID: domain.SideBlue, users[4].ID: domain.SideBlue,
+		redCaptain.ID: domain.SideRed, users[2].ID: domain.SideRed, users[5].ID: domain.SideRed,
+	}
+	for userID, side := range teams {
+		player, err := ts.Services.Lobby.GetLobbyPlayer(ctx, lobby.ID, userID)
+		require.NoError(t, err)
+		require.NotNil(t, player.Team)
+		assert.Equal(t, side, *player.Team)
+		require.NotNil(t, player.AssignedRole)
+	}
+
+	mid, err := ts.Services.Lobby.GetLobbyPlayer(ctx, lobby.ID, users[6].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleMid, *mid.AssignedRole)
+
+	captain, err := ts.Services.Lobby.GetLobbyPlayer(ctx, lobby.ID, blueCaptain.ID)
+	require.NoError(t, err)
+	assert.True(t, captain.IsCaptain)
+
+	benched, err := ts.Services.Lobby.GetLobbyPlayer(ctx, lobby.ID, users[3].ID)
+	require.NoError(t, err)
+	assert.True(t, benched.IsOnBench())
+}
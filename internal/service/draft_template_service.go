@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDraftTemplateNotFound    = errors.New("draft template not found")
+	ErrDraftTemplateNameTaken   = errors.New("draft template name already exists")
+	ErrInvalidDraftTemplateName = errors.New("draft template name is required")
+)
+
+type DraftTemplateService struct {
+	templateRepo repository.DraftTemplateRepository
+}
+
+func NewDraftTemplateService(templateRepo repository.DraftTemplateRepository) *DraftTemplateService {
+	return &DraftTemplateService{templateRepo: templateRepo}
+}
+
+// EnsureBuiltInTemplates creates the built-in templates, refreshing their phases if they already exist
+func (s *DraftTemplateService) EnsureBuiltInTemplates(ctx context.Context) error {
+	for _, builtIn := range domain.BuiltInDraftTemplates() {
+		existing, err := s.templateRepo.GetByName(ctx, builtIn.Name)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err := s.templateRepo.Create(ctx, builtIn); err != nil {
+				return err
+			}
+			log.Printf("Created built-in draft template %s", builtIn.Name)
+			continue
+		}
+
+		existing.Description = builtIn.Description
+		existing.Kind = builtIn.Kind
+		existing.Phases = builtIn.Phases
+		existing.PoolSize = builtIn.PoolSize
+		existing.IsBuiltIn = true
+		if err := s.templateRepo.Update(ctx, existing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListTemplates returns all templates, built-ins first
+func (s *DraftTemplateService) ListTemplates(ctx context.Context) ([]*domain.DraftTemplate, error) {
+	return s.templateRepo.GetAll(ctx)
+}
+
+// GetTemplate looks a template up by UUID or name
+func (s *DraftTemplateService) GetTemplate(ctx context.Context, idOrName string) (*domain.DraftTemplate, error) {
+	var template *domain.DraftTemplate
+	var err error
+
+	if id, parseErr := uuid.Parse(idOrName); parseErr == nil {
+		template, err = s.templateRepo.GetByID(ctx, id)
+	} else {
+		template, err = s.templateRepo.GetByName(ctx, strings.ToLower(idOrName))
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDraftTemplateNotFound
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+type CreateDraftTemplateInput struct {
+	CreatedBy   uuid.UUID
+	Name        string
+	Description string
+	Kind        domain.DraftTemplateKind
+	Phases      []domain.Phase
+	PoolSize    int
+}
+
+// CreateCustomTemplate validates and stores a user-uploaded phase order
+func (s *DraftTemplateService) CreateCustomTemplate(ctx context.Context, input CreateDraftTemplateInput) (*domain.DraftTemplate, error) {
+	name := strings.ToLower(strings.TrimSpace(input.Name))
+	if name == "" {
+		return nil, ErrInvalidDraftTemplateName
+	}
+
+	if _, err := s.templateRepo.GetByName(ctx, name); err == nil {
+		return nil, ErrDraftTemplateNameTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	kind := input.Kind
+	if kind == "" {
+		kind = domain.DraftTemplateKindStandard
+	}
+
+	template, err := domain.NewDraftTemplate(name, input.Description, kind, input.Phases, input.PoolSize)
+	if err != nil {
+		return nil, err
+	}
+	template.CreatedBy = &input.CreatedBy
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftTemplateService_EnsureBuiltInTemplates(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	templateService := service.NewDraftTemplateService(repos.DraftTemplate)
+	ctx := context.Background()
+
+	require.NoError(t, templateService.EnsureBuiltInTemplates(ctx))
+	// Running again refreshes rather than duplicates
+	require.NoError(t, templateService.EnsureBuiltInTemplates(ctx))
+
+	templates, err := templateService.ListTemplates(ctx)
+	require.NoError(t, err)
+	assert.Len(t, templates, len(domain.BuiltInDraftTemplates()))
+
+	proPlay, err := templateService.GetTemplate(ctx, domain.DraftTemplateProPlay)
+	require.NoError(t, err)
+	phases, err := proPlay.GetPhases()
+	require.NoError(t, err)
+	assert.Equal(t, domain.TotalPhases(), len(phases))
+
+	byID, err := templateService.GetTemplate(ctx, proPlay.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, proPlay.Name, byID.Name)
+
+	aram, err := templateService.GetTemplate(ctx, domain.DraftTemplateARAM)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DraftTemplateKindRandomPool, aram.Kind)
+	assert.Equal(t, domain.DefaultRandomPoolSize, aram.PoolSize)
+
+	_, err = templateService.GetTemplate(ctx, "does_not_exist")
+	assert.ErrorIs(t, err, service.ErrDraftTemplateNotFound)
+}
+
+func TestDraftTemplateService_CreateCustomTemplate(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	templateService := service.NewDraftTemplateService(repos.DraftTemplate)
+	ctx := context.Background()
+
+	user, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	// One ban each, then a single pick each
+	phases := []domain.Phase{
+		{Team: domain.SideRed, ActionType: domain.ActionTypeBan},
+		{Team: domain.SideBlue, ActionType: domain.ActionTypeBan},
+		{Team: domain.SideBlue, ActionType: domain.ActionTypePick},
+		{Team: domain.SideRed, ActionType: domain.ActionTypePick},
+	}
+
+	t.Run("creates custom template", func(t *testing.T) {
+		template, err := templateService.CreateCustomTemplate(ctx, service.CreateDraftTemplateInput{
+			CreatedBy: user.ID,
+			Name:      "Red First Ban",
+			Phases:    phases,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "red first ban", template.Name)
+		assert.Equal(t, domain.DraftTemplateKindStandard, template.Kind)
+		assert.False(t, template.IsBuiltIn)
+
+		stored, err := template.GetPhases()
+		require.NoError(t, err)
+		require.Len(t, stored, 4)
+		assert.Equal(t, 3, stored[3].Index)
+		assert.Equal(t, domain.SideRed, stored[0].Team)
+	})
+
+	t.Run("rejects duplicate name", func(t *testing.T) {
+		_, err := templateService.CreateCustomTemplate(ctx, service.CreateDraftTemplateInput{
+			CreatedBy: user.ID,
+			Name:      "red first ban",
+			Phases:    phases,
+		})
+		assert.ErrorIs(t, err, service.ErrDraftTemplateNameTaken)
+	})
+
+	t.Run("rejects invalid phases", func(t *testing.T) {
+		_, err := templateService.CreateCustomTemplate(ctx, service.CreateDraftTemplateInput{
+			CreatedBy: user.ID,
+			Name:      "bad team",
+			Phases:    []domain.Phase{{Team: domain.SideSpectator, ActionType: domain.ActionTypePick}},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidDraftPhases)
+
+		_, err = templateService.CreateCustomTemplate(ctx, service.CreateDraftTemplateInput{
+			CreatedBy: user.ID,
+			Name:      "no red picks",
+			Phases:    []domain.Phase{{Team: domain.SideBlue, ActionType: domain.ActionTypePick}},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidDraftPickCount)
+	})
+
+	t.Run("rejects bad pool size", func(t *testing.T) {
+		_, err := templateService.CreateCustomTemplate(ctx, service.CreateDraftTemplateInput{
+			CreatedBy: user.ID,
+			Name:      "tiny pool",
+			Kind:      domain.DraftTemplateKindRandomPool,
+			Phases:    phases,
+			PoolSize:  2,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidDraftPoolSize)
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrChatMuted         = errors.New("you are muted in this lobby's chat")
+	ErrCannotMuteCaptain = errors.New("captains cannot be muted")
+)
+
+// SendChatMessage posts a player's message to the lobby's all channel or their team's channel
+func (s *LobbyService) SendChatMessage(ctx context.Context, lobbyID, userID uuid.UUID, channel domain.ChatChannel, body string) (*domain.ChatMessage, error) {
+	player, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInLobby
+		}
+		return nil, err
+	}
+	if player.ChatMuted {
+		return nil, ErrChatMuted
+	}
+
+	msg, err := domain.NewChatMessage(lobbyID, userID, channel, player.Team, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.chatRepo.Create(ctx, msg); err != nil {
+		return nil, err
+	}
+	msg.User = player.User
+	return msg, nil
+}
+
+// PostSystemMessage posts a server message, such as a captain change, to the lobby's all channel
+func (s *LobbyService) PostSystemMessage(ctx context.Context, lobbyID uuid.UUID, body string) (*domain.ChatMessage, error) {
+	msg := domain.NewSystemChatMessage(lobbyID, body)
+	if err := s.chatRepo.Create(ctx, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// GetChatHistory returns the lobby's recent messages that the user can read.
+// Users outside the lobby, such as draft room spectators, and bench players only read the all channel
+func (s *LobbyService) GetChatHistory(ctx context.Context, lobbyID, userID uuid.UUID) ([]*domain.ChatMessage, error) {
+	var team *domain.Side
+	player, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	if err == nil {
+		team = player.Team
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	messages, err := s.chatRepo.GetRecentByLobbyID(ctx, lobbyID, domain.ChatHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.ChatMessage, 0, len(messages))
+	for _, msg := range messages {
+		if msg.VisibleTo(team) {
+			visible = append(visible, msg)
+		}
+	}
+	return visible, nil
+}
+
+// SetChatMuted lets a captain mute or unmute a player in the lobby's chat
+func (s *LobbyService) SetChatMuted(ctx context.Context, lobbyID, captainID, targetUserID uuid.UUID, muted bool) error {
+	captain, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, captainID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotInLobby
+		}
+		return err
+	}
+	if !captain.IsCaptain {
+		return ErrNotCaptain
+	}
+
+	target, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, targetUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPlayerNotFound
+		}
+		return err
+	}
+	if target.IsCaptain {
+		return ErrCannotMuteCaptain
+	}
+
+	target.ChatMuted = muted
+	return s.lobbyPlayerRepo.Update(ctx, target)
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChatMessage_Validation(t *testing.T) {
+	lobbyID, userID := uuid.New(), uuid.New()
+	blue := domain.SideBlue
+
+	msg, err := domain.NewChatMessage(lobbyID, userID, domain.ChatChannelTeam, &blue, "  gank top  ")
+	require.NoError(t, err)
+	assert.Equal(t, "gank top", msg.Body)
+	assert.True(t, msg.VisibleTo(&blue))
+	red := domain.SideRed
+	assert.False(t, msg.VisibleTo(&red))
+	assert.False(t, msg.VisibleTo(nil))
+
+	_, err = domain.NewChatMessage(lobbyID, userID, domain.ChatChannelAll, nil, "   ")
+	assert.ErrorIs(t, err, domain.ErrInvalidChatMessage)
+	_, err = domain.NewChatMessage(lobbyID, userID, domain.ChatChannelAll, nil, strings.Repeat("a", domain.MaxChatMessageLength+1))
+	assert.ErrorIs(t, err, domain.ErrInvalidChatMessage)
+	_, err = domain.NewChatMessage(lobbyID, userID, "whisper", nil, "hi")
+	assert.ErrorIs(t, err, domain.ErrInvalidChatMessage)
+	_, err = domain.NewChatMessage(lobbyID, userID, domain.ChatChannelTeam, nil, "hi")
+	assert.ErrorIs(t, err, domain.ErrNoChatTeam)
+}
+
+func TestLobbyService_Chat(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+	db := ts.DB.DB
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, db)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "CHAT01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusTeamSelected,
+	}
+	require.NoError(t, db.Create(lobby).Error)
+
+	blue, red := domain.SideBlue, domain.SideRed
+	blueCaptain := createLobbyPlayer(t, db, lobby.ID, createUserUniform(t, db, "ChatBlueCapt", 1500, 3), true)
+	blueMember := createLobbyPlayer(t, db, lobby.ID, createUserUniform(t, db, "ChatBlue", 1500, 3), true)
+	redCaptain := createLobbyPlayer(t, db, lobby.ID, createUserUniform(t, db, "ChatRedCapt", 1500, 3), true)
+	require.NoError(t, db.Model(blueCaptain).Updates(map[string]interface{}{"team": blue, "is_captain": true}).Error)
+	require.NoError(t, db.Model(blueMember).Update("team", blue).Error)
+	require.NoError(t, db.Model(redCaptain).Updates(map[string]interface{}{"team": red, "is_captain": true}).Error)
+
+	_, err := ts.Services.Lobby.SendChatMessage(ctx, lobby.ID, creator.ID, domain.ChatChannelAll, "hello")
+	assert.ErrorIs(t, err, service.ErrNotInLobby)
+
+	_, err = ts.Services.Lobby.SendChatMessage(ctx, lobby.ID, blueMember.UserID, domain.ChatChannelAll, "glhf")
+	require.NoError(t, err)
+	msg, err := ts.Services.Lobby.SendChatMessage(ctx, lobby.ID, blueMember.UserID, domain.ChatChannelTeam, "ban their jungler")
+	require.NoError(t, err)
+	require.NotNil(t, msg.Team)
+	assert.Equal(t, domain.SideBlue, *msg.Team)
+	_, err = ts.Services.Lobby.PostSystemMessage(ctx, lobby.ID, "ChatRedCapt is now red captain")
+	require.NoError(t, err)
+
+	// The red team never reads blue's team channel
+	history, err := ts.Services.Lobby.GetChatHistory(ctx, lobby.ID, redCaptain.UserID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "glhf", history[0].Body)
+	assert.True(t, history[1].IsSystem())
+
+	history, err = ts.Services.Lobby.GetChatHistory(ctx, lobby.ID, blueCaptain.UserID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "ban their jungler", history[1].Body)
+	require.NotNil(t, history[1].User)
+	assert.Equal(t, "ChatBlue", history[1].User.DisplayName)
+
+	// Captains moderate the chat but cannot be muted themselves
+	err = ts.Services.Lobby.SetChatMuted(ctx, lobby.ID, blueMember.UserID, redCaptain.UserID, true)
+	assert.ErrorIs(t, err, service.ErrNotCaptain)
+	err = ts.Services.Lobby.SetChatMuted(ctx, lobby.ID, blueCaptain.UserID, redCaptain.UserID, true)
+	assert.ErrorIs(t, err, service.ErrCannotMuteCaptain)
+
+	require.NoError(t, ts.Services.Lobby.SetChatMuted(ctx, lobby.ID, redCaptain.UserID, blueMember.UserID, true))
+	_, err = ts.Services.Lobby.SendChatMessage(ctx, lobby.ID, blueMember.UserID, domain.ChatChannelAll, "unfair")
+	assert.ErrorIs(t, err, service.ErrChatMuted)
+
+	require.NoError(t, ts.Services.Lobby.SetChatMuted(ctx, lobby.ID, blueCaptain.UserID, blueMember.UserID, false))
+	_, err = ts.Services.Lobby.SendChatMessage(ctx, lobby.ID, blueMember.UserID, domain.ChatChannelAll, "thanks")
+	assert.NoError(t, err)
+}
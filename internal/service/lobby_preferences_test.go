@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLobbyService_RemovePreference(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, ts.DB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "PREF02",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+	}
+	require.NoError(t, ts.DB.DB.Create(lobby).Error)
+
+	var users []*domain.User
+	for i := 0; i < 3; i++ {
+		user, _ := testutil.NewUserBuilder().WithDisplayName("Prefs"+string(rune('A'+i))).Build(t, ts.DB.DB)
+		users = append(users, user)
+		require.NoError(t, ts.DB.DB.Create(&domain.LobbyPlayer{
+			ID:        uuid.New(),
+			LobbyID:   lobby.ID,
+			UserID:    user.ID,
+			IsCaptain: i == 2,
+			JoinOrder: i,
+		}).Error)
+	}
+	griefer, other, captain := users[0], users[1], users[2]
+
+	addHard := func() *domain.LobbyPreference {
+		pref, err := ts.Services.Lobby.AddPreference(ctx, lobby.ID, griefer.ID, service.AddPreferenceInput{
+			Type:         domain.PreferenceNotSameTeam,
+			TargetUserID: &other.ID,
+			IsHard:       true,
+		})
+		require.NoError(t, err)
+		return pref
+	}
+
+	// Other players can't remove someone else's preference
+	pref := addHard()
+	err := ts.Services.Lobby.RemovePreference(ctx, lobby.ID, other.ID, pref.ID)
+	assert.ErrorIs(t, err, service.ErrNotPreferenceOwner)
+
+	// The lobby creator and captains can
+	require.NoError(t, ts.Services.Lobby.RemovePreference(ctx, lobby.ID, creator.ID, pref.ID))
+	pref = addHard()
+	require.NoError(t, ts.Services.Lobby.RemovePreference(ctx, lobby.ID, captain.ID, pref.ID))
+
+	// And the player can remove their own
+	pref = addHard()
+	require.NoError(t, ts.Services.Lobby.RemovePreference(ctx, lobby.ID, griefer.ID, pref.ID))
+
+	prefs, err := ts.Services.Lobby.GetPreferences(ctx, lobby.ID)
+	require.NoError(t, err)
+	assert.Empty(t, prefs)
+}
@@ -21,7 +21,7 @@ var (
 	ErrAlreadyInLobby        = errors.New("user is already in lobby")
 	ErrNotInLobby            = errors.New("user is not in lobby")
 	ErrNotLobbyCreator       = errors.New("only lobby creator can perform this action")
-	ErrNotEnoughPlayers      = errors.New("lobby needs 10 players")
+	ErrNotEnoughPlayers      = errors.New("lobby does not have enough players for its format")
 	ErrPlayersNotReady       = errors.New("not all players are ready")
 	ErrInvalidLobbyState     = errors.New("invalid lobby state for this action")
 	ErrNoMatchOptions        = errors.New("no match options available")
@@ -39,8 +39,15 @@ var (
 	ErrVotingNotEnabled      = errors.New("voting is not enabled for this lobby")
 	ErrVotingNotActive       = errors.New("voting is not currently active")
 	ErrInvalidVotingMode     = errors.New("invalid voting mode")
+	ErrPreferenceNotFound    = errors.New("preference not found")
+	ErrNotPreferenceOwner    = errors.New("only the player, the lobby creator or a captain can remove a preference")
+	ErrTooManyPreferences    = errors.New("too many preferences")
+	ErrNotOnBench            = errors.New("player is not on the bench")
 )
 
+// MaxPreferencesPerPlayer caps how many matchmaking preferences a player can declare in a lobby
+const MaxPreferencesPerPlayer = 5
+
 type LobbyService struct {
 	lobbyRepo          repository.LobbyRepository
 	lobbyPlayerRepo    repository.LobbyPlayerRepository
@@ -49,6 +56,9 @@ type LobbyService struct {
 	roomPlayerRepo     repository.RoomPlayerRepository
 	pendingActionRepo  repository.PendingActionRepository
 	voteRepo           repository.VoteRepository
+	preferenceRepo     repository.LobbyPreferenceRepository
+	chatRepo           repository.ChatMessageRepository
+	transactor         repository.Transactor
 	roomService        *RoomService
 	matchmakingService *MatchmakingService
 }
@@ -61,6 +71,9 @@ func NewLobbyService(
 	roomPlayerRepo repository.RoomPlayerRepository,
 	pendingActionRepo repository.PendingActionRepository,
 	voteRepo repository.VoteRepository,
+	preferenceRepo repository.LobbyPreferenceRepository,
+	chatRepo repository.ChatMessageRepository,
+	transactor repository.Transactor,
 	roomService *RoomService,
 	matchmakingService *MatchmakingService,
 ) *LobbyService {
@@ -72,16 +85,23 @@ func NewLobbyService(
 		roomPlayerRepo:     roomPlayerRepo,
 		pendingActionRepo:  pendingActionRepo,
 		voteRepo:           voteRepo,
+		preferenceRepo:     preferenceRepo,
+		chatRepo:           chatRepo,
+		transactor:         transactor,
 		roomService:        roomService,
 		matchmakingService: matchmakingService,
 	}
 }
 
 type CreateLobbyInput struct {
-	DraftMode            domain.DraftMode
-	TimerDurationSeconds int
-	VotingEnabled        bool
-	VotingMode           domain.VotingMode
+	DraftMode             domain.DraftMode
+	TimerDurationSeconds  int
+	TradePhaseSeconds     int
+	SpectatorDelaySeconds int
+	TeamSize              int // players per team, 5 when unset
+	VotingEnabled         bool
+	VotingMode            domain.VotingMode
+	MatchmakingSettings   domain.MatchmakingSettings
 }
 
 func (s *LobbyService) CreateLobby(ctx context.Context, creatorID uuid.UUID, input CreateLobbyInput) (*domain.Lobby, error) {
@@ -97,16 +117,31 @@ func (s *LobbyService) CreateLobby(ctx context.Context, creatorID uuid.UUID, inp
 		votingMode = domain.VotingModeMajority
 	}
 
+	teamSize := input.TeamSize
+	if teamSize == 0 {
+		teamSize = domain.DefaultTeamSize
+	}
+
+	if err := validateMatchmakingSettings(input.MatchmakingSettings); err != nil {
+		return nil, err
+	}
+
 	lobby := &domain.Lobby{
-		ID:                   uuid.New(),
-		ShortCode:            shortCode,
-		CreatedBy:            creatorID,
-		Status:               domain.LobbyStatusWaitingForPlayers,
-		DraftMode:            input.DraftMode,
-		TimerDurationSeconds: timerDuration,
-		VotingEnabled:        input.VotingEnabled,
-		VotingMode:           votingMode,
-		CreatedAt:            time.Now(),
+		ID:                    uuid.New(),
+		ShortCode:             shortCode,
+		CreatedBy:             creatorID,
+		Status:                domain.LobbyStatusWaitingForPlayers,
+		DraftMode:             input.DraftMode,
+		TimerDurationSeconds:  timerDuration,
+		TradePhaseSeconds:     input.TradePhaseSeconds,
+		SpectatorDelaySeconds: input.SpectatorDelaySeconds,
+		TeamSize:              teamSize,
+		VotingEnabled:         input.VotingEnabled,
+		VotingMode:            votingMode,
+		CreatedAt:             time.Now(),
+	}
+	if err := lobby.SetMatchmakingSettings(input.MatchmakingSettings); err != nil {
+		return nil, err
 	}
 
 	if err := s.lobbyRepo.Create(ctx, lobby); err != nil {
@@ -151,7 +186,11 @@ func (s *LobbyService) JoinLobby(ctx context.Context, lobbyID, userID uuid.UUID)
 		return nil, err
 	}
 
-	if lobby.Status != domain.LobbyStatusWaitingForPlayers {
+	// Once teams are set, new players join the bench and can be substituted in.
+	// During a captain's draft they join the bench and can be picked
+	joinBench := lobby.Status == domain.LobbyStatusCaptainsDraft ||
+		lobby.Status == domain.LobbyStatusTeamSelected || lobby.Status == domain.LobbyStatusDrafting
+	if lobby.Status != domain.LobbyStatusWaitingForPlayers && !joinBench {
 		return nil, ErrInvalidLobbyState
 	}
 
@@ -170,10 +209,24 @@ func (s *LobbyService) JoinLobby(ctx context.Context, lobbyID, userID uuid.UUID)
 		return nil, err
 	}
 
-	if len(players) >= domain.MaxLobbyPlayers {
+	if len(players) >= lobby.MaxPlayers() {
 		return nil, ErrLobbyFull
 	}
 
+	if joinBench {
+		player := &domain.LobbyPlayer{
+			ID:        uuid.New(),
+			LobbyID:   lobbyID,
+			UserID:    userID,
+			JoinOrder: len(players),
+			JoinedAt:  time.Now(),
+		}
+		if err := s.lobbyPlayerRepo.Create(ctx, player); err != nil {
+			return nil, err
+		}
+		return s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	}
+
 	// Count players on each side
 	blueCount, redCount := 0, 0
 	hasBlueCaptain, hasRedCaptain := false, false
@@ -238,10 +291,6 @@ func (s *LobbyService) LeaveLobby(ctx context.Context, lobbyID, userID uuid.UUID
 		return err
 	}
 
-	if lobby.Status != domain.LobbyStatusWaitingForPlayers {
-		return ErrInvalidLobbyState
-	}
-
 	// Get the leaving player
 	leavingPlayer, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
 	if err != nil {
@@ -251,6 +300,15 @@ func (s *LobbyService) LeaveLobby(ctx context.Context, lobbyID, userID uuid.UUID
 		return err
 	}
 
+	// Once teams are set only bench players can leave; team players need a substitute
+	if lobby.Status != domain.LobbyStatusWaitingForPlayers {
+		benchLeaving := leavingPlayer.IsOnBench() && (lobby.Status == domain.LobbyStatusCaptainsDraft ||
+			lobby.Status == domain.LobbyStatusTeamSelected || lobby.Status == domain.LobbyStatusDrafting)
+		if !benchLeaving {
+			return ErrInvalidLobbyState
+		}
+	}
+
 	// If this player is captain, we need to promote a successor
 	if leavingPlayer.IsCaptain && leavingPlayer.Team != nil {
 		players, err := s.lobbyPlayerRepo.GetByLobbyID(ctx, lobbyID)
@@ -428,9 +486,11 @@ func (s *LobbyService) StartDraft(ctx context.Context, lobbyID uuid.UUID, userID
 
 	// Create the room
 	room, err := s.roomService.CreateRoom(ctx, CreateRoomInput{
-		CreatedBy:     userID,
-		DraftMode:     lobby.DraftMode,
-		TimerDuration: lobby.TimerDurationSeconds,
+		CreatedBy:      userID,
+		DraftMode:      lobby.DraftMode,
+		TimerDuration:  lobby.TimerDurationSeconds,
+		TradePhase:     lobby.TradePhaseSeconds,
+		SpectatorDelay: lobby.SpectatorDelaySeconds,
 	})
 	if err != nil {
 		return nil, err
@@ -469,7 +529,7 @@ func (s *LobbyService) StartDraft(ctx context.Context, lobbyID uuid.UUID, userID
 		return nil, err
 	}
 
-	// Create RoomPlayer entries for all 10 players
+	// Create RoomPlayer entries for every player on a team; players sitting out stay in the lobby
 	// Use lobbyPlayer data for team/role (which includes any swaps) instead of stale MatchOption assignments
 	var roomPlayers []*domain.RoomPlayer
 	for _, lp := range lobbyPlayers {
@@ -575,8 +635,9 @@ func (s *LobbyService) TakeCaptain(ctx context.Context, lobbyID, userID uuid.UUI
 		return err
 	}
 
-	// Allow taking captain in any lobby state before drafting starts
-	if lobby.Status == domain.LobbyStatusDrafting || lobby.Status == domain.LobbyStatusCompleted {
+	// Allow taking captain in any lobby state before drafting starts, except while captains pick teams
+	if lobby.Status == domain.LobbyStatusCaptainsDraft ||
+		lobby.Status == domain.LobbyStatusDrafting || lobby.Status == domain.LobbyStatusCompleted {
 		return ErrInvalidLobbyState
 	}
 
@@ -819,8 +880,8 @@ func (s *LobbyService) ProposeMatchmake(ctx context.Context, lobbyID, captainID
 		return nil, ErrPendingActionExists
 	}
 
-	// Verify 10 players
-	if !lobby.IsFull() {
+	// Verify the lobby has enough players for its format
+	if !lobby.HasEnoughPlayers() {
 		return nil, ErrNotEnoughPlayers
 	}
 
@@ -928,6 +989,68 @@ func (s *LobbyService) ProposeSelectOption(ctx context.Context, lobbyID, captain
 	return action, nil
 }
 
+// ProposeSubstitute creates a pending action to replace a player on the captain's team with a bench player.
+// The bench player keeps the leaving player's role, and their captaincy if they were captain.
+// Allowed in: team_selected, drafting
+func (s *LobbyService) ProposeSubstitute(ctx context.Context, lobbyID, captainID, outUserID, benchUserID uuid.UUID) (*domain.PendingAction, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLobbyNotFound
+		}
+		return nil, err
+	}
+
+	if lobby.Status != domain.LobbyStatusTeamSelected && lobby.Status != domain.LobbyStatusDrafting {
+		return nil, ErrInvalidLobbyState
+	}
+
+	// Check for existing pending action
+	existing, _ := s.pendingActionRepo.GetPendingByLobbyID(ctx, lobbyID)
+	if existing != nil && !existing.IsExpired() {
+		return nil, ErrPendingActionExists
+	}
+
+	// Verify the caller is a captain
+	captain, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, captainID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInLobby
+		}
+		return nil, err
+	}
+	if !captain.IsCaptain || captain.Team == nil {
+		return nil, ErrNotCaptain
+	}
+
+	// The leaving player must be on the captain's team, which includes the captain
+	outPlayer, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, outUserID)
+	if err != nil {
+		return nil, ErrPlayerNotFound
+	}
+	if outPlayer.Team == nil || *outPlayer.Team != *captain.Team {
+		return nil, ErrNotOnTeam
+	}
+
+	benchPlayer, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, benchUserID)
+	if err != nil {
+		return nil, ErrPlayerNotFound
+	}
+	if !benchPlayer.IsOnBench() {
+		return nil, ErrNotOnBench
+	}
+
+	action := domain.NewPendingAction(lobbyID, captainID, *captain.Team, domain.PendingActionSubstitutePlayer)
+	action.Player1ID = &outUserID
+	action.Player2ID = &benchUserID
+
+	if err := s.pendingActionRepo.Create(ctx, action); err != nil {
+		return nil, err
+	}
+
+	return action, nil
+}
+
 // ApprovePendingAction approves a pending action by the other captain
 func (s *LobbyService) ApprovePendingAction(ctx context.Context, lobbyID, captainID, actionID uuid.UUID) error {
 	action, err := s.pendingActionRepo.GetByID(ctx, actionID)
@@ -1055,6 +1178,8 @@ func (s *LobbyService) executePendingAction(ctx context.Context, action *domain.
 	case domain.PendingActionStartDraft:
 		_, err := s.executeStartDraft(ctx, action.LobbyID)
 		return err
+	case domain.PendingActionSubstitutePlayer:
+		return s.executeSubstitutePlayer(ctx, action)
 	default:
 		return ErrInvalidLobbyState
 	}
@@ -1077,7 +1202,7 @@ func (s *LobbyService) executeMatchmaking(ctx context.Context, lobbyID uuid.UUID
 		return err
 	}
 
-	if len(players) != 10 {
+	if len(players) < lobby.PlayersPerMatch() {
 		return ErrNotEnoughPlayers
 	}
 
@@ -1193,9 +1318,11 @@ func (s *LobbyService) executeStartDraft(ctx context.Context, lobbyID uuid.UUID)
 
 	// Create the room
 	room, err := s.roomService.CreateRoom(ctx, CreateRoomInput{
-		CreatedBy:     creatorID,
-		DraftMode:     lobby.DraftMode,
-		TimerDuration: lobby.TimerDurationSeconds,
+		CreatedBy:      creatorID,
+		DraftMode:      lobby.DraftMode,
+		TimerDuration:  lobby.TimerDurationSeconds,
+		TradePhase:     lobby.TradePhaseSeconds,
+		SpectatorDelay: lobby.SpectatorDelaySeconds,
 	})
 	if err != nil {
 		return nil, err
@@ -1216,7 +1343,7 @@ func (s *LobbyService) executeStartDraft(ctx context.Context, lobbyID uuid.UUID)
 		return nil, err
 	}
 
-	// Create RoomPlayer entries for all 10 players
+	// Create RoomPlayer entries for every player on a team; players sitting out stay in the lobby
 	// Use lobbyPlayer data for team/role (which includes any swaps) instead of stale MatchOption assignments
 	var roomPlayers []*domain.RoomPlayer
 	for _, lp := range lobbyPlayers {
@@ -1314,6 +1441,121 @@ func (s *LobbyService) executeSwapRoles(ctx context.Context, action *domain.Pend
 	return s.lobbyPlayerRepo.Update(ctx, player2)
 }
 
+func (s *LobbyService) executeSubstitutePlayer(ctx context.Context, action *domain.PendingAction) error {
+	if action.Player1ID == nil || action.Player2ID == nil {
+		return ErrInvalidSwap
+	}
+
+	lobby, err := s.lobbyRepo.GetByID(ctx, action.LobbyID)
+	if err != nil {
+		return err
+	}
+	if lobby.Status != domain.LobbyStatusTeamSelected && lobby.Status != domain.LobbyStatusDrafting {
+		return ErrInvalidLobbyState
+	}
+
+	outPlayer, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, action.LobbyID, *action.Player1ID)
+	if err != nil {
+		return err
+	}
+	benchPlayer, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, action.LobbyID, *action.Player2ID)
+	if err != nil {
+		return err
+	}
+
+	// Either player may have moved since the proposal
+	if outPlayer.Team == nil || outPlayer.AssignedRole == nil {
+		return ErrNotOnTeam
+	}
+	if !benchPlayer.IsOnBench() {
+		return ErrNotOnBench
+	}
+
+	// The bench player takes over the slot: team, role and captaincy
+	benchPlayer.Team, benchPlayer.AssignedRole, benchPlayer.IsCaptain = outPlayer.Team, outPlayer.AssignedRole, outPlayer.IsCaptain
+	outPlayer.Team, outPlayer.AssignedRole, outPlayer.IsCaptain = nil, nil, false
+
+	// Before the draft the RoomPlayers are created from the lobby players later on
+	var roomPlayer *domain.RoomPlayer
+	var room *domain.Room
+	if lobby.RoomID != nil {
+		roomPlayer, room, err = s.substituteRoomPlayer(ctx, *lobby.RoomID, outPlayer.UserID, benchPlayer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Both players, and the room's slot once the draft started, change together
+	return s.transactor.WithinTransaction(ctx, func(repos *repository.Repositories) error {
+		if err := repos.LobbyPlayer.Update(ctx, outPlayer); err != nil {
+			return err
+		}
+		if err := repos.LobbyPlayer.Update(ctx, benchPlayer); err != nil {
+			return err
+		}
+		if roomPlayer != nil {
+			if err := repos.RoomPlayer.Update(ctx, roomPlayer); err != nil {
+				return err
+			}
+		}
+		if room != nil {
+			return repos.Room.Update(ctx, room)
+		}
+		return nil
+	})
+}
+
+// substituteRoomPlayer hands the leaving player's RoomPlayer slot to the substitute,
+// and the room's side to them if the leaving player was the captain. The room is only
+// returned when its side changed; neither is saved here
+func (s *LobbyService) substituteRoomPlayer(ctx context.Context, roomID, outUserID uuid.UUID, substitute *domain.LobbyPlayer) (*domain.RoomPlayer, *domain.Room, error) {
+	room, err := s.roomService.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if room.Status == domain.RoomStatusCompleted {
+		return nil, nil, ErrInvalidLobbyState
+	}
+
+	roomPlayer, err := s.roomPlayerRepo.GetByRoomAndUser(ctx, roomID, outUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrPlayerNotFound
+		}
+		return nil, nil, err
+	}
+
+	roomPlayer.UserID = substitute.UserID
+	roomPlayer.DisplayName = ""
+	if substitute.User != nil {
+		roomPlayer.DisplayName = substitute.User.DisplayName
+	}
+	roomPlayer.IsReady = false
+
+	if !roomPlayer.IsCaptain {
+		return roomPlayer, nil, nil
+	}
+	// Clear the preloaded user so saving keeps the new side user
+	if roomPlayer.Team == domain.SideBlue {
+		room.BlueSideUserID, room.BlueSideUser = &substitute.UserID, nil
+	} else {
+		room.RedSideUserID, room.RedSideUser = &substitute.UserID, nil
+	}
+	return roomPlayer, room, nil
+}
+
+// GetRoomPlayer returns a player's slot in a team draft room
+func (s *LobbyService) GetRoomPlayer(ctx context.Context, roomID, userID uuid.UUID) (*domain.RoomPlayer, error) {
+	player, err := s.roomPlayerRepo.GetByRoomAndUser(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	return player, nil
+}
+
 // ==================== Team Stats ====================
 
 // TeamStats represents the current team balance stats
@@ -1368,7 +1610,7 @@ func (s *LobbyService) GetTeamStats(ctx context.Context, lobbyID uuid.UUID) (*Te
 			// Find profile for assigned role
 			for _, prof := range userProfiles {
 				if prof.Role == *p.AssignedRole {
-					mmr = prof.MMR
+					mmr = prof.EffectiveMMR()
 					comfort = prof.ComfortRating
 					break
 				}
@@ -1380,7 +1622,7 @@ func (s *LobbyService) GetTeamStats(ctx context.Context, lobbyID uuid.UUID) (*Te
 			totalMMR := 0
 			totalComfort := 0
 			for _, prof := range userProfiles {
-				totalMMR += prof.MMR
+				totalMMR += prof.EffectiveMMR()
 				totalComfort += prof.ComfortRating
 			}
 			mmr = totalMMR / len(userProfiles)
@@ -1658,6 +1900,161 @@ func (s *LobbyService) StartVoting(ctx context.Context, lobbyID, userID uuid.UUI
 	return s.lobbyRepo.Update(ctx, lobby)
 }
 
+// UpdateMatchmakingSettings changes which algorithms and weights generate the lobby's match options (captain only).
+// The new settings apply the next time teams are generated.
+func (s *LobbyService) UpdateMatchmakingSettings(ctx context.Context, lobbyID, userID uuid.UUID, settings domain.MatchmakingSettings) (*domain.Lobby, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLobbyNotFound
+		}
+		return nil, err
+	}
+
+	player, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInLobby
+		}
+		return nil, err
+	}
+	if !player.IsCaptain {
+		return nil, ErrNotCaptain
+	}
+
+	if lobby.Status != domain.LobbyStatusWaitingForPlayers && lobby.Status != domain.LobbyStatusMatchmaking {
+		return nil, ErrInvalidLobbyState
+	}
+
+	if err := validateMatchmakingSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := lobby.SetMatchmakingSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := s.lobbyRepo.Update(ctx, lobby); err != nil {
+		return nil, err
+	}
+	return lobby, nil
+}
+
+// AddPreferenceInput is a player's matchmaking preference request
+type AddPreferenceInput struct {
+	Type         domain.PreferenceType
+	TargetUserID *uuid.UUID
+	Roles        []domain.Role
+	IsHard       bool
+}
+
+// AddPreference declares a matchmaking preference for the player.
+// The preference applies the next time teams are generated.
+func (s *LobbyService) AddPreference(ctx context.Context, lobbyID, userID uuid.UUID, input AddPreferenceInput) (*domain.LobbyPreference, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLobbyNotFound
+		}
+		return nil, err
+	}
+
+	if lobby.Status != domain.LobbyStatusWaitingForPlayers && lobby.Status != domain.LobbyStatusMatchmaking {
+		return nil, ErrInvalidLobbyState
+	}
+
+	if _, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInLobby
+		}
+		return nil, err
+	}
+
+	pref, err := domain.NewLobbyPreference(lobbyID, userID, input.Type, input.TargetUserID, input.Roles, input.IsHard)
+	if err != nil {
+		return nil, err
+	}
+
+	if pref.TargetUserID != nil {
+		if _, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, *pref.TargetUserID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrPlayerNotFound
+			}
+			return nil, err
+		}
+	}
+
+	existing, err := s.preferenceRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	for _, p := range existing {
+		if p.UserID == userID {
+			count++
+		}
+	}
+	if count >= MaxPreferencesPerPlayer {
+		return nil, ErrTooManyPreferences
+	}
+
+	if err := s.preferenceRepo.Create(ctx, pref); err != nil {
+		return nil, err
+	}
+	return pref, nil
+}
+
+// RemovePreference deletes a matchmaking preference. Players remove their own; the lobby creator
+// and captains can remove anyone's, so one player's hard preferences can't hold up matchmaking
+func (s *LobbyService) RemovePreference(ctx context.Context, lobbyID, userID, preferenceID uuid.UUID) error {
+	pref, err := s.preferenceRepo.GetByID(ctx, preferenceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPreferenceNotFound
+		}
+		return err
+	}
+	if pref.LobbyID != lobbyID {
+		return ErrPreferenceNotFound
+	}
+	if pref.UserID != userID {
+		canModerate, err := s.canModeratePreferences(ctx, lobbyID, userID)
+		if err != nil {
+			return err
+		}
+		if !canModerate {
+			return ErrNotPreferenceOwner
+		}
+	}
+	return s.preferenceRepo.Delete(ctx, preferenceID)
+}
+
+// canModeratePreferences returns whether the user created the lobby or captains a team in it
+func (s *LobbyService) canModeratePreferences(ctx context.Context, lobbyID, userID uuid.UUID) (bool, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, ErrLobbyNotFound
+		}
+		return false, err
+	}
+	if lobby.CreatedBy == userID {
+		return true, nil
+	}
+
+	player, err := s.lobbyPlayerRepo.GetByLobbyIDAndUserID(ctx, lobbyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return player.IsCaptain, nil
+}
+
+// GetPreferences returns the matchmaking preferences declared in a lobby
+func (s *LobbyService) GetPreferences(ctx context.Context, lobbyID uuid.UUID) ([]*domain.LobbyPreference, error) {
+	return s.preferenceRepo.GetByLobbyID(ctx, lobbyID)
+}
+
 // EndVoting ends voting and applies the winning option (captain only)
 func (s *LobbyService) EndVoting(ctx context.Context, lobbyID, userID uuid.UUID, forceOption *int) (*domain.Lobby, error) {
 	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLobbyService_SubstitutePlayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	// A drafting 2v2 with both teams set
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, ts.DB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "SUBS01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusTeamSelected,
+		TeamSize:  2,
+	}
+	require.NoError(t, ts.DB.DB.Create(lobby).Error)
+
+	sides := []domain.Side{domain.SideBlue, domain.SideBlue, domain.SideRed, domain.SideRed}
+	roles := []domain.Role{domain.RoleADC, domain.RoleSupport, domain.RoleADC, domain.RoleSupport}
+	var users []*domain.User
+	for i := range sides {
+		user, _ := testutil.NewUserBuilder().WithDisplayName("Sub"+string(rune('A'+i))).Build(t, ts.DB.DB)
+		users = append(users, user)
+		require.NoError(t, ts.DB.DB.Create(&domain.LobbyPlayer{
+			ID:           uuid.New(),
+			LobbyID:      lobby.ID,
+			UserID:       user.ID,
+			Team:         &sides[i],
+			AssignedRole: &roles[i],
+			IsCaptain:    i%2 == 0,
+			JoinOrder:    i,
+		}).Error)
+	}
+	blueCaptain, blueSupport, redCaptain := users[0], users[1], users[2]
+
+	room := &domain.Room{
+		ID:             uuid.New(),
+		ShortCode:      "SUBR01",
+		CreatedBy:      blueCaptain.ID,
+		Status:         domain.RoomStatusInProgress,
+		IsTeamDraft:    true,
+		LobbyID:        &lobby.ID,
+		BlueSideUserID: &blueCaptain.ID,
+		RedSideUserID:  &redCaptain.ID,
+	}
+	require.NoError(t, ts.Repos.Room.Create(ctx, room))
+	for i, user := range users {
+		require.NoError(t, ts.Repos.RoomPlayer.Create(ctx, &domain.RoomPlayer{
+			ID:           uuid.New(),
+			RoomID:       room.ID,
+			UserID:       user.ID,
+			Team:         sides[i],
+			AssignedRole: roles[i],
+			DisplayName:  user.DisplayName,
+			IsCaptain:    i%2 == 0,
+		}))
+	}
+	lobby.Status = domain.LobbyStatusDrafting
+	lobby.RoomID = &room.ID
+	require.NoError(t, ts.Repos.Lobby.Update(ctx, lobby))
+
+	// Late joiners take a bench slot
+	bench1, _ := testutil.NewUserBuilder().WithDisplayName("Bench1").Build(t, ts.DB.DB)
+	bench2, _ := testutil.NewUserBuilder().WithDisplayName("Bench2").Build(t, ts.DB.DB)
+	for _, user := range []*domain.User{bench1, bench2} {
+		player, err := ts.Services.Lobby.JoinLobby(ctx, lobby.ID, user.ID)
+		require.NoError(t, err)
+		assert.True(t, player.IsOnBench())
+	}
+
+	// Only bench players can come in, and only for the captain's own team
+	_, err := ts.Services.Lobby.ProposeSubstitute(ctx, lobby.ID, blueCaptain.ID, blueSupport.ID, redCaptain.ID)
+	assert.ErrorIs(t, err, service.ErrNotOnBench)
+	_, err = ts.Services.Lobby.ProposeSubstitute(ctx, lobby.ID, blueCaptain.ID, redCaptain.ID, bench1.ID)
+	assert.ErrorIs(t, err, service.ErrNotOnTeam)
+
+	t.Run("substitute keeps the role", func(t *testing.T) {
+		action, err := ts.Services.Lobby.ProposeSubstitute(ctx, lobby.ID, blueCaptain.ID, blueSupport.ID, bench1.ID)
+		require.NoError(t, err)
+		require.NoError(t, ts.Services.Lobby.ApprovePendingAction(ctx, lobby.ID, redCaptain.ID, action.ID))
+
+		in, err := ts.Repos.LobbyPlayer.GetByLobbyIDAndUserID(ctx, lobby.ID, bench1.ID)
+		require.NoError(t, err)
+		require.NotNil(t, in.Team)
+		assert.Equal(t, domain.SideBlue, *in.Team)
+		assert.Equal(t, domain.RoleSupport, *in.AssignedRole)
+		assert.False(t, in.IsCaptain)
+
+		out, err := ts.Repos.LobbyPlayer.GetByLobbyIDAndUserID(ctx, lobby.ID, blueSupport.ID)
+		require.NoError(t, err)
+		assert.True(t, out.IsOnBench())
+
+		roomPlayer, err := ts.Services.Lobby.GetRoomPlayer(ctx, room.ID, bench1.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleSupport, roomPlayer.AssignedRole)
+		assert.Equal(t, "Bench1", roomPlayer.DisplayName)
+		_, err = ts.Services.Lobby.GetRoomPlayer(ctx, room.ID, blueSupport.ID)
+		assert.ErrorIs(t, err, service.ErrPlayerNotFound)
+	})
+
+	t.Run("leaving captain hands over captaincy", func(t *testing.T) {
+		action, err := ts.Services.Lobby.ProposeSubstitute(ctx, lobby.ID, redCaptain.ID, redCaptain.ID, bench2.ID)
+		require.NoError(t, err)
+		require.NoError(t, ts.Services.Lobby.ApprovePendingAction(ctx, lobby.ID, blueCaptain.ID, action.ID))
+
+		in, err := ts.Repos.LobbyPlayer.GetByLobbyIDAndUserID(ctx, lobby.ID, bench2.ID)
+		require.NoError(t, err)
+		assert.True(t, in.IsCaptain)
+		assert.Equal(t, domain.RoleADC, *in.AssignedRole)
+
+		roomPlayer, err := ts.Services.Lobby.GetRoomPlayer(ctx, room.ID, bench2.ID)
+		require.NoError(t, err)
+		assert.True(t, roomPlayer.IsCaptain)
+
+		updated, err := ts.Repos.Room.GetByID(ctx, room.ID)
+		require.NoError(t, err)
+		require.NotNil(t, updated.RedSideUserID)
+		assert.Equal(t, bench2.ID, *updated.RedSideUserID)
+	})
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDraftNotCompleted      = errors.New("results can only be reported once the draft is completed")
+	ErrNotRoomCaptain         = errors.New("only a captain of either side can report results")
+	ErrResultAlreadyConfirmed = errors.New("result is already confirmed")
+	ErrNoPendingResult        = errors.New("no result is awaiting confirmation")
+	ErrOwnResultReport        = errors.New("a result must be confirmed by the other side")
+)
+
+// MatchResultService handles reporting who won the game played from a completed draft.
+// One captain reports the winner and the other confirms it; conflicting reports are
+// flagged as disputed until a captain reports again.
+type MatchResultService struct {
+	roomRepo       repository.RoomRepository
+	roomPlayerRepo repository.RoomPlayerRepository
+	ratingService  *RatingService
+}
+
+func NewMatchResultService(
+	roomRepo repository.RoomRepository,
+	roomPlayerRepo repository.RoomPlayerRepository,
+	ratingService *RatingService,
+) *MatchResultService {
+	return &MatchResultService{
+		roomRepo:       roomRepo,
+		roomPlayerRepo: roomPlayerRepo,
+		ratingService:  ratingService,
+	}
+}
+
+// ReportResult records the winner as reported by one side's captain.
+// A report from the other side that agrees with a pending one confirms it, and one
+// that disagrees disputes it. Reporting again after a dispute starts over.
+// Players are rated once the result is confirmed.
+func (s *MatchResultService) ReportResult(ctx context.Context, roomID, userID uuid.UUID, winner domain.Side) (*domain.Room, error) {
+	if winner != domain.SideBlue && winner != domain.SideRed {
+		return nil, ErrInvalidGameWinner
+	}
+
+	room, side, err := s.getReportableRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case room.ResultStatus == domain.ResultStatusConfirmed:
+		return nil, s.rateConfirmed(ctx, room)
+	case room.ResultStatus == domain.ResultStatusPending && *room.ResultReporterSide != side:
+		if *room.WinnerSide == winner {
+			room.ConfirmResult(winner)
+		} else {
+			room.ResultStatus = domain.ResultStatusDisputed
+		}
+	default:
+		now := time.Now()
+		room.WinnerSide = &winner
+		room.ResultStatus = domain.ResultStatusPending
+		room.ResultReportedBy = &userID
+		room.ResultReporterSide = &side
+		room.ResultReportedAt = &now
+
+		// Nobody can confirm for the bot, so a report against it stands
+		if room.BotSide != nil && *room.BotSide != side {
+			room.ConfirmResult(winner)
+		}
+	}
+
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+	if err := s.ratingService.ApplyMatchResult(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// ConfirmResult accepts the result pending from the other side and rates the players.
+func (s *MatchResultService) ConfirmResult(ctx context.Context, roomID, userID uuid.UUID) (*domain.Room, error) {
+	room, side, err := s.getReportableRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if room.ResultStatus == domain.ResultStatusConfirmed {
+		return nil, s.rateConfirmed(ctx, room)
+	}
+	if err := checkPendingResult(room, side); err != nil {
+		return nil, err
+	}
+
+	room.ConfirmResult(*room.WinnerSide)
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+	if err := s.ratingService.ApplyMatchResult(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// DisputeResult rejects the result pending from the other side.
+func (s *MatchResultService) DisputeResult(ctx context.Context, roomID, userID uuid.UUID) (*domain.Room, error) {
+	room, err := s.getPendingResult(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	room.ResultStatus = domain.ResultStatusDisputed
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// getPendingResult loads a room whose result the user's side can confirm or dispute
+func (s *MatchResultService) getPendingResult(ctx context.Context, roomID, userID uuid.UUID) (*domain.Room, error) {
+	room, side, err := s.getReportableRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPendingResult(room, side); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// checkPendingResult returns an error unless the room has a result pending from the other side
+func checkPendingResult(room *domain.Room, side domain.Side) error {
+	if room.ResultStatus != domain.ResultStatusPending {
+		return ErrNoPendingResult
+	}
+	if *room.ResultReporterSide == side {
+		return ErrOwnResultReport
+	}
+	return nil
+}
+
+// rateConfirmed handles a report or confirmation for a room that is already confirmed.
+// The result was saved before its ratings, so a rating that failed then is applied now
+// (ApplyMatchResult never rates a room twice) before reporting that nothing changed.
+func (s *MatchResultService) rateConfirmed(ctx context.Context, room *domain.Room) error {
+	if err := s.ratingService.ApplyMatchResult(ctx, room); err != nil {
+		return err
+	}
+	return ErrResultAlreadyConfirmed
+}
+
+// getReportableRoom loads a completed room and the side the user captains in it
+func (s *MatchResultService) getReportableRoom(ctx context.Context, roomID, userID uuid.UUID) (*domain.Room, domain.Side, error) {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrRoomNotFound
+		}
+		return nil, "", err
+	}
+
+	if room.Status != domain.RoomStatusCompleted {
+		return nil, "", ErrDraftNotCompleted
+	}
+
+	side, ok := s.captainSide(ctx, room, userID)
+	if !ok {
+		return nil, "", ErrNotRoomCaptain
+	}
+	return room, side, nil
+}
+
+// captainSide returns the side the user captains: their side in a 1v1 draft, or the
+// team they captain in a team draft
+func (s *MatchResultService) captainSide(ctx context.Context, room *domain.Room, userID uuid.UUID) (domain.Side, bool) {
+	if room.IsTeamDraft {
+		player, err := s.roomPlayerRepo.GetByRoomAndUser(ctx, room.ID, userID)
+		if err != nil || player == nil || !player.IsCaptain {
+			return "", false
+		}
+		return player.Team, true
+	}
+
+	if room.BlueSideUserID != nil && *room.BlueSideUserID == userID {
+		return domain.SideBlue, true
+	}
+	if room.RedSideUserID != nil && *room.RedSideUserID == userID {
+		return domain.SideRed, true
+	}
+	return "", false
+}
@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchResultService_ReportAndConfirm(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	blue, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	red, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	outsider, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	room := testutil.NewRoomBuilder().
+		WithBlueSide(blue).
+		WithRedSide(red).
+		Build(t, testDB.DB)
+
+	// The draft is still running
+	_, err := resultService.ReportResult(ctx, room.ID, blue.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrDraftNotCompleted)
+
+	room.Status = domain.RoomStatusCompleted
+	require.NoError(t, repos.Room.Update(ctx, room))
+
+	_, err = resultService.ReportResult(ctx, room.ID, outsider.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrNotRoomCaptain)
+
+	_, err = resultService.ReportResult(ctx, room.ID, blue.ID, domain.Side("purple"))
+	assert.ErrorIs(t, err, service.ErrInvalidGameWinner)
+
+	reported, err := resultService.ReportResult(ctx, room.ID, blue.ID, domain.SideBlue)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResultStatusPending, reported.ResultStatus)
+	assert.Equal(t, domain.SideBlue, *reported.ResultReporterSide)
+
+	// The reporting side cannot confirm its own result
+	_, err = resultService.ConfirmResult(ctx, room.ID, blue.ID)
+	assert.ErrorIs(t, err, service.ErrOwnResultReport)
+
+	confirmed, err := resultService.ConfirmResult(ctx, room.ID, red.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResultStatusConfirmed, confirmed.ResultStatus)
+	require.NotNil(t, confirmed.WinnerSide)
+	assert.Equal(t, domain.SideBlue, *confirmed.WinnerSide)
+	assert.NotNil(t, confirmed.ResultConfirmedAt)
+
+	_, err = resultService.ReportResult(ctx, room.ID, red.ID, domain.SideRed)
+	assert.ErrorIs(t, err, service.ErrResultAlreadyConfirmed)
+}
+
+func TestMatchResultService_Disputes(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	blueCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	blueMember, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	redCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	room := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(blueMember, domain.SideBlue, domain.RoleMid, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		Build(t, testDB.DB)
+	room.Status = domain.RoomStatusCompleted
+	require.NoError(t, repos.Room.Update(ctx, room))
+
+	t.Run("only captains report in team drafts", func(t *testing.T) {
+		_, err := resultService.ReportResult(ctx, room.ID, blueMember.ID, domain.SideBlue)
+		assert.ErrorIs(t, err, service.ErrNotRoomCaptain)
+	})
+
+	t.Run("conflicting reports are disputed", func(t *testing.T) {
+		_, err := resultService.ReportResult(ctx, room.ID, blueCaptain.ID, domain.SideBlue)
+		require.NoError(t, err)
+
+		disputed, err := resultService.ReportResult(ctx, room.ID, redCaptain.ID, domain.SideRed)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResultStatusDisputed, disputed.ResultStatus)
+
+		_, err = resultService.ConfirmResult(ctx, room.ID, redCaptain.ID)
+		assert.ErrorIs(t, err, service.ErrNoPendingResult)
+	})
+
+	t.Run("reporting again after a dispute starts over", func(t *testing.T) {
+		reported, err := resultService.ReportResult(ctx, room.ID, redCaptain.ID, domain.SideRed)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResultStatusPending, reported.ResultStatus)
+
+		disputed, err := resultService.DisputeResult(ctx, room.ID, blueCaptain.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResultStatusDisputed, disputed.ResultStatus)
+	})
+
+	t.Run("matching reports confirm", func(t *testing.T) {
+		_, err := resultService.ReportResult(ctx, room.ID, blueCaptain.ID, domain.SideRed)
+		require.NoError(t, err)
+
+		confirmed, err := resultService.ReportResult(ctx, room.ID, redCaptain.ID, domain.SideRed)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResultStatusConfirmed, confirmed.ResultStatus)
+		assert.Equal(t, domain.SideRed, *confirmed.WinnerSide)
+	})
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SoftPreferencePenalty is subtracted from an option's score for each soft preference it breaks
+const SoftPreferencePenalty = 5.0
+
+// teamConstraint is a same_team or not_same_team preference between two lobby players
+type teamConstraint struct {
+	id       uuid.UUID
+	a, b     int // player indexes
+	sameTeam bool
+	hard     bool
+}
+
+// roleConstraint is an only_roles or never_role preference of one lobby player
+type roleConstraint struct {
+	id      uuid.UUID
+	player  int
+	allowed [domain.MaxTeamSize]bool // indexed like the format's roles
+	hard    bool
+}
+
+// roleRules are a team's role constraints by team position and role index
+type roleRules struct {
+	blocked    [domain.MaxTeamSize][domain.MaxTeamSize]bool
+	violations [domain.MaxTeamSize][domain.MaxTeamSize]int
+}
+
+// lobbyPreferences are a lobby's preferences resolved against the players being matched.
+// Preferences of or about players who are not in the lobby are ignored.
+type lobbyPreferences struct {
+	roles []domain.Role
+	index map[uuid.UUID]int
+	team  []teamConstraint
+	role  []roleConstraint
+}
+
+func compilePreferences(prefs []*domain.LobbyPreference, players []*PlayerData, roles []domain.Role) *lobbyPreferences {
+	compiled := &lobbyPreferences{roles: roles, index: make(map[uuid.UUID]int, len(players))}
+	for i, p := range players {
+		compiled.index[p.UserID] = i
+	}
+
+	for _, pref := range prefs {
+		player, ok := compiled.index[pref.UserID]
+		if !ok {
+			continue
+		}
+
+		if pref.Type.IsTeamPreference() {
+			if pref.TargetUserID == nil {
+				continue
+			}
+			target, ok := compiled.index[*pref.TargetUserID]
+			if !ok {
+				continue
+			}
+			compiled.team = append(compiled.team, teamConstraint{
+				id:       pref.ID,
+				a:        player,
+				b:        target,
+				sameTeam: pref.Type == domain.PreferenceSameTeam,
+				hard:     pref.IsHard,
+			})
+			continue
+		}
+
+		rc := roleConstraint{id: pref.ID, player: player, hard: pref.IsHard}
+		for r, role := range roles {
+			rc.allowed[r] = pref.AllowsRole(role)
+		}
+		compiled.role = append(compiled.role, rc)
+	}
+
+	return compiled
+}
+
+// hasHard returns whether any preference must be kept
+func (p *lobbyPreferences) hasHard() bool {
+	for _, tc := range p.team {
+		if tc.hard {
+			return true
+		}
+	}
+	for _, rc := range p.role {
+		if rc.hard {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSplit returns whether a team split keeps every hard team preference,
+// and how many soft ones it breaks
+func (p *lobbyPreferences) checkSplit(split []bool) (bool, int) {
+	violations := 0
+	for _, tc := range p.team {
+		if (split[tc.a] == split[tc.b]) != tc.sameTeam {
+			if tc.hard {
+				return false, 0
+			}
+			violations++
+		}
+	}
+	return true, violations
+}
+
+// roleRulesFor returns the role constraints of a team, given the player index at each position
+func (p *lobbyPreferences) roleRulesFor(team []int) roleRules {
+	var rules roleRules
+	for _, rc := range p.role {
+		for pos, player := range team {
+			if player != rc.player {
+				continue
+			}
+			for r := range p.roles {
+				if rc.allowed[r] {
+					continue
+				}
+				if rc.hard {
+					rules.blocked[pos][r] = true
+				} else {
+					rules.violations[pos][r]++
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// results returns the IDs of the preferences a composition keeps and breaks
+func (p *lobbyPreferences) results(assignments []TeamAssignment) (satisfied, unsatisfied []uuid.UUID) {
+	teams := make(map[int]domain.Side, len(assignments))
+	roles := make(map[int]domain.Role, len(assignments))
+	for _, a := range assignments {
+		if i, ok := p.index[a.UserID]; ok {
+			teams[i] = a.Team
+			roles[i] = a.Role
+		}
+	}
+
+	for _, tc := range p.team {
+		if (teams[tc.a] == teams[tc.b]) == tc.sameTeam {
+			satisfied = append(satisfied, tc.id)
+		} else {
+			unsatisfied = append(unsatisfied, tc.id)
+		}
+	}
+	for _, rc := range p.role {
+		allowed := false
+		for r, role := range p.roles {
+			if role == roles[rc.player] {
+				allowed = rc.allowed[r]
+			}
+		}
+		if allowed {
+			satisfied = append(satisfied, rc.id)
+		} else {
+			unsatisfied = append(unsatisfied, rc.id)
+		}
+	}
+	return satisfied, unsatisfied
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchmakingService_PlayerPreferences(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "PREF01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+	}
+	require.NoError(t, testDB.DB.Create(lobby).Error)
+
+	mmrs := []int{3000, 2800, 2500, 2200, 2000, 1800, 1500, 1200, 1000, 800}
+	var players []*domain.LobbyPlayer
+	for i, mmr := range mmrs {
+		user := createUserUniform(t, testDB.DB, "Pref"+string(rune('A'+i)), mmr, i%5+1)
+		players = append(players, createLobbyPlayer(t, testDB.DB, lobby.ID, user, true))
+	}
+
+	// The two strongest players duo, player C only plays support, and player D would rather avoid E
+	duo, err := domain.NewLobbyPreference(lobby.ID, players[0].UserID, domain.PreferenceSameTeam, &players[1].UserID, nil, true)
+	require.NoError(t, err)
+	support, err := domain.NewLobbyPreference(lobby.ID, players[2].UserID, domain.PreferenceOnlyRoles, nil, []domain.Role{domain.RoleSupport}, true)
+	require.NoError(t, err)
+	avoid, err := domain.NewLobbyPreference(lobby.ID, players[3].UserID, domain.PreferenceNotSameTeam, &players[4].UserID, nil, false)
+	require.NoError(t, err)
+	for _, pref := range []*domain.LobbyPreference{duo, support, avoid} {
+		require.NoError(t, repos.LobbyPreference.Create(ctx, pref))
+	}
+
+	options, err := svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	require.NoError(t, err)
+	require.NotEmpty(t, options)
+
+	for _, opt := range options {
+		teams := make(map[uuid.UUID]domain.Side)
+		roles := make(map[uuid.UUID]domain.Role)
+		for _, a := range opt.Assignments {
+			teams[a.UserID] = a.Team
+			roles[a.UserID] = a.AssignedRole
+		}
+
+		// Hard preferences are always kept
+		assert.Equal(t, teams[players[0].UserID], teams[players[1].UserID])
+		assert.Equal(t, domain.RoleSupport, roles[players[2].UserID])
+
+		satisfied, unsatisfied, err := opt.GetPreferenceResults()
+		require.NoError(t, err)
+		assert.Contains(t, satisfied, duo.ID)
+		assert.Contains(t, satisfied, support.ID)
+
+		// The soft preference is reported either way
+		if teams[players[3].UserID] != teams[players[4].UserID] {
+			assert.Contains(t, satisfied, avoid.ID)
+		} else {
+			assert.Contains(t, unsatisfied, avoid.ID)
+		}
+	}
+
+	// Contradicting hard preferences leave no options
+	split, err := domain.NewLobbyPreference(lobby.ID, players[1].UserID, domain.PreferenceNotSameTeam, &players[0].UserID, nil, true)
+	require.NoError(t, err)
+	require.NoError(t, repos.LobbyPreference.Create(ctx, split))
+
+	_, err = svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	assert.ErrorIs(t, err, service.ErrPreferencesUnsatisfiable)
+}
+
+func TestNewLobbyPreference_Validation(t *testing.T) {
+	lobbyID, userID, targetID := uuid.New(), uuid.New(), uuid.New()
+
+	_, err := domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceSameTeam, nil, nil, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidPreference)
+
+	_, err = domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceSameTeam, &userID, nil, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidPreference)
+
+	_, err = domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceNeverRole, nil, []domain.Role{domain.RoleTop, domain.RoleMid}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidPreference)
+
+	_, err = domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceOnlyRoles, nil, []domain.Role{"roam"}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidRole)
+
+	pref, err := domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceNeverRole, nil, []domain.Role{domain.RoleJungle}, true)
+	require.NoError(t, err)
+	assert.False(t, pref.AllowsRole(domain.RoleJungle))
+	assert.True(t, pref.AllowsRole(domain.RoleTop))
+
+	_, err = domain.NewLobbyPreference(lobbyID, userID, domain.PreferenceNotSameTeam, &targetID, nil, false)
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/rand"
 	"sort"
@@ -20,21 +21,36 @@ const (
 	MaxMmrThreshold       = 500
 )
 
+// SitOutWindow is how far back recent participation counts when choosing who sits out
+const SitOutWindow = 30 * 24 * time.Hour
+
+var (
+	ErrUnknownMatchmakingAlgorithm = errors.New("unknown matchmaking algorithm")
+	ErrInvalidBalanceWeights       = errors.New("balance weights must be between 0 and 10")
+	ErrPreferencesUnsatisfiable    = errors.New("no team composition keeps every hard preference")
+)
+
 type MatchmakingService struct {
 	profileRepo     repository.UserRoleProfileRepository
 	matchOptionRepo repository.MatchOptionRepository
 	lobbyRepo       repository.LobbyRepository
+	preferenceRepo  repository.LobbyPreferenceRepository
+	lobbyPlayerRepo repository.LobbyPlayerRepository
 }
 
 func NewMatchmakingService(
 	profileRepo repository.UserRoleProfileRepository,
 	matchOptionRepo repository.MatchOptionRepository,
 	lobbyRepo repository.LobbyRepository,
+	preferenceRepo repository.LobbyPreferenceRepository,
+	lobbyPlayerRepo repository.LobbyPlayerRepository,
 ) *MatchmakingService {
 	return &MatchmakingService{
 		profileRepo:     profileRepo,
 		matchOptionRepo: matchOptionRepo,
 		lobbyRepo:       lobbyRepo,
+		preferenceRepo:  preferenceRepo,
+		lobbyPlayerRepo: lobbyPlayerRepo,
 	}
 }
 
@@ -45,6 +61,15 @@ type PlayerData struct {
 	RoleProfiles map[domain.Role]*domain.UserRoleProfile
 }
 
+// BestMMR returns the player's highest MMR over the given roles
+func (p *PlayerData) BestMMR(roles []domain.Role) int {
+	best := 0
+	for _, role := range roles {
+		best = max(best, p.RoleProfiles[role].EffectiveMMR())
+	}
+	return best
+}
+
 // TeamAssignment represents a player's assignment to a team and role
 type TeamAssignment struct {
 	UserID        uuid.UUID
@@ -67,55 +92,49 @@ type GeneratedOption struct {
 	MaxLaneDiff         int
 	AlgorithmType       domain.AlgorithmType
 	UsedMmrThreshold    int
+	// IDs of the lobby preferences the option keeps and breaks
+	SatisfiedPreferences   []uuid.UUID
+	UnsatisfiedPreferences []uuid.UUID
+}
+
+// generationConfig is how a lobby's options are generated
+type generationConfig struct {
+	roles     []domain.Role // the lobby format's roles, one per team slot
+	balancers []TeamBalancer
+	weights   domain.BalanceWeights
+	prefs     *lobbyPreferences
 }
 
-// GenerateMatchOptions generates balanced team options for a lobby
+// GenerateMatchOptions generates balanced team options for a lobby.
+// When the lobby has more players than its format needs, the same players sit out in every option.
 func (s *MatchmakingService) GenerateMatchOptions(ctx context.Context, lobbyID uuid.UUID, players []*domain.LobbyPlayer, count int) ([]*domain.MatchOption, error) {
-	if len(players) != 10 {
-		return nil, ErrNotEnoughPlayers
+	lobby, config, err := s.getGenerationConfig(ctx, lobbyID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Load role profiles for all players
-	userIDs := make([]uuid.UUID, len(players))
-	displayNames := make(map[uuid.UUID]string)
-	for i, p := range players {
-		userIDs[i] = p.UserID
-		if p.User != nil {
-			displayNames[p.UserID] = p.User.DisplayName
-		}
+	if err := checkPlayerCount(lobby, players); err != nil {
+		return nil, err
 	}
-
-	profilesByUser, err := s.profileRepo.GetByUserIDs(ctx, userIDs)
+	players, err = s.choosePlayers(ctx, lobby, players)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build player data
-	playerData := make([]*PlayerData, len(players))
-	for i, p := range players {
-		pd := &PlayerData{
-			UserID:       p.UserID,
-			DisplayName:  displayNames[p.UserID],
-			RoleProfiles: make(map[domain.Role]*domain.UserRoleProfile),
-		}
-
-		profiles := profilesByUser[p.UserID]
-		for _, profile := range profiles {
-			pd.RoleProfiles[profile.Role] = profile
-		}
-
-		// Fill missing roles with defaults
-		for _, role := range domain.AllRoles {
-			if _, ok := pd.RoleProfiles[role]; !ok {
-				pd.RoleProfiles[role] = domain.NewDefaultUserRoleProfile(p.UserID, role)
-			}
-		}
+	playerData, err := s.loadPlayerData(ctx, players)
+	if err != nil {
+		return nil, err
+	}
 
-		playerData[i] = pd
+	if err := s.loadPreferences(ctx, lobbyID, playerData, config); err != nil {
+		return nil, err
 	}
 
 	// Generate all possible team combinations and find the best ones
-	options := s.generateBestOptions(playerData, count)
+	options := s.generateBestOptions(playerData, count, config)
+	if len(options) == 0 && config.prefs.hasHard() {
+		return nil, ErrPreferencesUnsatisfiable
+	}
 
 	// Delete existing options for this lobby
 	if err := s.matchOptionRepo.DeleteByLobbyID(ctx, lobbyID); err != nil {
@@ -125,33 +144,9 @@ func (s *MatchmakingService) GenerateMatchOptions(ctx context.Context, lobbyID u
 	// Save new options
 	savedOptions := make([]*domain.MatchOption, len(options))
 	for i, opt := range options {
-		matchOption := &domain.MatchOption{
-			ID:               uuid.New(),
-			LobbyID:          lobbyID,
-			OptionNumber:     i + 1,
-			AlgorithmType:    opt.AlgorithmType,
-			BlueTeamAvgMMR:   opt.BlueTeamMMR / 5,
-			RedTeamAvgMMR:    opt.RedTeamMMR / 5,
-			MMRDifference:    opt.MMRDifference,
-			BalanceScore:     opt.BalanceScore,
-			AvgBlueComfort:   opt.AvgBlueComfort,
-			AvgRedComfort:    opt.AvgRedComfort,
-			MaxLaneDiff:      opt.MaxLaneDiff,
-			UsedMmrThreshold: opt.UsedMmrThreshold,
-			CreatedAt:        time.Now(),
-			Assignments:      make([]domain.MatchOptionAssignment, len(opt.Assignments)),
-		}
-
-		for j, a := range opt.Assignments {
-			matchOption.Assignments[j] = domain.MatchOptionAssignment{
-				ID:            uuid.New(),
-				MatchOptionID: matchOption.ID,
-				UserID:        a.UserID,
-				Team:          a.Team,
-				AssignedRole:  a.Role,
-				RoleMMR:       a.RoleMMR,
-				ComfortRating: a.ComfortRating,
-			}
+		matchOption, err := newMatchOption(lobbyID, i+1, opt, len(config.roles))
+		if err != nil {
+			return nil, err
 		}
 
 		if err := s.matchOptionRepo.Create(ctx, matchOption); err != nil {
@@ -161,10 +156,6 @@ func (s *MatchmakingService) GenerateMatchOptions(ctx context.Context, lobbyID u
 	}
 
 	// Update lobby status
-	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
-	if err != nil {
-		return nil, err
-	}
 	lobby.Status = domain.LobbyStatusMatchmaking
 	if err := s.lobbyRepo.Update(ctx, lobby); err != nil {
 		return nil, err
@@ -173,10 +164,16 @@ func (s *MatchmakingService) GenerateMatchOptions(ctx context.Context, lobbyID u
 	return s.matchOptionRepo.GetByLobbyID(ctx, lobbyID)
 }
 
-// GenerateMoreMatchOptions generates additional team options and appends them to existing ones
+// GenerateMoreMatchOptions generates additional team options and appends them to existing ones.
+// Players who sit out of the existing options sit out of the new ones too.
 func (s *MatchmakingService) GenerateMoreMatchOptions(ctx context.Context, lobbyID uuid.UUID, players []*domain.LobbyPlayer, count int) ([]*domain.MatchOption, error) {
-	if len(players) != 10 {
-		return nil, ErrNotEnoughPlayers
+	lobby, config, err := s.getGenerationConfig(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPlayerCount(lobby, players); err != nil {
+		return nil, err
 	}
 
 	// Get existing options to find max option number and existing compositions
@@ -185,6 +182,18 @@ func (s *MatchmakingService) GenerateMoreMatchOptions(ctx context.Context, lobby
 		return nil, err
 	}
 
+	if len(existingOptions) > 0 {
+		players = playersInOption(players, existingOptions[0])
+	} else {
+		players, err = s.choosePlayers(ctx, lobby, players)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(players) != lobby.PlayersPerMatch() {
+		return nil, ErrNotEnoughPlayers
+	}
+
 	// Find the next option number and max threshold used
 	maxOptionNum := 0
 	maxThresholdUsed := InitialMmrThreshold
@@ -204,7 +213,196 @@ func (s *MatchmakingService) GenerateMoreMatchOptions(ctx context.Context, lobby
 	// Increase threshold by 100 for loading more teams
 	newThreshold := maxThresholdUsed + MmrThresholdIncrement
 
-	// Load role profiles for all players
+	playerData, err := s.loadPlayerData(ctx, players)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadPreferences(ctx, lobbyID, playerData, config); err != nil {
+		return nil, err
+	}
+
+	// Generate ALL possible options with the increased threshold
+	// There are at most 252 possible team splits (5v5), so we request all of them
+	options := s.generateBestOptionsWithMinThreshold(playerData, 252, newThreshold, config)
+
+	// Filter out duplicates - use a fresh set to track within this batch too
+	seenInBatch := make(map[string]bool)
+	var newOptions []*GeneratedOption
+	for _, opt := range options {
+		hash := computeAssignmentHash(opt.Assignments)
+		// Skip if already exists in DB or already added in this batch
+		if existingHashes[hash] || seenInBatch[hash] {
+			continue
+		}
+		seenInBatch[hash] = true
+		newOptions = append(newOptions, opt)
+		if len(newOptions) >= count {
+			break
+		}
+	}
+
+	// Save new options with incremented option numbers
+	for i, opt := range newOptions {
+		matchOption, err := newMatchOption(lobbyID, maxOptionNum+i+1, opt, len(config.roles))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.matchOptionRepo.Create(ctx, matchOption); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.matchOptionRepo.GetByLobbyID(ctx, lobbyID)
+}
+
+// AssignRolesByComfort gives teams picked in a captain's draft their most comfortable roles.
+// The result replaces the lobby's options as option 1. Hard role preferences are kept when they
+// can be, teams are never changed
+func (s *MatchmakingService) AssignRolesByComfort(ctx context.Context, lobbyID uuid.UUID, blue, red []*domain.LobbyPlayer) (*domain.MatchOption, error) {
+	lobby, config, err := s.getGenerationConfig(ctx, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blue) != lobby.PlayersPerTeam() || len(red) != lobby.PlayersPerTeam() {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	comfortFirst, _ := GetBalancer(domain.AlgorithmComfortFirst)
+	config.balancers = []TeamBalancer{comfortFirst}
+	config.weights = domain.DefaultBalanceWeights
+
+	playerData, err := s.loadPlayerData(ctx, append(append([]*domain.LobbyPlayer{}, blue...), red...))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadPreferences(ctx, lobbyID, playerData, config); err != nil {
+		return nil, err
+	}
+
+	teamSize := len(blue)
+	blueIdx, redIdx := make([]int, teamSize), make([]int, teamSize)
+	for i := range teamSize {
+		blueIdx[i], redIdx[i] = i, teamSize+i
+	}
+	blueTeam, redTeam := playerData[:teamSize], playerData[teamSize:]
+
+	opt := s.findBestRoleAssignment(blueTeam, redTeam, config, config.prefs.roleRulesFor(blueIdx), config.prefs.roleRulesFor(redIdx), 0)[0]
+	if opt == nil {
+		// The captains' picks leave no way to keep every hard role preference
+		opt = s.findBestRoleAssignment(blueTeam, redTeam, config, roleRules{}, roleRules{}, 0)[0]
+	}
+	opt.AlgorithmType = domain.AlgorithmCaptainsDraft
+	opt.SatisfiedPreferences, opt.UnsatisfiedPreferences = config.prefs.results(opt.Assignments)
+
+	if err := s.matchOptionRepo.DeleteByLobbyID(ctx, lobbyID); err != nil {
+		return nil, err
+	}
+	matchOption, err := newMatchOption(lobbyID, 1, opt, teamSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.matchOptionRepo.Create(ctx, matchOption); err != nil {
+		return nil, err
+	}
+	return matchOption, nil
+}
+
+// getGenerationConfig loads a lobby with the balancers and weights its options are generated with
+func (s *MatchmakingService) getGenerationConfig(ctx context.Context, lobbyID uuid.UUID) (*domain.Lobby, *generationConfig, error) {
+	lobby, err := s.lobbyRepo.GetByID(ctx, lobbyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings, err := lobby.GetMatchmakingSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	balancers, err := resolveBalancers(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lobby, &generationConfig{roles: lobby.Roles(), balancers: balancers, weights: settings.BalanceWeights()}, nil
+}
+
+// checkPlayerCount checks that a lobby has enough players for its format and no more than it accepts
+func checkPlayerCount(lobby *domain.Lobby, players []*domain.LobbyPlayer) error {
+	if len(players) < lobby.PlayersPerMatch() {
+		return ErrNotEnoughPlayers
+	}
+	if len(players) > lobby.MaxPlayers() {
+		return ErrLobbyFull
+	}
+	return nil
+}
+
+// choosePlayers picks who plays when a lobby has more players than its format needs.
+// Players who recently played the most and sat out the least sit out first; ties are random.
+func (s *MatchmakingService) choosePlayers(ctx context.Context, lobby *domain.Lobby, players []*domain.LobbyPlayer) ([]*domain.LobbyPlayer, error) {
+	sitOuts := len(players) - lobby.PlayersPerMatch()
+	if sitOuts <= 0 {
+		return players, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(players))
+	for i, p := range players {
+		userIDs[i] = p.UserID
+	}
+	participation, err := s.lobbyPlayerRepo.GetRecentParticipation(ctx, userIDs, time.Now().Add(-SitOutWindow))
+	if err != nil {
+		return nil, err
+	}
+	recentBalance := func(p *domain.LobbyPlayer) int {
+		if pp, ok := participation[p.UserID]; ok {
+			return pp.Played - pp.SatOut
+		}
+		return 0
+	}
+
+	candidates := make([]*domain.LobbyPlayer, len(players))
+	copy(candidates, players)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return recentBalance(candidates[i]) > recentBalance(candidates[j])
+	})
+
+	sittingOut := make(map[uuid.UUID]bool, sitOuts)
+	for _, p := range candidates[:sitOuts] {
+		sittingOut[p.UserID] = true
+	}
+
+	active := make([]*domain.LobbyPlayer, 0, lobby.PlayersPerMatch())
+	for _, p := range players {
+		if !sittingOut[p.UserID] {
+			active = append(active, p)
+		}
+	}
+	return active, nil
+}
+
+// playersInOption returns the players assigned a team in a match option
+func playersInOption(players []*domain.LobbyPlayer, option *domain.MatchOption) []*domain.LobbyPlayer {
+	assigned := make(map[uuid.UUID]bool, len(option.Assignments))
+	for _, a := range option.Assignments {
+		assigned[a.UserID] = true
+	}
+
+	var active []*domain.LobbyPlayer
+	for _, p := range players {
+		if assigned[p.UserID] {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// loadPlayerData loads the role profiles of lobby players, with defaults for roles they have no profile for
+func (s *MatchmakingService) loadPlayerData(ctx context.Context, players []*domain.LobbyPlayer) ([]*PlayerData, error) {
 	userIDs := make([]uuid.UUID, len(players))
 	displayNames := make(map[uuid.UUID]string)
 	for i, p := range players {
@@ -219,7 +417,6 @@ func (s *MatchmakingService) GenerateMoreMatchOptions(ctx context.Context, lobby
 		return nil, err
 	}
 
-	// Build player data
 	playerData := make([]*PlayerData, len(players))
 	for i, p := range players {
 		pd := &PlayerData{
@@ -242,64 +439,53 @@ func (s *MatchmakingService) GenerateMoreMatchOptions(ctx context.Context, lobby
 
 		playerData[i] = pd
 	}
+	return playerData, nil
+}
 
-	// Generate ALL possible options with the increased threshold
-	// There are 252 possible team splits, so we request all of them
-	options := s.generateBestOptionsWithMinThreshold(playerData, 252, newThreshold)
-
-	// Filter out duplicates - use a fresh set to track within this batch too
-	seenInBatch := make(map[string]bool)
-	var newOptions []*GeneratedOption
-	for _, opt := range options {
-		hash := computeAssignmentHash(opt.Assignments)
-		// Skip if already exists in DB or already added in this batch
-		if existingHashes[hash] || seenInBatch[hash] {
-			continue
-		}
-		seenInBatch[hash] = true
-		newOptions = append(newOptions, opt)
-		if len(newOptions) >= count {
-			break
-		}
+// newMatchOption builds the match option to save for a generated option
+func newMatchOption(lobbyID uuid.UUID, optionNumber int, opt *GeneratedOption, teamSize int) (*domain.MatchOption, error) {
+	matchOption := &domain.MatchOption{
+		ID:               uuid.New(),
+		LobbyID:          lobbyID,
+		OptionNumber:     optionNumber,
+		AlgorithmType:    opt.AlgorithmType,
+		BlueTeamAvgMMR:   opt.BlueTeamMMR / teamSize,
+		RedTeamAvgMMR:    opt.RedTeamMMR / teamSize,
+		MMRDifference:    opt.MMRDifference,
+		BalanceScore:     opt.BalanceScore,
+		AvgBlueComfort:   opt.AvgBlueComfort,
+		AvgRedComfort:    opt.AvgRedComfort,
+		MaxLaneDiff:      opt.MaxLaneDiff,
+		UsedMmrThreshold: opt.UsedMmrThreshold,
+		CreatedAt:        time.Now(),
+		Assignments:      make([]domain.MatchOptionAssignment, len(opt.Assignments)),
+	}
+	if err := matchOption.SetPreferenceResults(opt.SatisfiedPreferences, opt.UnsatisfiedPreferences); err != nil {
+		return nil, err
 	}
 
-	// Save new options with incremented option numbers
-	for i, opt := range newOptions {
-		matchOption := &domain.MatchOption{
-			ID:               uuid.New(),
-			LobbyID:          lobbyID,
-			OptionNumber:     maxOptionNum + i + 1,
-			AlgorithmType:    opt.AlgorithmType,
-			BlueTeamAvgMMR:   opt.BlueTeamMMR / 5,
-			RedTeamAvgMMR:    opt.RedTeamMMR / 5,
-			MMRDifference:    opt.MMRDifference,
-			BalanceScore:     opt.BalanceScore,
-			AvgBlueComfort:   opt.AvgBlueComfort,
-			AvgRedComfort:    opt.AvgRedComfort,
-			MaxLaneDiff:      opt.MaxLaneDiff,
-			UsedMmrThreshold: opt.UsedMmrThreshold,
-			CreatedAt:        time.Now(),
-			Assignments:      make([]domain.MatchOptionAssignment, len(opt.Assignments)),
-		}
-
-		for j, a := range opt.Assignments {
-			matchOption.Assignments[j] = domain.MatchOptionAssignment{
-				ID:            uuid.New(),
-				MatchOptionID: matchOption.ID,
-				UserID:        a.UserID,
-				Team:          a.Team,
-				AssignedRole:  a.Role,
-				RoleMMR:       a.RoleMMR,
-				ComfortRating: a.ComfortRating,
-			}
-		}
-
-		if err := s.matchOptionRepo.Create(ctx, matchOption); err != nil {
-			return nil, err
+	for j, a := range opt.Assignments {
+		matchOption.Assignments[j] = domain.MatchOptionAssignment{
+			ID:            uuid.New(),
+			MatchOptionID: matchOption.ID,
+			UserID:        a.UserID,
+			Team:          a.Team,
+			AssignedRole:  a.Role,
+			RoleMMR:       a.RoleMMR,
+			ComfortRating: a.ComfortRating,
 		}
 	}
+	return matchOption, nil
+}
 
-	return s.matchOptionRepo.GetByLobbyID(ctx, lobbyID)
+// loadPreferences resolves the lobby's player preferences against the players being matched
+func (s *MatchmakingService) loadPreferences(ctx context.Context, lobbyID uuid.UUID, players []*PlayerData, config *generationConfig) error {
+	prefs, err := s.preferenceRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		return err
+	}
+	config.prefs = compilePreferences(prefs, players, config.roles)
+	return nil
 }
 
 // buildOptionHash creates a hash from a domain.MatchOption for deduplication
@@ -315,53 +501,52 @@ func (s *MatchmakingService) buildOptionHash(opt *domain.MatchOption) string {
 	return computeAssignmentHash(assignments)
 }
 
-// generateBestOptions generates the best team compositions using comfort-first algorithm
+// generateBestOptions generates the best team compositions for each balancer
 // with progressive MMR threshold expansion and randomization for variety
-func (s *MatchmakingService) generateBestOptions(players []*PlayerData, count int) []*GeneratedOption {
-	return s.generateBestOptionsWithMinThreshold(players, count, InitialMmrThreshold)
+func (s *MatchmakingService) generateBestOptions(players []*PlayerData, count int, config *generationConfig) []*GeneratedOption {
+	return s.generateBestOptionsWithMinThreshold(players, count, InitialMmrThreshold, config)
 }
 
-// generateBestOptionsWithMinThreshold generates options starting from a minimum threshold
-func (s *MatchmakingService) generateBestOptionsWithMinThreshold(players []*PlayerData, count int, minThreshold int) []*GeneratedOption {
+// generateBestOptionsWithMinThreshold generates options starting from a minimum threshold.
+// With several balancers, their best options are interleaved so each algorithm is represented.
+func (s *MatchmakingService) generateBestOptionsWithMinThreshold(players []*PlayerData, count int, minThreshold int, config *generationConfig) []*GeneratedOption {
 	// Seed random for variety on each call
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	// Generate team splits once (C(10,5) = 252 combinations)
-	teamSplits := generateTeamSplits(len(players))
-
-	// Pre-compute all base options with comfort-optimized role assignments
-	baseOptions := s.computeAllBaseOptions(players, teamSplits)
+	// Generate team splits once (C(10,5) = 252 combinations for 5v5)
+	teamSplits := generateTeamSplits(len(players), len(config.roles))
 
-	// Score all options by comfort (lower penalty = better)
-	for _, opt := range baseOptions {
-		opt.BalanceScore = s.scoreByComfort(opt)
-		opt.AlgorithmType = domain.AlgorithmComfortFirst
-	}
+	// Pre-compute every split's best role assignment for each balancer
+	optionsByBalancer := s.computeAllBaseOptions(players, teamSplits, config)
 
-	// Shuffle first to randomize tie-breaking among options with identical scores
-	rng.Shuffle(len(baseOptions), func(i, j int) {
-		baseOptions[i], baseOptions[j] = baseOptions[j], baseOptions[i]
-	})
+	for _, baseOptions := range optionsByBalancer {
+		// Shuffle first to randomize tie-breaking among options with identical scores
+		rng.Shuffle(len(baseOptions), func(i, j int) {
+			baseOptions[i], baseOptions[j] = baseOptions[j], baseOptions[i]
+		})
 
-	// Sort by comfort score (descending - higher score is better)
-	// Use stable sort to preserve random order among equal scores
-	sort.SliceStable(baseOptions, func(i, j int) bool {
-		return baseOptions[i].BalanceScore > baseOptions[j].BalanceScore
-	})
+		// Sort by score (descending - higher score is better)
+		// Use stable sort to preserve random order among equal scores
+		sort.SliceStable(baseOptions, func(i, j int) bool {
+			return baseOptions[i].BalanceScore > baseOptions[j].BalanceScore
+		})
+	}
 
 	// Try progressive MMR thresholds starting from minThreshold
 	for threshold := minThreshold; threshold <= MaxMmrThreshold; threshold += MmrThresholdIncrement {
-		var filtered []*GeneratedOption
-		for _, opt := range baseOptions {
-			if opt.MMRDifference <= threshold {
-				optCopy := *opt
-				optCopy.UsedMmrThreshold = threshold
-				filtered = append(filtered, &optCopy)
+		filteredByBalancer := make([][]*GeneratedOption, len(optionsByBalancer))
+		for i, baseOptions := range optionsByBalancer {
+			for _, opt := range baseOptions {
+				if opt.MMRDifference <= threshold {
+					optCopy := *opt
+					optCopy.UsedMmrThreshold = threshold
+					filteredByBalancer[i] = append(filteredByBalancer[i], &optCopy)
+				}
 			}
 		}
 
 		// Deduplicate
-		filtered = deduplicateOptions(filtered)
+		filtered := deduplicateOptions(interleaveOptions(filteredByBalancer))
 
 		if len(filtered) >= 1 {
 			// Return top N options
@@ -373,7 +558,7 @@ func (s *MatchmakingService) generateBestOptionsWithMinThreshold(players []*Play
 	}
 
 	// No threshold worked - return best available (best effort)
-	deduped := deduplicateOptions(baseOptions)
+	deduped := deduplicateOptions(interleaveOptions(optionsByBalancer))
 	for _, opt := range deduped {
 		opt.UsedMmrThreshold = -1 // Indicates best effort, no threshold met
 	}
@@ -383,45 +568,61 @@ func (s *MatchmakingService) generateBestOptionsWithMinThreshold(players []*Play
 	return deduped
 }
 
-// scoreByComfort scores an option based purely on comfort, with tiny MMR tiebreaker
-func (s *MatchmakingService) scoreByComfort(opt *GeneratedOption) float64 {
-	var totalComfortPenalty float64
-	for _, a := range opt.Assignments {
-		totalComfortPenalty += comfortPenalty(a.ComfortRating)
+// interleaveOptions merges ranked option lists by taking the next best option from each in turn
+func interleaveOptions(lists [][]*GeneratedOption) []*GeneratedOption {
+	var merged []*GeneratedOption
+	for i := 0; ; i++ {
+		added := false
+		for _, list := range lists {
+			if i < len(list) {
+				merged = append(merged, list[i])
+				added = true
+			}
+		}
+		if !added {
+			return merged
+		}
 	}
-
-	// Higher score is better
-	// Start at 100, subtract comfort penalty
-	// Add tiny MMR tiebreaker (smaller diff = slightly higher score)
-	score := 100.0
-	score -= totalComfortPenalty * 1.0               // Primary: comfort
-	score -= float64(opt.MMRDifference) / 10000.0    // Tiny tiebreaker for identical comfort
-	return max(0, score)
 }
 
-// computeAllBaseOptions computes all possible team compositions with their base stats
-func (s *MatchmakingService) computeAllBaseOptions(players []*PlayerData, teamSplits [][]bool) []*GeneratedOption {
-	var allOptions []*GeneratedOption
+// computeAllBaseOptions computes all possible team compositions with their base stats,
+// indexed like the config's balancers. Splits that break a hard preference are skipped.
+func (s *MatchmakingService) computeAllBaseOptions(players []*PlayerData, teamSplits [][]bool, config *generationConfig) [][]*GeneratedOption {
+	allOptions := make([][]*GeneratedOption, len(config.balancers))
 
 	for _, split := range teamSplits {
-		blueTeam := make([]*PlayerData, 5)
-		redTeam := make([]*PlayerData, 5)
+		ok, splitViolations := config.prefs.checkSplit(split)
+		if !ok {
+			continue
+		}
+
+		teamSize := len(config.roles)
+		blueTeam := make([]*PlayerData, teamSize)
+		redTeam := make([]*PlayerData, teamSize)
+		bluePlayers := make([]int, teamSize)
+		redPlayers := make([]int, teamSize)
 
 		blueIdx, redIdx := 0, 0
 		for i, inBlue := range split {
 			if inBlue {
 				blueTeam[blueIdx] = players[i]
+				bluePlayers[blueIdx] = i
 				blueIdx++
 			} else {
 				redTeam[redIdx] = players[i]
+				redPlayers[redIdx] = i
 				redIdx++
 			}
 		}
 
-		// Find optimal role assignment for this team split
-		option := s.findBestRoleAssignment(blueTeam, redTeam)
-		if option != nil {
-			allOptions = append(allOptions, option)
+		// Find each balancer's optimal role assignment for this team split
+		blueRules := config.prefs.roleRulesFor(bluePlayers)
+		redRules := config.prefs.roleRulesFor(redPlayers)
+		for i, option := range s.findBestRoleAssignment(blueTeam, redTeam, config, blueRules, redRules, splitViolations) {
+			if option != nil {
+				option.SatisfiedPreferences, option.UnsatisfiedPreferences = config.prefs.results(option.Assignments)
+				allOptions[i] = append(allOptions[i], option)
+			}
 		}
 	}
 
@@ -461,105 +662,154 @@ func computeAssignmentHash(assignments []TeamAssignment) string {
 	return strings.Join(parts, "|")
 }
 
-// findBestRoleAssignment finds the optimal role assignment for two teams
-// Tries all 120 × 120 = 14,400 permutations for both teams
-func (s *MatchmakingService) findBestRoleAssignment(blueTeam, redTeam []*PlayerData) *GeneratedOption {
-	roles := domain.AllRoles
+// findBestRoleAssignment finds the optimal role assignment for two teams under each balancer
+// Tries all permutations of the format's roles for both teams (120 × 120 = 14,400 for 5v5)
+// Role MMR is the earned rating once a role is past its provisional games (see UserRoleProfile.EffectiveMMR)
+// Assignments that break a hard role preference are skipped, and each broken soft preference
+// (including the split's splitViolations) costs SoftPreferencePenalty
+func (s *MatchmakingService) findBestRoleAssignment(blueTeam, redTeam []*PlayerData, config *generationConfig, blueRules, redRules roleRules, splitViolations int) []*GeneratedOption {
+	roles := config.roles
+	teamSize := len(roles)
+	balancers := config.balancers
+
+	// Look up each player's MMR and comfort penalty per role once
+	var blueMMRs, redMMRs [domain.MaxTeamSize][domain.MaxTeamSize]int
+	var bluePens, redPens [domain.MaxTeamSize][domain.MaxTeamSize]float64
+	for i := range teamSize {
+		for r, role := range roles {
+			blueProfile := blueTeam[i].RoleProfiles[role]
+			redProfile := redTeam[i].RoleProfiles[role]
+			blueMMRs[i][r] = blueProfile.EffectiveMMR()
+			redMMRs[i][r] = redProfile.EffectiveMMR()
+			bluePens[i][r] = comfortPenalty(blueProfile.ComfortRating)
+			redPens[i][r] = comfortPenalty(redProfile.ComfortRating)
+		}
+	}
 
-	var bestOption *GeneratedOption
-	bestScore := float64(-1000)
+	type candidate struct {
+		bluePerm, redPerm []int
+		metrics           CompositionMetrics
+		score             float64
+	}
+	best := make([]*candidate, len(balancers))
 
-	// Generate all permutations of roles (5! = 120)
-	permutations := generatePermutations(5)
+	// Generate all permutations of roles (5! = 120 for 5v5)
+	permutations := generatePermutations(teamSize)
 
-	// Try all permutations for BOTH teams (120 × 120 = 14,400)
+	// Try all permutations for BOTH teams
 	for _, bluePerm := range permutations {
 		for _, redPerm := range permutations {
-			// Calculate team MMRs and comfort
+			// Calculate team MMRs, comfort, lane MMRs by role and broken preferences
 			blueMMR, redMMR := 0, 0
-			var blueComfortPen, redComfortPen float64
-
-			blueAssignments := make([]TeamAssignment, 5)
-			redAssignments := make([]TeamAssignment, 5)
-
-			for i := range 5 {
-				blueRole := roles[bluePerm[i]]
-				redRole := roles[redPerm[i]] // Now permuted!
-
-				blueProfile := blueTeam[i].RoleProfiles[blueRole]
-				redProfile := redTeam[i].RoleProfiles[redRole]
+			var comfortPen float64
+			var blueLanes, redLanes [domain.MaxTeamSize]int
+			violations := splitViolations
+			blocked := false
+
+			for i := range teamSize {
+				if blueRules.blocked[i][bluePerm[i]] || redRules.blocked[i][redPerm[i]] {
+					blocked = true
+					break
+				}
+				violations += blueRules.violations[i][bluePerm[i]] + redRules.violations[i][redPerm[i]]
 
-				blueMMR += blueProfile.MMR
-				redMMR += redProfile.MMR
+				blueMMR += blueMMRs[i][bluePerm[i]]
+				redMMR += redMMRs[i][redPerm[i]]
+				comfortPen += bluePens[i][bluePerm[i]] + redPens[i][redPerm[i]]
+				blueLanes[bluePerm[i]] = blueMMRs[i][bluePerm[i]]
+				redLanes[redPerm[i]] = redMMRs[i][redPerm[i]]
+			}
+			if blocked {
+				continue
+			}
 
-				blueComfortPen += comfortPenalty(blueProfile.ComfortRating)
-				redComfortPen += comfortPenalty(redProfile.ComfortRating)
+			maxLaneDiff := 0
+			for r := range teamSize {
+				maxLaneDiff = max(maxLaneDiff, abs(blueLanes[r]-redLanes[r]))
+			}
 
-				blueAssignments[i] = TeamAssignment{
-					UserID:        blueTeam[i].UserID,
-					Team:          domain.SideBlue,
-					Role:          blueRole,
-					RoleMMR:       blueProfile.MMR,
-					ComfortRating: blueProfile.ComfortRating,
-				}
+			metrics := CompositionMetrics{
+				MMRDifference:  abs(blueMMR - redMMR),
+				ComfortPenalty: comfortPen,
+				MaxLaneDiff:    maxLaneDiff,
+			}
 
-				redAssignments[i] = TeamAssignment{
-					UserID:        redTeam[i].UserID,
-					Team:          domain.SideRed,
-					Role:          redRole,
-					RoleMMR:       redProfile.MMR,
-					ComfortRating: redProfile.ComfortRating,
+			for b, balancer := range balancers {
+				score := balancer.Score(metrics, config.weights) - SoftPreferencePenalty*float64(violations)
+				if best[b] == nil || score > best[b].score {
+					best[b] = &candidate{bluePerm: bluePerm, redPerm: redPerm, metrics: metrics, score: score}
 				}
 			}
+		}
+	}
 
-			mmrDiff := abs(blueMMR - redMMR)
-			totalPenalty := blueComfortPen + redComfortPen
-
-			// Comfort-first scoring: prioritize minimizing comfort penalty
-			// Use tiny MMR tiebreaker for identical comfort scores
-			score := 100.0
-			score -= totalPenalty * 1.0              // Primary: comfort penalty
-			score -= float64(mmrDiff) / 10000.0      // Tiny tiebreaker for identical comfort
-
-			if score > bestScore {
-				bestScore = score
-
-				allAssignments := make([]TeamAssignment, 10)
-				copy(allAssignments[:5], blueAssignments)
-				copy(allAssignments[5:], redAssignments)
-
-				// Calculate average comfort (convert from penalty back to rating)
-				// Max penalty per player is 15, so max per team is 75
-				avgBlueComfort := 5.0 - (blueComfortPen / 5.0)
-				avgRedComfort := 5.0 - (redComfortPen / 5.0)
-
-				bestOption = &GeneratedOption{
-					Assignments:         allAssignments,
-					BlueTeamMMR:         blueMMR,
-					RedTeamMMR:          redMMR,
-					MMRDifference:       mmrDiff,
-					BalanceScore:        math.Max(0, score),
-					AvgBlueComfort:      math.Max(1, math.Min(5, avgBlueComfort)),
-					AvgRedComfort:       math.Max(1, math.Min(5, avgRedComfort)),
-					TotalComfortPenalty: int(totalPenalty),
-				}
+	options := make([]*GeneratedOption, len(balancers))
+	for b, c := range best {
+		if c == nil {
+			continue
+		}
+
+		allAssignments := make([]TeamAssignment, 2*teamSize)
+		blueMMR, redMMR := 0, 0
+		var blueComfortPen, redComfortPen float64
+		for i := range teamSize {
+			blueRole, redRole := c.bluePerm[i], c.redPerm[i]
+			blueProfile := blueTeam[i].RoleProfiles[roles[blueRole]]
+			redProfile := redTeam[i].RoleProfiles[roles[redRole]]
+
+			blueMMR += blueMMRs[i][blueRole]
+			redMMR += redMMRs[i][redRole]
+			blueComfortPen += bluePens[i][blueRole]
+			redComfortPen += redPens[i][redRole]
+
+			allAssignments[i] = TeamAssignment{
+				UserID:        blueTeam[i].UserID,
+				Team:          domain.SideBlue,
+				Role:          roles[blueRole],
+				RoleMMR:       blueMMRs[i][blueRole],
+				ComfortRating: blueProfile.ComfortRating,
 			}
+			allAssignments[teamSize+i] = TeamAssignment{
+				UserID:        redTeam[i].UserID,
+				Team:          domain.SideRed,
+				Role:          roles[redRole],
+				RoleMMR:       redMMRs[i][redRole],
+				ComfortRating: redProfile.ComfortRating,
+			}
+		}
+
+		// Calculate average comfort (convert from penalty back to rating)
+		// Max penalty per player is 15, so max per 5v5 team is 75
+		avgBlueComfort := 5.0 - (blueComfortPen / float64(teamSize))
+		avgRedComfort := 5.0 - (redComfortPen / float64(teamSize))
+
+		options[b] = &GeneratedOption{
+			Assignments:         allAssignments,
+			BlueTeamMMR:         blueMMR,
+			RedTeamMMR:          redMMR,
+			MMRDifference:       c.metrics.MMRDifference,
+			BalanceScore:        math.Max(0, math.Min(100, c.score)),
+			AvgBlueComfort:      math.Max(1, math.Min(5, avgBlueComfort)),
+			AvgRedComfort:       math.Max(1, math.Min(5, avgRedComfort)),
+			TotalComfortPenalty: int(c.metrics.ComfortPenalty),
+			MaxLaneDiff:         c.metrics.MaxLaneDiff,
+			AlgorithmType:       balancers[b].Algorithm(),
 		}
 	}
 
-	return bestOption
+	return options
 }
 
-// generateTeamSplits generates all C(10,5) team split combinations
-func generateTeamSplits(n int) [][]bool {
-	// We need exactly 5 true values (blue team) in a slice of 10
+// generateTeamSplits generates all C(n,teamSize) team split combinations, C(10,5) for 5v5
+func generateTeamSplits(n, teamSize int) [][]bool {
+	// We need exactly teamSize true values (blue team) in a slice of n
 	var results [][]bool
 	var generate func(pos, trueCount int, current []bool)
 
 	generate = func(pos, trueCount int, current []bool) {
 		remaining := n - pos
-		neededTrue := 5 - trueCount
-		neededFalse := 5 - (pos - trueCount)
+		neededTrue := teamSize - trueCount
+		neededFalse := teamSize - (pos - trueCount)
 
 		// Pruning: can't complete if not enough positions left
 		if neededTrue > remaining || neededFalse > remaining {
@@ -567,7 +817,7 @@ func generateTeamSplits(n int) [][]bool {
 		}
 
 		if pos == n {
-			if trueCount == 5 {
+			if trueCount == teamSize {
 				result := make([]bool, n)
 				copy(result, current)
 				results = append(results, result)
@@ -576,13 +826,13 @@ func generateTeamSplits(n int) [][]bool {
 		}
 
 		// Try adding true (blue team)
-		if trueCount < 5 {
+		if trueCount < teamSize {
 			current[pos] = true
 			generate(pos+1, trueCount+1, current)
 		}
 
 		// Try adding false (red team)
-		if pos-trueCount < 5 {
+		if pos-trueCount < teamSize {
 			current[pos] = false
 			generate(pos+1, trueCount, current)
 		}
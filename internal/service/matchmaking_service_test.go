@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/dom/league-draft-website/internal/repository/postgres"
@@ -75,7 +76,7 @@ func TestMatchmakingService_IdenticalProfiles(t *testing.T) {
 	// on similar/same team compositions, resulting in few unique options
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	// Create lobby
@@ -120,7 +121,7 @@ func TestMatchmakingService_DiverseMMR(t *testing.T) {
 	// Wide MMR spread should create more varied options
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -164,7 +165,7 @@ func TestMatchmakingService_RoleSpecialists(t *testing.T) {
 	// Players who specialize in specific roles (high comfort in one role)
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -235,7 +236,7 @@ func TestMatchmakingService_MixedMMRAndComfort(t *testing.T) {
 	// Realistic scenario: varied MMR AND varied role comfort
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -313,7 +314,7 @@ func TestMatchmakingService_ExtremeMMRGap(t *testing.T) {
 	// Test with extreme MMR gaps (Challenger + Iron players)
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -363,7 +364,7 @@ func TestMatchmakingService_AllFillPlayers(t *testing.T) {
 	// All players are "fill" - equal comfort across all roles
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -403,7 +404,7 @@ func TestMatchmakingService_TwoOTPsPerRole(t *testing.T) {
 	// 2 one-trick-ponies per role - perfect setup for balanced teams
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -494,7 +495,7 @@ func TestMatchmakingService_LaneBalancing(t *testing.T) {
 	// Test lane-balanced algorithm with intentional lane mismatches
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -576,7 +577,7 @@ func TestMatchmakingService_MinimumOptionsReturned(t *testing.T) {
 	// Verify that even with deduplication, we get useful options
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -656,7 +657,7 @@ func TestMatchmakingService_AlgorithmDiversity(t *testing.T) {
 	// Verify that different algorithms are represented in output
 	testDB := testutil.NewTestDB(t)
 	repos := postgres.NewRepositories(testDB.DB)
-	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
 	ctx := context.Background()
 
 	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
@@ -704,3 +705,130 @@ func TestMatchmakingService_AlgorithmDiversity(t *testing.T) {
 	// We should see at least 2 different algorithms represented
 	assert.GreaterOrEqual(t, len(algorithmCounts), 1, "Should have at least 1 algorithm represented")
 }
+
+func TestMatchmakingService_SmallFormats(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "DUO001",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+		TeamSize:  2,
+	}
+	require.NoError(t, testDB.DB.Create(lobby).Error)
+
+	var players []*domain.LobbyPlayer
+	for i, mmr := range []int{2400, 2000, 1600, 1200} {
+		user := createUserUniform(t, testDB.DB, "Duo"+string(rune('A'+i)), mmr, 3)
+		players = append(players, createLobbyPlayer(t, testDB.DB, lobby.ID, user, true))
+	}
+
+	// 2v2 is bot lane only
+	options, err := svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	require.NoError(t, err)
+	require.NotEmpty(t, options)
+	for _, opt := range options {
+		require.Len(t, opt.Assignments, 4)
+		for _, a := range opt.Assignments {
+			assert.Contains(t, []domain.Role{domain.RoleADC, domain.RoleSupport}, a.AssignedRole)
+		}
+		assert.Len(t, opt.GetBlueTeam(), 2)
+	}
+
+	// Three players are not enough for 2v2
+	_, err = svc.GenerateMatchOptions(ctx, lobby.ID, players[:3], 8)
+	assert.ErrorIs(t, err, service.ErrNotEnoughPlayers)
+}
+
+func TestMatchmakingService_SitOutsByRecentParticipation(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
+
+	var users []*domain.User
+	for i := range 12 {
+		users = append(users, createUserUniform(t, testDB.DB, "Tuesday"+string(rune('A'+i)), 1500+i*50, 3))
+	}
+
+	// Last week the first two players sat out and the others played
+	startedAt := time.Now().Add(-7 * 24 * time.Hour)
+	lastWeek := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "TUES01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusCompleted,
+		StartedAt: &startedAt,
+	}
+	require.NoError(t, testDB.DB.Create(lastWeek).Error)
+	for i, user := range users {
+		lp := createLobbyPlayer(t, testDB.DB, lastWeek.ID, user, true)
+		if i >= 2 {
+			side := domain.SideBlue
+			lp.Team = &side
+			require.NoError(t, testDB.DB.Save(lp).Error)
+		}
+	}
+
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "TUES02",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+	}
+	require.NoError(t, testDB.DB.Create(lobby).Error)
+
+	var players []*domain.LobbyPlayer
+	for _, user := range users {
+		players = append(players, createLobbyPlayer(t, testDB.DB, lobby.ID, user, true))
+	}
+
+	options, err := svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	require.NoError(t, err)
+	require.NotEmpty(t, options)
+
+	// Every option uses the same ten players, including last week's sit-outs
+	playing := make(map[uuid.UUID]bool)
+	for _, a := range options[0].Assignments {
+		playing[a.UserID] = true
+	}
+	assert.Len(t, playing, 10)
+	assert.True(t, playing[users[0].ID])
+	assert.True(t, playing[users[1].ID])
+	for _, opt := range options {
+		for _, a := range opt.Assignments {
+			assert.True(t, playing[a.UserID])
+		}
+	}
+
+	// Selecting an option benches the players who sit out
+	assignments := make(map[uuid.UUID]struct {
+		Team domain.Side
+		Role domain.Role
+	})
+	for _, a := range options[0].Assignments {
+		assignments[a.UserID] = struct {
+			Team domain.Side
+			Role domain.Role
+		}{Team: a.Team, Role: a.AssignedRole}
+	}
+	require.NoError(t, repos.LobbyPlayer.UpdateTeamAssignments(ctx, lobby.ID, assignments))
+
+	lobbyPlayers, err := repos.LobbyPlayer.GetByLobbyID(ctx, lobby.ID)
+	require.NoError(t, err)
+	sittingOut := 0
+	for _, lp := range lobbyPlayers {
+		if lp.Team == nil {
+			sittingOut++
+			assert.False(t, playing[lp.UserID])
+		}
+	}
+	assert.Equal(t, 2, sittingOut)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+)
+
+// RatingService updates each player's per-role rating from confirmed match results.
+// Every player in a team draft is rated against the opposing team as a single composite
+// opponent, using the role they were assigned in the room.
+type RatingService struct {
+	userRepo         repository.UserRepository
+	roomPlayerRepo   repository.RoomPlayerRepository
+	profileRepo      repository.UserRoleProfileRepository
+	ratingChangeRepo repository.RatingChangeRepository
+}
+
+func NewRatingService(
+	userRepo repository.UserRepository,
+	roomPlayerRepo repository.RoomPlayerRepository,
+	profileRepo repository.UserRoleProfileRepository,
+	ratingChangeRepo repository.RatingChangeRepository,
+) *RatingService {
+	return &RatingService{
+		userRepo:         userRepo,
+		roomPlayerRepo:   roomPlayerRepo,
+		profileRepo:      profileRepo,
+		ratingChangeRepo: ratingChangeRepo,
+	}
+}
+
+// ratedPlayer is a room player with the role profile their game is rated on
+type ratedPlayer struct {
+	player  *domain.RoomPlayer
+	profile *domain.UserRoleProfile
+	before  domain.Glicko2Rating
+}
+
+// ApplyMatchResult rates the players of a room whose result is confirmed.
+// Rooms without a confirmed result, 1v1 drafts and practice drafts against the bot are
+// not rated, and a room is only ever rated once, so this is safe to call again.
+func (s *RatingService) ApplyMatchResult(ctx context.Context, room *domain.Room) error {
+	if room.ResultStatus != domain.ResultStatusConfirmed || room.WinnerSide == nil {
+		return nil
+	}
+	if !room.IsTeamDraft || room.BotSide != nil {
+		return nil
+	}
+
+	existing, err := s.ratingChangeRepo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	players, err := s.roomPlayerRepo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+
+	// Deleted accounts are not rated, so their profiles stay deleted
+	var userIDs []uuid.UUID
+	var active []*domain.RoomPlayer
+	for _, p := range players {
+		user, err := s.userRepo.GetByID(ctx, p.UserID)
+		if err != nil {
+			return err
+		}
+		if user.IsDeleted() {
+			continue
+		}
+		userIDs = append(userIDs, p.UserID)
+		active = append(active, p)
+	}
+	profilesByUser, err := s.profileRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	teams := make(map[domain.Side][]*ratedPlayer)
+	for _, p := range active {
+		var profile *domain.UserRoleProfile
+		for _, candidate := range profilesByUser[p.UserID] {
+			if candidate.Role == p.AssignedRole {
+				profile = candidate
+				break
+			}
+		}
+		if profile == nil {
+			profile = domain.NewDefaultUserRoleProfile(p.UserID, p.AssignedRole)
+		}
+		teams[p.Team] = append(teams[p.Team], &ratedPlayer{
+			player:  p,
+			profile: profile,
+			before:  profile.Glicko2(),
+		})
+	}
+	if len(teams[domain.SideBlue]) == 0 || len(teams[domain.SideRed]) == 0 {
+		return nil
+	}
+
+	opponents := map[domain.Side]domain.Glicko2Rating{
+		domain.SideBlue: teamRating(teams[domain.SideRed]),
+		domain.SideRed:  teamRating(teams[domain.SideBlue]),
+	}
+
+	var profiles []*domain.UserRoleProfile
+	var changes []*domain.RatingChange
+	for side, team := range teams {
+		won := side == *room.WinnerSide
+		score := 0.0
+		if won {
+			score = 1
+		}
+
+		for _, rp := range team {
+			after := rp.before.Update(opponents[side], score)
+			rp.profile.ApplyRating(after)
+			profiles = append(profiles, rp.profile)
+
+			changes = append(changes, &domain.RatingChange{
+				ID:               uuid.New(),
+				UserID:           rp.player.UserID,
+				RoomID:           room.ID,
+				Role:             rp.player.AssignedRole,
+				Won:              won,
+				OpponentRating:   opponents[side].Rating,
+				RatingBefore:     rp.before.Rating,
+				RatingAfter:      after.Rating,
+				DeviationBefore:  rp.before.Deviation,
+				DeviationAfter:   after.Deviation,
+				VolatilityBefore: rp.before.Volatility,
+				VolatilityAfter:  after.Volatility,
+			})
+		}
+	}
+
+	return s.ratingChangeRepo.Record(ctx, profiles, changes)
+}
+
+// teamRating combines a team's ratings from before the game into one opponent
+func teamRating(team []*ratedPlayer) domain.Glicko2Rating {
+	ratings := make([]domain.Glicko2Rating, len(team))
+	for i, rp := range team {
+		ratings[i] = rp.before
+	}
+	return domain.CompositeRating(ratings)
+}
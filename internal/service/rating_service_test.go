@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingService_RatesConfirmedTeamResults(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	blueCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	blueMid, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	redCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	redMid, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	// The blue top laner claims Gold 4; everyone else has no profile yet
+	claimed := domain.NewDefaultUserRoleProfile(blueCaptain.ID, domain.RoleTop)
+	claimed.SetRankAndUpdateMMR(domain.RankGold4)
+	require.NoError(t, repos.UserRoleProfile.Create(ctx, claimed))
+
+	room := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(blueMid, domain.SideBlue, domain.RoleMid, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		WithTeamPlayer(redMid, domain.SideRed, domain.RoleMid, false).
+		Build(t, testDB.DB)
+	room.Status = domain.RoomStatusCompleted
+	require.NoError(t, repos.Room.Update(ctx, room))
+
+	// A pending result is not rated
+	_, err := resultService.ReportResult(ctx, room.ID, redCaptain.ID, domain.SideRed)
+	require.NoError(t, err)
+	changes, err := repos.RatingChange.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	confirmed, err := resultService.ConfirmResult(ctx, room.ID, blueCaptain.ID)
+	require.NoError(t, err)
+
+	changes, err = repos.RatingChange.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	byUser := make(map[uuid.UUID]*domain.RatingChange)
+	for _, c := range changes {
+		byUser[c.UserID] = c
+	}
+
+	// The first rated game is seeded from the rank-based MMR
+	top := byUser[blueCaptain.ID]
+	assert.Equal(t, domain.RoleTop, top.Role)
+	assert.False(t, top.Won)
+	assert.Equal(t, float64(domain.RankGold4.ToMMR()), top.RatingBefore)
+	assert.Less(t, top.RatingAfter, top.RatingBefore)
+	assert.Less(t, top.DeviationAfter, top.DeviationBefore)
+
+	mid := byUser[redMid.ID]
+	assert.Equal(t, domain.RoleMid, mid.Role)
+	assert.True(t, mid.Won)
+	assert.Greater(t, mid.RatingAfter, mid.RatingBefore)
+
+	// Profiles are created for roles that had none, and rank edits are kept
+	profile, err := repos.UserRoleProfile.GetByUserIDAndRole(ctx, redMid.ID, domain.RoleMid)
+	require.NoError(t, err)
+	assert.Equal(t, 1, profile.RatedGames)
+	assert.InDelta(t, mid.RatingAfter, profile.Rating, 0.0001)
+	assert.True(t, profile.IsProvisional())
+	assert.Equal(t, profile.MMR, profile.EffectiveMMR())
+
+	profile, err = repos.UserRoleProfile.GetByUserIDAndRole(ctx, blueCaptain.ID, domain.RoleTop)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RankGold4, profile.LeagueRank)
+	assert.Equal(t, 1, profile.RatedGames)
+
+	// A room is only rated once
+	require.NoError(t, ratingService.ApplyMatchResult(ctx, confirmed))
+	changes, err = repos.RatingChange.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Len(t, changes, 4)
+}
+
+func TestRatingService_SkipsDeletedUsers(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	ctx := context.Background()
+
+	blueCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	deleted, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	redCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	room := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(deleted, domain.SideBlue, domain.RoleMid, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		Build(t, testDB.DB)
+	require.NoError(t, repos.User.Anonymize(ctx, deleted.ID))
+
+	winner := domain.SideBlue
+	room.Status = domain.RoomStatusCompleted
+	room.ResultStatus = domain.ResultStatusConfirmed
+	room.WinnerSide = &winner
+	require.NoError(t, ratingService.ApplyMatchResult(ctx, room))
+
+	changes, err := repos.RatingChange.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	for _, c := range changes {
+		assert.NotEqual(t, deleted.ID, c.UserID)
+	}
+
+	// The deleted account's profiles are not recreated
+	profiles, err := repos.UserRoleProfile.GetByUserIDs(ctx, []uuid.UUID{deleted.ID})
+	require.NoError(t, err)
+	assert.Empty(t, profiles[deleted.ID])
+}
+
+func TestUserRoleProfile_EffectiveMMR(t *testing.T) {
+	profile := domain.NewDefaultUserRoleProfile(uuid.New(), domain.RoleSupport)
+	profile.SetRankAndUpdateMMR(domain.RankDiamond4)
+
+	// Provisional roles keep the rank-based MMR
+	for range domain.ProvisionalRatedGames - 1 {
+		profile.ApplyRating(profile.Glicko2().Update(domain.Glicko2Rating{
+			Rating:     1500,
+			Deviation:  100,
+			Volatility: domain.DefaultRatingVolatility,
+		}, 0))
+	}
+	assert.True(t, profile.IsProvisional())
+	assert.Equal(t, domain.RankDiamond4.ToMMR(), profile.EffectiveMMR())
+
+	profile.ApplyRating(profile.Glicko2().Update(domain.Glicko2Rating{
+		Rating:     1500,
+		Deviation:  100,
+		Volatility: domain.DefaultRatingVolatility,
+	}, 0))
+	assert.False(t, profile.IsProvisional())
+	assert.Less(t, profile.EffectiveMMR(), domain.RankDiamond4.ToMMR())
+}
@@ -35,20 +35,44 @@ type CreateRoomInput struct {
 	DraftMode     domain.DraftMode
 	TimerDuration int
 	SeriesID      *uuid.UUID
+	GameNumber    int
+	// DraftTemplateID selects the phase order; nil drafts with the default pro play order
+	DraftTemplateID *uuid.UUID
+	// ReserveTime is each side's chess-clock reserve in seconds; 0 disables it
+	ReserveTime int
+	// BotSide is drafted by the bot for practice; nil for two human sides
+	BotSide *domain.Side
+	// BotOnTimeout lets the bot draft timed-out turns instead of skipping or picking at random
+	BotOnTimeout bool
+	// TradePhase is how long team drafts trade champions after the last pick, in seconds; 0 disables it
+	TradePhase int
+	// SpectatorDelay is how far behind the draft spectators are shown, in seconds; 0 shows it live
+	SpectatorDelay int
 }
 
 func (s *RoomService) CreateRoom(ctx context.Context, input CreateRoomInput) (*domain.Room, error) {
 	shortCode := generateShortCode()
 
+	gameNumber := input.GameNumber
+	if gameNumber < 1 {
+		gameNumber = 1
+	}
+
 	room := &domain.Room{
-		ID:                   uuid.New(),
-		ShortCode:            shortCode,
-		CreatedBy:            input.CreatedBy,
-		DraftMode:            input.DraftMode,
-		TimerDurationSeconds: input.TimerDuration,
-		Status:               domain.RoomStatusWaiting,
-		SeriesID:             input.SeriesID,
-		GameNumber:           1,
+		ID:                    uuid.New(),
+		ShortCode:             shortCode,
+		CreatedBy:             input.CreatedBy,
+		DraftMode:             input.DraftMode,
+		TimerDurationSeconds:  input.TimerDuration,
+		ReserveTimeSeconds:    input.ReserveTime,
+		Status:                domain.RoomStatusWaiting,
+		SeriesID:              input.SeriesID,
+		GameNumber:            gameNumber,
+		DraftTemplateID:       input.DraftTemplateID,
+		BotSide:               input.BotSide,
+		BotOnTimeout:          input.BotOnTimeout,
+		TradePhaseSeconds:     input.TradePhase,
+		SpectatorDelaySeconds: input.SpectatorDelay,
 	}
 
 	if err := s.roomRepo.Create(ctx, room); err != nil {
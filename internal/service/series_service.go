@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSeriesNotFound       = errors.New("series not found")
+	ErrInvalidSeriesFormat  = errors.New("invalid series format")
+	ErrSeriesCompleted      = errors.New("series is already completed")
+	ErrGameNotFinished      = errors.New("current game draft is not finished")
+	ErrInvalidGameWinner    = errors.New("game winner must be blue or red")
+	ErrNotSeriesParticipant = errors.New("user is not a participant in this series")
+	ErrRoomAlreadyInSeries  = errors.New("room already belongs to a series")
+	ErrResultConflict       = errors.New("winner does not match the game's confirmed result")
+	ErrResultNotConfirmed   = errors.New("current game's result has not been confirmed by both sides")
+	ErrSeriesAlreadyMovedOn = errors.New("series has already moved past this game")
+)
+
+type SeriesService struct {
+	seriesRepo      repository.SeriesRepository
+	roomRepo        repository.RoomRepository
+	roomPlayerRepo  repository.RoomPlayerRepository
+	draftActionRepo repository.DraftActionRepository
+	fearlessBanRepo repository.FearlessBanRepository
+	roomService     *RoomService
+	ratingService   *RatingService
+}
+
+func NewSeriesService(
+	seriesRepo repository.SeriesRepository,
+	roomRepo repository.RoomRepository,
+	roomPlayerRepo repository.RoomPlayerRepository,
+	draftActionRepo repository.DraftActionRepository,
+	fearlessBanRepo repository.FearlessBanRepository,
+	roomService *RoomService,
+	ratingService *RatingService,
+) *SeriesService {
+	return &SeriesService{
+		seriesRepo:      seriesRepo,
+		roomRepo:        roomRepo,
+		roomPlayerRepo:  roomPlayerRepo,
+		draftActionRepo: draftActionRepo,
+		fearlessBanRepo: fearlessBanRepo,
+		roomService:     roomService,
+		ratingService:   ratingService,
+	}
+}
+
+type CreateSeriesInput struct {
+	CreatedBy     uuid.UUID
+	Format        domain.SeriesFormat
+	DraftMode     domain.DraftMode
+	TimerDuration int
+	// DraftTemplateID optionally selects the phase order every game is drafted with
+	DraftTemplateID *uuid.UUID
+	// ReserveTime is each side's chess-clock reserve in seconds for every game
+	ReserveTime int
+	// RoomID optionally turns an existing room (e.g. one started from a lobby) into game 1
+	RoomID *uuid.UUID
+}
+
+// CreateSeries creates a series and its first game
+func (s *SeriesService) CreateSeries(ctx context.Context, input CreateSeriesInput) (*domain.Series, *domain.Room, error) {
+	if !input.Format.IsValid() {
+		return nil, nil, ErrInvalidSeriesFormat
+	}
+
+	if input.RoomID != nil {
+		return s.createSeriesFromRoom(ctx, input)
+	}
+
+	series := &domain.Series{
+		ID:                   uuid.New(),
+		CreatedBy:            input.CreatedBy,
+		Format:               input.Format,
+		DraftMode:            input.DraftMode,
+		TimerDurationSeconds: input.TimerDuration,
+		ReserveTimeSeconds:   input.ReserveTime,
+		Status:               domain.SeriesStatusInProgress,
+		DraftTemplateID:      input.DraftTemplateID,
+		CurrentGame:          1,
+	}
+
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		return nil, nil, err
+	}
+
+	room, err := s.roomService.CreateRoom(ctx, CreateRoomInput{
+		CreatedBy:       input.CreatedBy,
+		DraftMode:       input.DraftMode,
+		TimerDuration:   input.TimerDuration,
+		SeriesID:        &series.ID,
+		GameNumber:      1,
+		DraftTemplateID: input.DraftTemplateID,
+		ReserveTime:     input.ReserveTime,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, room, nil
+}
+
+// createSeriesFromRoom wraps an existing room as game 1 of a new series
+func (s *SeriesService) createSeriesFromRoom(ctx context.Context, input CreateSeriesInput) (*domain.Series, *domain.Room, error) {
+	room, err := s.roomRepo.GetByID(ctx, *input.RoomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRoomNotFound
+		}
+		return nil, nil, err
+	}
+
+	if !s.isRoomParticipant(ctx, room, input.CreatedBy) {
+		return nil, nil, ErrNotSeriesParticipant
+	}
+
+	if room.SeriesID != nil {
+		return nil, nil, ErrRoomAlreadyInSeries
+	}
+
+	series := &domain.Series{
+		ID:                   uuid.New(),
+		CreatedBy:            input.CreatedBy,
+		Format:               input.Format,
+		DraftMode:            room.DraftMode,
+		TimerDurationSeconds: room.TimerDurationSeconds,
+		ReserveTimeSeconds:   room.ReserveTimeSeconds,
+		Status:               domain.SeriesStatusInProgress,
+		BlueSideUserID:       room.BlueSideUserID,
+		RedSideUserID:        room.RedSideUserID,
+		IsTeamDraft:          room.IsTeamDraft,
+		LobbyID:              room.LobbyID,
+		DraftTemplateID:      room.DraftTemplateID,
+		CurrentGame:          1,
+	}
+
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		return nil, nil, err
+	}
+
+	room.SeriesID = &series.ID
+	room.GameNumber = 1
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, nil, err
+	}
+
+	// A fearless room that already finished never saw its series, so its picks are
+	// recorded here. A live room is told about the series by the hub instead
+	if room.DraftMode == domain.DraftModeFearless && room.Status == domain.RoomStatusCompleted {
+		if err := s.backfillFearlessBans(ctx, room); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return series, room, nil
+}
+
+// backfillFearlessBans records the picks of a finished room as fearless bans of its series
+func (s *SeriesService) backfillFearlessBans(ctx context.Context, room *domain.Room) error {
+	actions, err := s.draftActionRepo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+
+	var bans []*domain.FearlessBan
+	for _, action := range actions {
+		if action.ActionType != domain.ActionTypePick || action.ChampionID == "" || action.ChampionID == "None" {
+			continue
+		}
+		bans = append(bans, &domain.FearlessBan{
+			ID:           uuid.New(),
+			SeriesID:     *room.SeriesID,
+			ChampionID:   action.ChampionID,
+			BannedInGame: room.GameNumber,
+			PickedByTeam: action.Team,
+		})
+	}
+	return s.fearlessBanRepo.CreateMany(ctx, bans)
+}
+
+// GetSeries returns a series and its games ordered by game number
+func (s *SeriesService) GetSeries(ctx context.Context, id uuid.UUID) (*domain.Series, []*domain.Room, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrSeriesNotFound
+		}
+		return nil, nil, err
+	}
+
+	games, err := s.roomRepo.GetBySeriesID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, games, nil
+}
+
+// StartNextGame records the winner of the current game and, unless the series is decided,
+// creates the next room with the same teams. The returned room is nil when the series is over.
+// The game's result must already be confirmed by both sides; a non-empty winner is only
+// checked against it.
+func (s *SeriesService) StartNextGame(ctx context.Context, seriesID, userID uuid.UUID, winner domain.Side) (*domain.Series, *domain.Room, error) {
+	series, games, err := s.GetSeries(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if series.IsComplete() {
+		return nil, nil, ErrSeriesCompleted
+	}
+
+	var current *domain.Room
+	for _, game := range games {
+		if game.GameNumber == series.CurrentGame {
+			current = game
+		}
+	}
+	if current == nil {
+		return nil, nil, ErrRoomNotFound
+	}
+
+	if !s.isRoomParticipant(ctx, current, userID) {
+		return nil, nil, ErrNotSeriesParticipant
+	}
+
+	if current.Status != domain.RoomStatusCompleted {
+		return nil, nil, ErrGameNotFinished
+	}
+
+	if winner != "" && winner != domain.SideBlue && winner != domain.SideRed {
+		return nil, nil, ErrInvalidGameWinner
+	}
+
+	// Results are settled by both sides through match result reporting, never by one participant here
+	if current.ResultStatus != domain.ResultStatusConfirmed || current.WinnerSide == nil {
+		return nil, nil, ErrResultNotConfirmed
+	}
+	if winner == "" {
+		winner = *current.WinnerSide
+	} else if winner != *current.WinnerSide {
+		return nil, nil, ErrResultConflict
+	}
+	// The players were rated when the result was confirmed; this only catches up on a rating that failed then
+	if err := s.ratingService.ApplyMatchResult(ctx, current); err != nil {
+		return nil, nil, err
+	}
+
+	// Claim the game before creating the next room, so concurrent requests can't both create one
+	previous := *series
+	series.RecordGameWinner(winner)
+	if !series.IsComplete() {
+		series.CurrentGame = current.GameNumber + 1
+	}
+	claimed, err := s.seriesRepo.UpdateFromGame(ctx, series, previous.CurrentGame)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !claimed {
+		return nil, nil, ErrSeriesAlreadyMovedOn
+	}
+	if series.IsComplete() {
+		return series, nil, nil
+	}
+
+	next, err := s.createNextRoom(ctx, series, current)
+	if err != nil {
+		// Hand the game back so the next request can create the room
+		if _, revertErr := s.seriesRepo.UpdateFromGame(ctx, &previous, series.CurrentGame); revertErr != nil {
+			return nil, nil, errors.Join(err, revertErr)
+		}
+		return nil, nil, err
+	}
+
+	return series, next, nil
+}
+
+// createNextRoom creates the room for the next game, copying sides and team players from the previous game
+func (s *SeriesService) createNextRoom(ctx context.Context, series *domain.Series, previous *domain.Room) (*domain.Room, error) {
+	room, err := s.roomService.CreateRoom(ctx, CreateRoomInput{
+		CreatedBy:       previous.CreatedBy,
+		DraftMode:       series.DraftMode,
+		TimerDuration:   series.TimerDurationSeconds,
+		SeriesID:        &series.ID,
+		GameNumber:      previous.GameNumber + 1,
+		DraftTemplateID: series.DraftTemplateID,
+		ReserveTime:     series.ReserveTimeSeconds,
+		TradePhase:      previous.TradePhaseSeconds,
+		SpectatorDelay:  previous.SpectatorDelaySeconds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	room.BlueSideUserID = previous.BlueSideUserID
+	room.RedSideUserID = previous.RedSideUserID
+	room.IsTeamDraft = previous.IsTeamDraft
+	room.LobbyID = previous.LobbyID
+
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+
+	if !previous.IsTeamDraft {
+		return room, nil
+	}
+
+	players, err := s.roomPlayerRepo.GetByRoomID(ctx, previous.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	roomPlayers := make([]*domain.RoomPlayer, 0, len(players))
+	for _, p := range players {
+		roomPlayers = append(roomPlayers, &domain.RoomPlayer{
+			ID:           uuid.New(),
+			RoomID:       room.ID,
+			UserID:       p.UserID,
+			Team:         p.Team,
+			AssignedRole: p.AssignedRole,
+			DisplayName:  p.DisplayName,
+			IsCaptain:    p.IsCaptain,
+		})
+	}
+
+	if len(roomPlayers) > 0 {
+		if err := s.roomPlayerRepo.CreateMany(ctx, roomPlayers); err != nil {
+			return nil, err
+		}
+	}
+
+	return room, nil
+}
+
+// isRoomParticipant checks if a user created, plays in, or captains a team in the room
+func (s *SeriesService) isRoomParticipant(ctx context.Context, room *domain.Room, userID uuid.UUID) bool {
+	if room.CreatedBy == userID {
+		return true
+	}
+	if room.BlueSideUserID != nil && *room.BlueSideUserID == userID {
+		return true
+	}
+	if room.RedSideUserID != nil && *room.RedSideUserID == userID {
+		return true
+	}
+	if room.IsTeamDraft {
+		player, err := s.roomPlayerRepo.GetByRoomAndUser(ctx, room.ID, userID)
+		if err == nil && player != nil && player.IsCaptain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesService_CreateSeries(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	roomService := service.NewRoomService(repos.Room, repos.DraftState)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	seriesService := service.NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService)
+	ctx := context.Background()
+
+	user, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	t.Run("creates series with game 1", func(t *testing.T) {
+		series, room, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+			CreatedBy:     user.ID,
+			Format:        domain.SeriesFormatBo3,
+			DraftMode:     domain.DraftModeFearless,
+			TimerDuration: 30,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.SeriesStatusInProgress, series.Status)
+		assert.Equal(t, 1, series.CurrentGame)
+		require.NotNil(t, room.SeriesID)
+		assert.Equal(t, series.ID, *room.SeriesID)
+		assert.Equal(t, 1, room.GameNumber)
+	})
+
+	t.Run("rejects invalid format", func(t *testing.T) {
+		_, _, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+			CreatedBy: user.ID,
+			Format:    domain.SeriesFormat("bo7"),
+		})
+		assert.ErrorIs(t, err, service.ErrInvalidSeriesFormat)
+	})
+
+	t.Run("wraps an existing room as game 1", func(t *testing.T) {
+		existing := testutil.NewRoomBuilder().WithCreator(user).Build(t, testDB.DB)
+
+		series, room, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+			CreatedBy: user.ID,
+			Format:    domain.SeriesFormatBo5,
+			RoomID:    &existing.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, room.ID)
+		assert.Equal(t, series.ID, *room.SeriesID)
+
+		_, _, err = seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+			CreatedBy: user.ID,
+			Format:    domain.SeriesFormatBo5,
+			RoomID:    &existing.ID,
+		})
+		assert.ErrorIs(t, err, service.ErrRoomAlreadyInSeries)
+	})
+
+	t.Run("wrapping a finished fearless room bans its picks", func(t *testing.T) {
+		existing := testutil.NewRoomBuilder().
+			WithCreator(user).
+			WithDraftMode(domain.DraftModeFearless).
+			Build(t, testDB.DB)
+		existing.Status = domain.RoomStatusCompleted
+		require.NoError(t, repos.Room.Update(ctx, existing))
+		for i, action := range []*domain.DraftAction{
+			{Team: domain.SideBlue, ActionType: domain.ActionTypeBan, ChampionID: "Zed"},
+			{Team: domain.SideBlue, ActionType: domain.ActionTypePick, ChampionID: "Ahri"},
+			{Team: domain.SideRed, ActionType: domain.ActionTypePick, ChampionID: "Jinx"},
+		} {
+			action.ID = uuid.New()
+			action.RoomID = existing.ID
+			action.PhaseIndex = i
+			require.NoError(t, repos.DraftAction.Create(ctx, action))
+		}
+
+		series, _, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+			CreatedBy: user.ID,
+			Format:    domain.SeriesFormatBo3,
+			RoomID:    &existing.ID,
+		})
+		require.NoError(t, err)
+
+		bans, err := repos.FearlessBan.GetBySeriesID(ctx, series.ID)
+		require.NoError(t, err)
+		picked := make(map[string]domain.Side)
+		for _, ban := range bans {
+			assert.Equal(t, 1, ban.BannedInGame)
+			picked[ban.ChampionID] = ban.PickedByTeam
+		}
+		assert.Equal(t, map[string]domain.Side{"Ahri": domain.SideBlue, "Jinx": domain.SideRed}, picked)
+	})
+}
+
+func TestSeriesService_StartNextGame(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	roomService := service.NewRoomService(repos.Room, repos.DraftState)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	seriesService := service.NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	opponent, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	series, game1, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+		CreatedBy:     creator.ID,
+		Format:        domain.SeriesFormatBo3,
+		DraftMode:     domain.DraftModeProPlay,
+		TimerDuration: 30,
+	})
+	require.NoError(t, err)
+
+	game1.BlueSideUserID = &creator.ID
+	game1.RedSideUserID = &opponent.ID
+	require.NoError(t, repos.Room.Update(ctx, game1))
+
+	completeRoom := func(t *testing.T, room *domain.Room) {
+		t.Helper()
+		room.Status = domain.RoomStatusCompleted
+		require.NoError(t, repos.Room.Update(ctx, room))
+	}
+	confirmWinner := func(t *testing.T, room *domain.Room, winner domain.Side) {
+		t.Helper()
+		_, err := resultService.ReportResult(ctx, room.ID, creator.ID, winner)
+		require.NoError(t, err)
+		_, err = resultService.ConfirmResult(ctx, room.ID, opponent.ID)
+		require.NoError(t, err)
+	}
+
+	// Game 1 draft is still running
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrGameNotFinished)
+
+	completeRoom(t, game1)
+
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.Side("purple"))
+	assert.ErrorIs(t, err, service.ErrInvalidGameWinner)
+
+	// One participant can't decide the winner on their own
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrResultNotConfirmed)
+
+	// Blue wins game 1
+	confirmWinner(t, game1, domain.SideBlue)
+	series, game2, err := seriesService.StartNextGame(ctx, series.ID, opponent.ID, domain.SideBlue)
+	require.NoError(t, err)
+	require.NotNil(t, game2)
+	assert.Equal(t, 1, series.BlueWins)
+	assert.Equal(t, 2, series.CurrentGame)
+	assert.Equal(t, 2, game2.GameNumber)
+	assert.Equal(t, series.ID, *game2.SeriesID)
+	assert.Equal(t, creator.ID, *game2.BlueSideUserID)
+	assert.Equal(t, opponent.ID, *game2.RedSideUserID)
+
+	completeRoom(t, game2)
+
+	// Blue wins game 2 and takes the series
+	confirmWinner(t, game2, domain.SideBlue)
+	series, game3, err := seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.SideBlue)
+	require.NoError(t, err)
+	assert.Nil(t, game3)
+	assert.Equal(t, domain.SeriesStatusCompleted, series.Status)
+	require.NotNil(t, series.WinnerSide)
+	assert.Equal(t, domain.SideBlue, *series.WinnerSide)
+
+	_, games, err := seriesService.GetSeries(ctx, series.ID)
+	require.NoError(t, err)
+	assert.Len(t, games, 2)
+
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.SideRed)
+	assert.ErrorIs(t, err, service.ErrSeriesCompleted)
+}
+
+func TestSeriesService_StartNextGameUsesConfirmedResult(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	roomService := service.NewRoomService(repos.Room, repos.DraftState)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	seriesService := service.NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	opponent, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	series, game1, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+		CreatedBy: creator.ID,
+		Format:    domain.SeriesFormatBo3,
+	})
+	require.NoError(t, err)
+
+	game1.BlueSideUserID = &creator.ID
+	game1.RedSideUserID = &opponent.ID
+	game1.Status = domain.RoomStatusCompleted
+	require.NoError(t, repos.Room.Update(ctx, game1))
+
+	_, err = resultService.ReportResult(ctx, game1.ID, creator.ID, domain.SideRed)
+	require.NoError(t, err)
+	_, err = resultService.ConfirmResult(ctx, game1.ID, opponent.ID)
+	require.NoError(t, err)
+
+	// A winner that contradicts the confirmed result is rejected
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, creator.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrResultConflict)
+
+	series, game2, err := seriesService.StartNextGame(ctx, series.ID, creator.ID, "")
+	require.NoError(t, err)
+	require.NotNil(t, game2)
+	assert.Equal(t, 1, series.RedWins)
+}
+
+func TestSeriesService_StartNextGameConcurrently(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	roomService := service.NewRoomService(repos.Room, repos.DraftState)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	seriesService := service.NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	opponent, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+
+	series, game1, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+		CreatedBy: creator.ID,
+		Format:    domain.SeriesFormatBo5,
+	})
+	require.NoError(t, err)
+
+	game1.BlueSideUserID = &creator.ID
+	game1.RedSideUserID = &opponent.ID
+	game1.Status = domain.RoomStatusCompleted
+	require.NoError(t, repos.Room.Update(ctx, game1))
+
+	_, err = resultService.ReportResult(ctx, game1.ID, creator.ID, domain.SideBlue)
+	require.NoError(t, err)
+	_, err = resultService.ConfirmResult(ctx, game1.ID, opponent.ID)
+	require.NoError(t, err)
+
+	// Both players press "next game" at once
+	errs := make(chan error, 2)
+	for _, user := range []*domain.User{creator, opponent} {
+		go func(userID uuid.UUID) {
+			_, _, err := seriesService.StartNextGame(ctx, series.ID, userID, "")
+			errs <- err
+		}(user.ID)
+	}
+
+	var succeeded int
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, service.ErrSeriesAlreadyMovedOn)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	series, games, err := seriesService.GetSeries(ctx, series.ID)
+	require.NoError(t, err)
+	assert.Len(t, games, 2)
+	assert.Equal(t, 2, series.CurrentGame)
+	assert.Equal(t, 1, series.BlueWins)
+}
+
+func TestSeriesService_StartNextGameDoesNotRateDisputedGame(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	roomService := service.NewRoomService(repos.Room, repos.DraftState)
+	ratingService := service.NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
+	seriesService := service.NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService)
+	resultService := service.NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService)
+	ctx := context.Background()
+
+	blueCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	redCaptain, _ := testutil.NewUserBuilder().Build(t, testDB.DB)
+	room := testutil.NewRoomBuilder().
+		WithCreator(blueCaptain).
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		Build(t, testDB.DB)
+
+	series, _, err := seriesService.CreateSeries(ctx, service.CreateSeriesInput{
+		CreatedBy: blueCaptain.ID,
+		Format:    domain.SeriesFormatBo3,
+		RoomID:    &room.ID,
+	})
+	require.NoError(t, err)
+
+	room.Status = domain.RoomStatusCompleted
+	require.NoError(t, testDB.DB.Model(room).Update("status", room.Status).Error)
+
+	_, err = resultService.ReportResult(ctx, room.ID, blueCaptain.ID, domain.SideBlue)
+	require.NoError(t, err)
+	disputed, err := resultService.ReportResult(ctx, room.ID, redCaptain.ID, domain.SideRed)
+	require.NoError(t, err)
+	require.Equal(t, domain.ResultStatusDisputed, disputed.ResultStatus)
+
+	// Neither captain can settle the disputed game by starting the next one
+	_, _, err = seriesService.StartNextGame(ctx, series.ID, blueCaptain.ID, domain.SideBlue)
+	assert.ErrorIs(t, err, service.ErrResultNotConfirmed)
+
+	changes, err := repos.RatingChange.GetByRoomID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	saved, err := repos.Room.GetByID(ctx, room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResultStatusDisputed, saved.ResultStatus)
+}
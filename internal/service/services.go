@@ -6,13 +6,19 @@ import (
 )
 
 type Services struct {
-	Auth        *AuthService
-	Room        *RoomService
-	Champion    *ChampionService
-	Draft       *DraftService
-	Profile     *ProfileService
-	Lobby       *LobbyService
-	Matchmaking *MatchmakingService
+	Auth          *AuthService
+	Room          *RoomService
+	Champion      *ChampionService
+	Draft         *DraftService
+	Profile       *ProfileService
+	Lobby         *LobbyService
+	Matchmaking   *MatchmakingService
+	Series        *SeriesService
+	DraftTemplate *DraftTemplateService
+	MatchResult   *MatchResultService
+	Rating        *RatingService
+	Admin         *AdminService
+	Ticket        *TicketService
 }
 
 func NewServices(repos *repository.Repositories, cfg *config.Config) *Services {
@@ -21,10 +27,13 @@ func NewServices(repos *repository.Repositories, cfg *config.Config) *Services {
 		repos.UserRoleProfile,
 		repos.MatchOption,
 		repos.Lobby,
+		repos.LobbyPreference,
+		repos.LobbyPlayer,
 	)
+	ratingService := NewRatingService(repos.User, repos.RoomPlayer, repos.UserRoleProfile, repos.RatingChange)
 
 	return &Services{
-		Auth:     NewAuthService(repos.User, repos.Session, cfg),
+		Auth:     NewAuthService(repos.User, repos.Session, repos.PasswordReset, cfg),
 		Room:     roomService,
 		Champion: NewChampionService(repos.Champion, cfg),
 		Draft:    NewDraftService(repos.DraftState, repos.DraftAction, repos.FearlessBan),
@@ -37,9 +46,18 @@ func NewServices(repos *repository.Repositories, cfg *config.Config) *Services {
 			repos.RoomPlayer,
 			repos.PendingAction,
 			repos.Vote,
+			repos.LobbyPreference,
+			repos.ChatMessage,
+			repos.Transactor,
 			roomService,
 			matchmakingService,
 		),
-		Matchmaking: matchmakingService,
+		Matchmaking:   matchmakingService,
+		Series:        NewSeriesService(repos.Series, repos.Room, repos.RoomPlayer, repos.DraftAction, repos.FearlessBan, roomService, ratingService),
+		DraftTemplate: NewDraftTemplateService(repos.DraftTemplate),
+		MatchResult:   NewMatchResultService(repos.Room, repos.RoomPlayer, ratingService),
+		Rating:        ratingService,
+		Admin:         NewAdminService(repos.User, repos.UserRoleProfile, repos.Lobby, repos.Room),
+		Ticket:        NewTicketService(),
 	}
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dom/league-draft-website/internal/domain"
+)
+
+// CompositionMetrics are the balance measures of one team composition
+type CompositionMetrics struct {
+	// MMRDifference is the gap between the two teams' total MMR
+	MMRDifference int
+	// ComfortPenalty is the summed comfortPenalty of all ten players
+	ComfortPenalty float64
+	// MaxLaneDiff is the largest MMR gap between two lane opponents
+	MaxLaneDiff int
+}
+
+// TeamBalancer scores team compositions for one matchmaking algorithm.
+// For every team split, matchmaking keeps the role assignment the balancer scores highest,
+// then offers the best scoring splits as match options.
+type TeamBalancer interface {
+	// Algorithm is the type recorded on the match options this balancer produces
+	Algorithm() domain.AlgorithmType
+	// Score rates a composition, higher is better. Match options store it clamped to 0-100.
+	// weights scale each measure relative to the balancer's own tuning.
+	Score(metrics CompositionMetrics, weights domain.BalanceWeights) float64
+}
+
+var (
+	balancersMu sync.RWMutex
+	balancers   = make(map[domain.AlgorithmType]TeamBalancer)
+)
+
+// DefaultMatchmakingAlgorithm produces match options for lobbies that have not chosen any
+const DefaultMatchmakingAlgorithm = domain.AlgorithmComfortFirst
+
+// RegisterBalancer makes a balancer available to lobbies under its algorithm type.
+// It panics if the algorithm is already registered.
+func RegisterBalancer(balancer TeamBalancer) {
+	balancersMu.Lock()
+	defer balancersMu.Unlock()
+
+	algorithm := balancer.Algorithm()
+	if _, exists := balancers[algorithm]; exists {
+		panic(fmt.Sprintf("matchmaking algorithm %q registered twice", algorithm))
+	}
+	balancers[algorithm] = balancer
+}
+
+// GetBalancer returns the balancer registered for an algorithm
+func GetBalancer(algorithm domain.AlgorithmType) (TeamBalancer, bool) {
+	balancersMu.RLock()
+	defer balancersMu.RUnlock()
+
+	balancer, ok := balancers[algorithm]
+	return balancer, ok
+}
+
+// RegisteredAlgorithms lists the algorithms lobbies can choose from
+func RegisteredAlgorithms() []domain.AlgorithmType {
+	balancersMu.RLock()
+	defer balancersMu.RUnlock()
+
+	algorithms := make([]domain.AlgorithmType, 0, len(balancers))
+	for algorithm := range balancers {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Slice(algorithms, func(i, j int) bool { return algorithms[i] < algorithms[j] })
+	return algorithms
+}
+
+// resolveBalancers returns the balancers for a lobby's settings, in the order chosen.
+// No algorithms means the default one.
+func resolveBalancers(settings domain.MatchmakingSettings) ([]TeamBalancer, error) {
+	algorithms := settings.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = []domain.AlgorithmType{DefaultMatchmakingAlgorithm}
+	}
+
+	seen := make(map[domain.AlgorithmType]bool)
+	var resolved []TeamBalancer
+	for _, algorithm := range algorithms {
+		if seen[algorithm] {
+			continue
+		}
+		seen[algorithm] = true
+
+		balancer, ok := GetBalancer(algorithm)
+		if !ok {
+			return nil, ErrUnknownMatchmakingAlgorithm
+		}
+		resolved = append(resolved, balancer)
+	}
+	return resolved, nil
+}
+
+// validateMatchmakingSettings checks that a lobby's algorithms are registered and its weights in range
+func validateMatchmakingSettings(settings domain.MatchmakingSettings) error {
+	if settings.Weights != nil && !settings.Weights.IsValid() {
+		return ErrInvalidBalanceWeights
+	}
+	_, err := resolveBalancers(settings)
+	return err
+}
+
+// balancerFunc adapts a scoring function to TeamBalancer
+type balancerFunc struct {
+	algorithm domain.AlgorithmType
+	score     func(m CompositionMetrics, w domain.BalanceWeights) float64
+}
+
+func (b balancerFunc) Algorithm() domain.AlgorithmType { return b.algorithm }
+
+func (b balancerFunc) Score(m CompositionMetrics, w domain.BalanceWeights) float64 {
+	return b.score(m, w)
+}
+
+func init() {
+	// Comfort first: minimize comfort penalty, with a tiny MMR tiebreaker for identical comfort
+	RegisterBalancer(balancerFunc{domain.AlgorithmComfortFirst, func(m CompositionMetrics, w domain.BalanceWeights) float64 {
+		return 100 - w.Comfort*m.ComfortPenalty - w.MMRDiff*float64(m.MMRDifference)/10000
+	}})
+
+	// Most balanced: even team MMR, comfort only breaks near-ties
+	RegisterBalancer(balancerFunc{domain.AlgorithmMMRBalanced, func(m CompositionMetrics, w domain.BalanceWeights) float64 {
+		return 100 - w.MMRDiff*float64(m.MMRDifference)/20 - w.Comfort*m.ComfortPenalty/10
+	}})
+
+	// Best role fit: heavily favor comfortable roles while keeping MMR in view
+	RegisterBalancer(balancerFunc{domain.AlgorithmRoleComfort, func(m CompositionMetrics, w domain.BalanceWeights) float64 {
+		return 100 - w.Comfort*m.ComfortPenalty*2 - w.MMRDiff*float64(m.MMRDifference)/200
+	}})
+
+	// Balanced overall: trade off all three measures
+	RegisterBalancer(balancerFunc{domain.AlgorithmHybrid, func(m CompositionMetrics, w domain.BalanceWeights) float64 {
+		return 100 - w.MMRDiff*float64(m.MMRDifference)/50 - w.Comfort*m.ComfortPenalty - w.LaneDiff*float64(m.MaxLaneDiff)/100
+	}})
+
+	// Fair lanes: avoid lopsided lane matchups first
+	RegisterBalancer(balancerFunc{domain.AlgorithmLaneBalanced, func(m CompositionMetrics, w domain.BalanceWeights) float64 {
+		return 100 - w.LaneDiff*float64(m.MaxLaneDiff)/20 - w.MMRDiff*float64(m.MMRDifference)/100 - w.Comfort*m.ComfortPenalty/5
+	}})
+}
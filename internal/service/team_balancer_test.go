@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository/postgres"
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fewestLaneGapsBalancer is a custom balancer that only looks at lane matchups
+type fewestLaneGapsBalancer struct{}
+
+func (fewestLaneGapsBalancer) Algorithm() domain.AlgorithmType {
+	return "test_fewest_lane_gaps"
+}
+
+func (fewestLaneGapsBalancer) Score(m service.CompositionMetrics, w domain.BalanceWeights) float64 {
+	return 100 - w.LaneDiff*float64(m.MaxLaneDiff)/10
+}
+
+func TestTeamBalancer_Registry(t *testing.T) {
+	for _, algorithm := range []domain.AlgorithmType{
+		domain.AlgorithmComfortFirst,
+		domain.AlgorithmMMRBalanced,
+		domain.AlgorithmRoleComfort,
+		domain.AlgorithmHybrid,
+		domain.AlgorithmLaneBalanced,
+	} {
+		balancer, ok := service.GetBalancer(algorithm)
+		require.True(t, ok, "built-in %s should be registered", algorithm)
+		assert.Equal(t, algorithm, balancer.Algorithm())
+	}
+
+	service.RegisterBalancer(fewestLaneGapsBalancer{})
+	assert.Contains(t, service.RegisteredAlgorithms(), domain.AlgorithmType("test_fewest_lane_gaps"))
+
+	assert.Panics(t, func() {
+		service.RegisterBalancer(fewestLaneGapsBalancer{})
+	})
+}
+
+func TestMatchmakingService_LobbyChosenAlgorithms(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	repos := postgres.NewRepositories(testDB.DB)
+	svc := service.NewMatchmakingService(repos.UserRoleProfile, repos.MatchOption, repos.Lobby, repos.LobbyPreference, repos.LobbyPlayer)
+	ctx := context.Background()
+
+	creator, _ := testutil.NewUserBuilder().WithDisplayName("creator").Build(t, testDB.DB)
+	lobby := &domain.Lobby{
+		ID:        uuid.New(),
+		ShortCode: "ALGO01",
+		CreatedBy: creator.ID,
+		Status:    domain.LobbyStatusWaitingForPlayers,
+	}
+	require.NoError(t, lobby.SetMatchmakingSettings(domain.MatchmakingSettings{
+		Algorithms: []domain.AlgorithmType{domain.AlgorithmMMRBalanced, domain.AlgorithmLaneBalanced},
+		Weights:    &domain.BalanceWeights{MMRDiff: 2, Comfort: 0, LaneDiff: 1},
+	}))
+	require.NoError(t, testDB.DB.Create(lobby).Error)
+
+	mmrs := []int{3000, 2800, 2500, 2200, 2000, 1800, 1500, 1200, 1000, 800}
+	var players []*domain.LobbyPlayer
+	for i, mmr := range mmrs {
+		user := createUserUniform(t, testDB.DB, "Algo"+string(rune('A'+i)), mmr, i%5+1)
+		players = append(players, createLobbyPlayer(t, testDB.DB, lobby.ID, user, true))
+	}
+
+	options, err := svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	require.NoError(t, err)
+	require.NotEmpty(t, options)
+
+	// Only the chosen algorithms produce options, starting with the first one
+	for _, opt := range options {
+		assert.Contains(t, []domain.AlgorithmType{domain.AlgorithmMMRBalanced, domain.AlgorithmLaneBalanced}, opt.AlgorithmType)
+		assert.GreaterOrEqual(t, opt.BalanceScore, 0.0)
+		assert.LessOrEqual(t, opt.BalanceScore, 100.0)
+	}
+	assert.Equal(t, domain.AlgorithmMMRBalanced, options[0].AlgorithmType)
+
+	// Unknown algorithms are rejected
+	require.NoError(t, lobby.SetMatchmakingSettings(domain.MatchmakingSettings{
+		Algorithms: []domain.AlgorithmType{"coin_flip"},
+	}))
+	require.NoError(t, repos.Lobby.Update(ctx, lobby))
+
+	_, err = svc.GenerateMatchOptions(ctx, lobby.ID, players, 8)
+	assert.ErrorIs(t, err, service.ErrUnknownMatchmakingAlgorithm)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// wsTicketTTL is how long a client has to open its websocket after getting a ticket
+const wsTicketTTL = 30 * time.Second
+
+var (
+	ErrInvalidTicketPurpose = errors.New("invalid websocket ticket purpose")
+	ErrInvalidTicket        = errors.New("invalid or expired websocket ticket")
+)
+
+// TicketPurpose is the websocket endpoint a ticket can open
+type TicketPurpose string
+
+const (
+	TicketPurposeDraft TicketPurpose = "draft"
+	TicketPurposeLobby TicketPurpose = "lobby"
+)
+
+func (p TicketPurpose) IsValid() bool {
+	return p == TicketPurposeDraft || p == TicketPurposeLobby
+}
+
+// WSTicket is a single-use ticket a client passes when opening a websocket, so the
+// access token never has to appear in a URL
+type WSTicket struct {
+	Ticket    string
+	ExpiresAt time.Time
+}
+
+type wsTicketEntry struct {
+	userID    uuid.UUID
+	purpose   TicketPurpose
+	expiresAt time.Time
+}
+
+// TicketService issues and redeems websocket tickets. Tickets only live in memory, since
+// they expire long before a restart would matter
+type TicketService struct {
+	mu      sync.Mutex
+	tickets map[string]wsTicketEntry
+}
+
+func NewTicketService() *TicketService {
+	return &TicketService{tickets: make(map[string]wsTicketEntry)}
+}
+
+// Issue creates a ticket that lets the user open one websocket of the given kind
+func (s *TicketService) Issue(userID uuid.UUID, purpose TicketPurpose) (*WSTicket, error) {
+	if !purpose.IsValid() {
+		return nil, ErrInvalidTicketPurpose
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	ticket := hex.EncodeToString(raw)
+	now := time.Now()
+	entry := wsTicketEntry{
+		userID:    userID,
+		purpose:   purpose,
+		expiresAt: now.Add(wsTicketTTL),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop tickets that were never used
+	for key, existing := range s.tickets {
+		if now.After(existing.expiresAt) {
+			delete(s.tickets, key)
+		}
+	}
+	s.tickets[ticket] = entry
+
+	return &WSTicket{Ticket: ticket, ExpiresAt: entry.expiresAt}, nil
+}
+
+// Redeem uses up a ticket and returns the user it was issued to. A ticket is gone after
+// its first redemption, even one for the wrong purpose
+func (s *TicketService) Redeem(ticket string, purpose TicketPurpose) (uuid.UUID, error) {
+	s.mu.Lock()
+	entry, ok := s.tickets[ticket]
+	delete(s.tickets, ticket)
+	s.mu.Unlock()
+
+	if !ok || entry.purpose != purpose || time.Now().After(entry.expiresAt) {
+		return uuid.Nil, ErrInvalidTicket
+	}
+	return entry.userID, nil
+}
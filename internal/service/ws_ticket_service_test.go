@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketService(t *testing.T) {
+	tickets := service.NewTicketService()
+	userID := uuid.New()
+
+	_, err := tickets.Issue(userID, "spectate")
+	assert.ErrorIs(t, err, service.ErrInvalidTicketPurpose)
+
+	ticket, err := tickets.Issue(userID, service.TicketPurposeDraft)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), ticket.ExpiresAt, time.Second)
+
+	redeemed, err := tickets.Redeem(ticket.Ticket, service.TicketPurposeDraft)
+	require.NoError(t, err)
+	assert.Equal(t, userID, redeemed)
+
+	// Tickets are single use
+	_, err = tickets.Redeem(ticket.Ticket, service.TicketPurposeDraft)
+	assert.ErrorIs(t, err, service.ErrInvalidTicket)
+
+	// A lobby ticket can't open a draft websocket, and is used up by trying
+	ticket, err = tickets.Issue(userID, service.TicketPurposeLobby)
+	require.NoError(t, err)
+	_, err = tickets.Redeem(ticket.Ticket, service.TicketPurposeDraft)
+	assert.ErrorIs(t, err, service.ErrInvalidTicket)
+	_, err = tickets.Redeem(ticket.Ticket, service.TicketPurposeLobby)
+	assert.ErrorIs(t, err, service.ErrInvalidTicket)
+
+	_, err = tickets.Redeem("", service.TicketPurposeLobby)
+	assert.ErrorIs(t, err, service.ErrInvalidTicket)
+}
@@ -20,6 +20,7 @@ import (
 type UserBuilder struct {
 	displayName string
 	password    string
+	role        domain.UserRole
 }
 
 // NewUserBuilder creates a new UserBuilder with default values
@@ -27,6 +28,7 @@ func NewUserBuilder() *UserBuilder {
 	return &UserBuilder{
 		displayName: fmt.Sprintf("testuser_%s", uuid.New().String()[:8]),
 		password:    "testpassword123",
+		role:        domain.UserRolePlayer,
 	}
 }
 
@@ -42,6 +44,12 @@ func (b *UserBuilder) WithPassword(password string) *UserBuilder {
 	return b
 }
 
+// WithRole sets the user's site-wide role
+func (b *UserBuilder) WithRole(role domain.UserRole) *UserBuilder {
+	b.role = role
+	return b
+}
+
 // Build creates the user in the database and returns the user with the raw password
 func (b *UserBuilder) Build(t *testing.T, db *gorm.DB) (*domain.User, string) {
 	t.Helper()
@@ -55,6 +63,7 @@ func (b *UserBuilder) Build(t *testing.T, db *gorm.DB) (*domain.User, string) {
 		ID:           uuid.New(),
 		DisplayName:  b.displayName,
 		PasswordHash: string(hashedPassword),
+		Role:         b.role,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -117,6 +126,14 @@ type RoomBuilder struct {
 	timerSeconds int
 	blueSide     *domain.User
 	redSide      *domain.User
+	seriesID     *uuid.UUID
+	gameNumber   int
+	templateID   *uuid.UUID
+	reserveSecs  int
+	botSide      *domain.Side
+	teamPlayers  []*domain.RoomPlayer
+	tradeSecs    int
+	delaySecs    int
 }
 
 // NewRoomBuilder creates a new RoomBuilder with default values
@@ -124,6 +141,7 @@ func NewRoomBuilder() *RoomBuilder {
 	return &RoomBuilder{
 		draftMode:    domain.DraftModeProPlay,
 		timerSeconds: 30,
+		gameNumber:   1,
 	}
 }
 
@@ -157,7 +175,56 @@ func (b *RoomBuilder) WithRedSide(user *domain.User) *RoomBuilder {
 	return b
 }
 
+// WithSeries places the room in a series at the given game number
+func (b *RoomBuilder) WithSeries(seriesID uuid.UUID, gameNumber int) *RoomBuilder {
+	b.seriesID = &seriesID
+	b.gameNumber = gameNumber
+	return b
+}
+
+// WithDraftTemplate sets the draft template the room drafts with
+func (b *RoomBuilder) WithDraftTemplate(templateID uuid.UUID) *RoomBuilder {
+	b.templateID = &templateID
+	return b
+}
+
+// WithReserveTime gives each side a chess-clock reserve in seconds
+func (b *RoomBuilder) WithReserveTime(seconds int) *RoomBuilder {
+	b.reserveSecs = seconds
+	return b
+}
+
+// WithBotSide has the bot draft for the given side
+func (b *RoomBuilder) WithBotSide(side domain.Side) *RoomBuilder {
+	b.botSide = &side
+	return b
+}
+
+// WithTeamPlayer adds a player to a team, making the room a team draft
+func (b *RoomBuilder) WithTeamPlayer(user *domain.User, team domain.Side, role domain.Role, isCaptain bool) *RoomBuilder {
+	b.teamPlayers = append(b.teamPlayers, &domain.RoomPlayer{
+		UserID:       user.ID,
+		Team:         team,
+		AssignedRole: role,
+		DisplayName:  user.DisplayName,
+		IsCaptain:    isCaptain,
+	})
+	return b
+}
+
 // Build creates the room in the database
+// WithTradePhase opens a trade phase of the given length after the last pick of a team draft
+func (b *RoomBuilder) WithTradePhase(seconds int) *RoomBuilder {
+	b.tradeSecs = seconds
+	return b
+}
+
+// WithSpectatorDelay holds spectators the given number of seconds behind the draft
+func (b *RoomBuilder) WithSpectatorDelay(seconds int) *RoomBuilder {
+	b.delaySecs = seconds
+	return b
+}
+
 func (b *RoomBuilder) Build(t *testing.T, db *gorm.DB) *domain.Room {
 	t.Helper()
 
@@ -167,13 +234,21 @@ func (b *RoomBuilder) Build(t *testing.T, db *gorm.DB) *domain.Room {
 	}
 
 	room := &domain.Room{
-		ID:                   uuid.New(),
-		ShortCode:            generateShortCode(),
-		CreatedBy:            b.creator.ID,
-		DraftMode:            b.draftMode,
-		Status:               domain.RoomStatusWaiting,
-		TimerDurationSeconds: b.timerSeconds,
-		CreatedAt:            time.Now(),
+		ID:                    uuid.New(),
+		ShortCode:             generateShortCode(),
+		CreatedBy:             b.creator.ID,
+		DraftMode:             b.draftMode,
+		Status:                domain.RoomStatusWaiting,
+		TimerDurationSeconds:  b.timerSeconds,
+		SeriesID:              b.seriesID,
+		GameNumber:            b.gameNumber,
+		DraftTemplateID:       b.templateID,
+		ReserveTimeSeconds:    b.reserveSecs,
+		BotSide:               b.botSide,
+		IsTeamDraft:           len(b.teamPlayers) > 0,
+		TradePhaseSeconds:     b.tradeSecs,
+		SpectatorDelaySeconds: b.delaySecs,
+		CreatedAt:             time.Now(),
 	}
 
 	if b.blueSide != nil {
@@ -187,6 +262,14 @@ func (b *RoomBuilder) Build(t *testing.T, db *gorm.DB) *domain.Room {
 		t.Fatalf("failed to create room: %v", err)
 	}
 
+	for _, player := range b.teamPlayers {
+		player.ID = uuid.New()
+		player.RoomID = room.ID
+		if err := db.Create(player).Error; err != nil {
+			t.Fatalf("failed to create room player: %v", err)
+		}
+	}
+
 	// Also create initial draft state
 	emptyJSON, _ := json.Marshal([]string{})
 	draftState := &domain.DraftState{
@@ -14,6 +14,7 @@ import (
 	repoPostgres "github.com/dom/league-draft-website/internal/repository/postgres"
 	"github.com/dom/league-draft-website/internal/service"
 	"github.com/dom/league-draft-website/internal/websocket"
+	"github.com/google/uuid"
 	"github.com/testcontainers/testcontainers-go"
 	tcPostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -66,14 +67,22 @@ func NewTestDB(t *testing.T) *TestDB {
 	err = db.AutoMigrate(
 		&domain.User{},
 		&domain.UserSession{},
+		&domain.PasswordResetToken{},
 		&domain.Room{},
 		&domain.DraftState{},
 		&domain.DraftAction{},
+		&domain.PickAssignment{},
+		&domain.RoomChatMessage{},
 		&domain.Champion{},
 		&domain.FearlessBan{},
+		&domain.Series{},
+		&domain.DraftTemplate{},
 		&domain.UserRoleProfile{},
+		&domain.RatingChange{},
 		&domain.Lobby{},
 		&domain.LobbyPlayer{},
+		&domain.LobbyPreference{},
+		&domain.ChatMessage{},
 		&domain.MatchOption{},
 		&domain.MatchOptionAssignment{},
 		&domain.RoomPlayer{},
@@ -109,18 +118,26 @@ func (tdb *TestDB) Truncate(t *testing.T) {
 	t.Helper()
 
 	tables := []string{
+		"chat_messages",
 		"votes",
 		"match_option_assignments",
 		"match_options",
+		"lobby_preferences",
 		"lobby_players",
 		"lobbies",
+		"room_chat_messages",
 		"room_players",
+		"rating_changes",
 		"user_role_profiles",
 		"fearless_bans",
+		"series",
+		"draft_templates",
+		"pick_assignments",
 		"draft_actions",
 		"draft_states",
 		"rooms",
 		"user_sessions",
+		"password_reset_tokens",
 		"users",
 		"champions",
 	}
@@ -138,7 +155,8 @@ func TestConfig() *config.Config {
 		Port:                 "0", // Random port
 		Environment:          "test",
 		JWTSecret:            "test-jwt-secret-key-for-testing-only",
-		JWTExpirationHours:   1,
+		AccessTokenTTL:       time.Hour,
+		RefreshTokenTTL:      24 * time.Hour,
 		DefaultTimerDuration: 2 * time.Second, // Fast timer for tests
 		DataDragonVersion:    "14.1.1",
 	}
@@ -162,13 +180,18 @@ func NewTestServer(t *testing.T) *TestServer {
 	cfg := TestConfig()
 
 	repos := repoPostgres.NewRepositories(testDB.DB)
-	hub := websocket.NewHub(repos.User, repos.RoomPlayer, repos.Champion, repos.Room, repos.DraftAction)
+	hub := websocket.NewHub(repos.User, repos.RoomPlayer, repos.Champion, repos.Room, repos.DraftAction, repos.FearlessBan, repos.DraftTemplate, repos.DraftState, repos.PickAssignment, repos.RoomChatMessage)
 	go hub.Run()
 
 	lobbyHub := websocket.NewLobbyHub(repos.Lobby, repos.LobbyPlayer, repos.MatchOption, repos.User)
 	go lobbyHub.Run()
 
 	services := service.NewServices(repos, cfg)
+	lobbyHub.SetCaptainPicker(services.Lobby)
+	lobbyHub.SetLobbyChat(services.Lobby)
+	if err := services.DraftTemplate.EnsureBuiltInTemplates(context.Background()); err != nil {
+		t.Fatalf("failed to seed draft templates: %v", err)
+	}
 	router := api.NewRouter(services, hub, lobbyHub, repos, cfg)
 
 	server := httptest.NewServer(router)
@@ -201,8 +224,58 @@ func (ts *TestServer) APIURL(path string) string {
 	return fmt.Sprintf("%s/api/v1%s", ts.Server.URL, path)
 }
 
-// WebSocketURL returns the WebSocket URL with token
-func (ts *TestServer) WebSocketURL(token string) string {
+// WebSocketURL returns the draft WebSocket URL with a fresh ticket for the token's user
+func (ts *TestServer) WebSocketURL(t *testing.T, token string) string {
+	t.Helper()
+
+	claims, err := ts.Services.Auth.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	sub, _ := (*claims)["sub"].(string)
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		t.Fatalf("failed to parse token subject: %v", err)
+	}
+	ticket, err := ts.Services.Ticket.Issue(userID, service.TicketPurposeDraft)
+	if err != nil {
+		t.Fatalf("failed to issue websocket ticket: %v", err)
+	}
+
 	wsURL := "ws" + ts.Server.URL[4:] // Replace "http" with "ws"
-	return fmt.Sprintf("%s/api/v1/ws?token=%s", wsURL, token)
+	return fmt.Sprintf("%s/api/v1/ws?ticket=%s", wsURL, ticket.Ticket)
+}
+
+// Restart simulates a server restart: it stops the hub, then starts a fresh hub, router and
+// HTTP server against the same database and restores in-progress rooms.
+func (ts *TestServer) Restart(t *testing.T) {
+	t.Helper()
+
+	ts.Hub.Stop()
+	ts.Server.Close()
+
+	repos := ts.Repos
+	hub := websocket.NewHub(repos.User, repos.RoomPlayer, repos.Champion, repos.Room, repos.DraftAction, repos.FearlessBan, repos.DraftTemplate, repos.DraftState, repos.PickAssignment, repos.RoomChatMessage)
+	go hub.Run()
+
+	lobbyHub := websocket.NewLobbyHub(repos.Lobby, repos.LobbyPlayer, repos.MatchOption, repos.User)
+	lobbyHub.SetCaptainPicker(ts.Services.Lobby)
+	lobbyHub.SetLobbyChat(ts.Services.Lobby)
+	go lobbyHub.Run()
+
+	if _, err := hub.RestoreRooms(context.Background()); err != nil {
+		t.Fatalf("failed to restore rooms: %v", err)
+	}
+
+	router := api.NewRouter(ts.Services, hub, lobbyHub, repos, ts.Config)
+	server := httptest.NewServer(router)
+
+	ts.Server = server
+	ts.Hub = hub
+
+	t.Cleanup(func() {
+		hub.Stop()
+		lobbyHub.Stop()
+		server.Close()
+	})
 }
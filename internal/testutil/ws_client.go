@@ -140,6 +140,44 @@ func (c *WSClient) sendCommand(action websocket.CommandAction, payload interface
 	}
 }
 
+// Hello sends a HELLO frame negotiating the protocol version
+func (c *WSClient) Hello(version int) {
+	c.t.Helper()
+	msg, err := websocket.NewMsg(websocket.MsgTypeHello, websocket.HelloPayload{Version: version})
+	if err != nil {
+		c.t.Fatalf("failed to build hello: %v", err)
+	}
+	c.writeJSON(msg)
+}
+
+// SendLegacy sends a v1 client message (e.g. LOCK_IN) instead of a v2 COMMAND
+func (c *WSClient) SendLegacy(msgType websocket.MessageType, payload interface{}) {
+	c.t.Helper()
+	msg, err := websocket.NewMessage(msgType, payload)
+	if err != nil {
+		c.t.Fatalf("failed to build legacy message: %v", err)
+	}
+	c.writeJSON(msg)
+}
+
+// writeJSON encodes and writes a frame to the server
+func (c *WSClient) writeJSON(v interface{}) {
+	c.t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.t.Fatalf("failed to marshal message: %v", err)
+	}
+
+	c.mu.Lock()
+	err = c.conn.WriteMessage(gorillaWS.TextMessage, data)
+	c.mu.Unlock()
+
+	if err != nil {
+		c.t.Fatalf("failed to send message: %v", err)
+	}
+}
+
 // JoinRoom sends a v2 join_room COMMAND
 func (c *WSClient) JoinRoom(roomID, side string) {
 	c.sendCommand(websocket.CmdJoinRoom, websocket.CmdJoinRoomPayload{
@@ -148,6 +186,15 @@ func (c *WSClient) JoinRoom(roomID, side string) {
 	})
 }
 
+// RejoinRoom sends a v2 join_room COMMAND resuming from the last event seq seen
+func (c *WSClient) RejoinRoom(roomID, side string, lastSeq int) {
+	c.sendCommand(websocket.CmdJoinRoom, websocket.CmdJoinRoomPayload{
+		RoomID:  roomID,
+		Side:    side,
+		LastSeq: &lastSeq,
+	})
+}
+
 // Ready sends a v2 set_ready COMMAND
 func (c *WSClient) Ready(ready bool) {
 	c.sendCommand(websocket.CmdSetReady, websocket.CmdSetReadyPayload{
@@ -179,6 +226,66 @@ func (c *WSClient) HoverChampion(championID *string) {
 	})
 }
 
+// SuggestChampion sends a v2 suggest_champion COMMAND
+func (c *WSClient) SuggestChampion(championID string) {
+	c.sendCommand(websocket.CmdSuggestChampion, websocket.CmdSuggestChampionPayload{
+		ChampionID: championID,
+	})
+}
+
+// VoteSuggestion sends a v2 vote_suggestion COMMAND
+func (c *WSClient) VoteSuggestion(championID string, vote bool) {
+	c.sendCommand(websocket.CmdVoteSuggestion, websocket.CmdVoteSuggestionPayload{
+		ChampionID: championID,
+		Vote:       vote,
+	})
+}
+
+// SendChat sends a v2 send_chat COMMAND
+func (c *WSClient) SendChat(channel, body string) {
+	c.sendCommand(websocket.CmdSendChat, websocket.CmdSendChatPayload{
+		Channel: channel,
+		Body:    body,
+	})
+}
+
+// PingChampion sends a v2 send_ping COMMAND for a champion
+func (c *WSClient) PingChampion(channel, championID string) {
+	c.sendCommand(websocket.CmdSendPing, websocket.CmdSendPingPayload{
+		Channel:    channel,
+		ChampionID: championID,
+	})
+}
+
+// ProposeTrade sends a v2 propose_trade COMMAND
+func (c *WSClient) ProposeTrade(championID string) {
+	c.sendCommand(websocket.CmdProposeTrade, websocket.CmdProposeTradePayload{
+		ChampionID: championID,
+	})
+}
+
+// RespondTrade sends a v2 respond_trade COMMAND
+func (c *WSClient) RespondTrade(offerID string, accept bool) {
+	c.sendCommand(websocket.CmdRespondTrade, websocket.CmdRespondTradePayload{
+		OfferID: offerID,
+		Accept:  accept,
+	})
+}
+
+// AssignPick sends a v2 assign_pick COMMAND
+func (c *WSClient) AssignPick(championID, userID, role string) {
+	c.sendCommand(websocket.CmdAssignPick, websocket.CmdAssignPickPayload{
+		ChampionID: championID,
+		UserID:     userID,
+		Role:       role,
+	})
+}
+
+// FinishTrades sends a v2 finish_trades COMMAND
+func (c *WSClient) FinishTrades() {
+	c.sendCommand(websocket.CmdFinishTrades, nil)
+}
+
 // SyncState sends a v2 sync_state QUERY
 func (c *WSClient) SyncState() {
 	c.sendQuery(websocket.QuerySyncState)
@@ -240,6 +347,36 @@ func (c *WSClient) ExpectMessage(msgType websocket.MessageType, timeout time.Dur
 	}
 }
 
+// ExpectEvent waits for a v2 EVENT envelope of the given event type
+func (c *WSClient) ExpectEvent(eventType websocket.EventType, timeout time.Duration) *websocket.Event {
+	c.t.Helper()
+
+	for {
+		msg := c.ExpectMessage(websocket.MessageType(websocket.MsgTypeEvent), timeout)
+		var evt websocket.Event
+		if err := json.Unmarshal(msg.Payload, &evt); err != nil {
+			c.t.Fatalf("failed to decode event: %v", err)
+		}
+		if evt.Event == eventType {
+			return &evt
+		}
+	}
+}
+
+// ExpectState waits for and decodes a v2 STATE envelope
+func (c *WSClient) ExpectState(timeout time.Duration) *websocket.StatePayload {
+	c.t.Helper()
+
+	msg := c.ExpectMessage(websocket.MessageType(websocket.MsgTypeState), timeout)
+
+	var payload websocket.StatePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		c.t.Fatalf("failed to decode state payload: %v", err)
+	}
+
+	return &payload
+}
+
 // ExpectStateSync waits for and decodes a STATE_SYNC message
 func (c *WSClient) ExpectStateSync(timeout time.Duration) *websocket.StateSyncPayload {
 	c.t.Helper()
@@ -391,6 +528,30 @@ func (c *WSClient) DrainMessagesWithTimeout(timeout time.Duration) {
 	}
 }
 
+// ExpectClosed waits for the server to close the connection, skipping any messages before
+// it, and returns the close code
+func (c *WSClient) ExpectClosed(timeout time.Duration) int {
+	c.t.Helper()
+
+	messages := c.messages
+	deadline := time.After(timeout)
+	for {
+		select {
+		case _, ok := <-messages:
+			if !ok {
+				messages = nil
+			}
+		case err := <-c.errors:
+			if closeErr, ok := err.(*gorillaWS.CloseError); ok {
+				return closeErr.Code
+			}
+			c.t.Fatalf("connection failed without a close frame: %v", err)
+		case <-deadline:
+			c.t.Fatal("timeout waiting for connection to close")
+		}
+	}
+}
+
 // ExpectAnyMessage waits for any message to arrive and returns it
 func (c *WSClient) ExpectAnyMessage(timeout time.Duration) *websocket.Message {
 	c.t.Helper()
@@ -0,0 +1,211 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CaptainPicker makes the picks of a lobby's captain's draft
+type CaptainPicker interface {
+	PickPlayer(ctx context.Context, lobbyID, captainID, pickedUserID uuid.UUID) (*domain.CaptainsDraft, error)
+	// AutoPickPlayer returns a nil draft if the pick was already made
+	AutoPickPlayer(ctx context.Context, lobbyID uuid.UUID, pick int) (*domain.CaptainsDraft, error)
+}
+
+// pickTimer runs out the time of one pick in a captain's draft
+type pickTimer struct {
+	pick   int
+	endsAt time.Time
+	timer  *time.Timer
+}
+
+// SetCaptainPicker sets what makes the picks of captain's drafts
+func (h *LobbyHub) SetCaptainPicker(picker CaptainPicker) {
+	h.captainPicker = picker
+}
+
+// StartCaptainsDraft starts the timer of a captain's draft's first pick and broadcasts it
+func (h *LobbyHub) StartCaptainsDraft(ctx context.Context, lobbyID uuid.UUID, draft *domain.CaptainsDraft) {
+	state := h.lockCaptainsDraft(lobbyID)
+	defer h.unlockCaptainsDraft(state)
+
+	h.advanceCaptainsDraftLocked(ctx, lobbyID, draft)
+}
+
+// handleCaptainPick makes a pick for the captain whose turn it is
+func (h *LobbyHub) handleCaptainPick(client *LobbyClient, pickedUserID uuid.UUID) {
+	lobbyID := client.LobbyID()
+	if lobbyID == uuid.Nil {
+		client.sendError("NOT_IN_LOBBY", "Join a lobby before picking")
+		return
+	}
+	if h.captainPicker == nil {
+		client.sendError("PICK_FAILED", "Captain's draft is not available")
+		return
+	}
+
+	ctx := context.Background()
+	state := h.lockCaptainsDraft(lobbyID)
+	defer h.unlockCaptainsDraft(state)
+
+	draft, err := h.captainPicker.PickPlayer(ctx, lobbyID, client.UserID(), pickedUserID)
+	if err != nil {
+		client.sendError("PICK_FAILED", err.Error())
+		return
+	}
+	h.advanceCaptainsDraftLocked(ctx, lobbyID, draft)
+}
+
+// autoPick picks for a captain whose time ran out, unless the pick was made in the meantime
+func (h *LobbyHub) autoPick(lobbyID uuid.UUID, pick int) {
+	state := h.lockCaptainsDraft(lobbyID)
+	defer h.unlockCaptainsDraft(state)
+
+	h.timersMu.Lock()
+	current, ok := h.pickTimers[lobbyID]
+	h.timersMu.Unlock()
+	if !ok || current.pick != pick || h.captainPicker == nil {
+		return
+	}
+
+	ctx := context.Background()
+	draft, err := h.captainPicker.AutoPickPlayer(ctx, lobbyID, pick)
+	if err != nil {
+		log.Printf("LobbyHub.autoPick: failed to pick for lobby %s: %v", lobbyID, err)
+		h.stopPickTimer(lobbyID)
+		return
+	}
+	if draft == nil {
+		return
+	}
+	h.advanceCaptainsDraftLocked(ctx, lobbyID, draft)
+}
+
+// lockCaptainsDraft takes the pick lock of a lobby's captain's draft, so picks in other lobbies are never blocked
+func (h *LobbyHub) lockCaptainsDraft(lobbyID uuid.UUID) *LobbyState {
+	h.mu.Lock()
+	state, exists := h.lobbies[lobbyID]
+	if !exists {
+		state = NewLobbyState(lobbyID)
+		h.lobbies[lobbyID] = state
+	}
+	state.pickers++
+	h.mu.Unlock()
+
+	state.pickMu.Lock()
+	return state
+}
+
+// unlockCaptainsDraft releases a lobby's pick lock and forgets the lobby if no one is left in it
+func (h *LobbyHub) unlockCaptainsDraft(state *LobbyState) {
+	state.pickMu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state.pickers--
+	if state.pickers == 0 && state.ClientCount() == 0 && h.lobbies[state.lobbyID] == state {
+		delete(h.lobbies, state.lobbyID)
+	}
+}
+
+// advanceCaptainsDraftLocked times the next pick and broadcasts the draft's progress.
+// Once the draft is complete the lobby's teams and roles are broadcast instead. Callers hold the lobby's pick lock
+func (h *LobbyHub) advanceCaptainsDraftLocked(ctx context.Context, lobbyID uuid.UUID, draft *domain.CaptainsDraft) {
+	h.stopPickTimer(lobbyID)
+	if !draft.IsComplete() {
+		h.startPickTimer(lobbyID, draft.Picks, domain.CaptainPickDuration)
+	}
+
+	if state := h.GetLobbyStateIfExists(lobbyID); state != nil {
+		state.Broadcast(NewLobbyMessage(LobbyMsgCaptainsDraftUpdated, CaptainsDraftUpdatedPayload{
+			Draft: *h.captainsDraftInfo(lobbyID, draft),
+		}))
+	}
+	h.BroadcastLobbyUpdate(ctx, lobbyID)
+}
+
+// resumeCaptainsDraft restarts the pick timer of a captain's draft that has none, such as after a restart
+func (h *LobbyHub) resumeCaptainsDraft(lobbyID uuid.UUID, players []*domain.LobbyPlayer, teamSize int) {
+	draft := domain.NewCaptainsDraft(players, teamSize)
+	if draft.IsComplete() || h.captainPicker == nil {
+		return
+	}
+
+	h.timersMu.Lock()
+	_, running := h.pickTimers[lobbyID]
+	h.timersMu.Unlock()
+	if !running {
+		h.startPickTimer(lobbyID, draft.Picks, domain.CaptainPickDuration)
+	}
+}
+
+// startPickTimer starts timing a pick, replacing any timer the lobby had
+func (h *LobbyHub) startPickTimer(lobbyID uuid.UUID, pick int, duration time.Duration) {
+	h.timersMu.Lock()
+	defer h.timersMu.Unlock()
+
+	if existing, ok := h.pickTimers[lobbyID]; ok {
+		existing.timer.Stop()
+	}
+	h.pickTimers[lobbyID] = &pickTimer{
+		pick:   pick,
+		endsAt: time.Now().Add(duration),
+		timer: time.AfterFunc(duration, func() {
+			h.autoPick(lobbyID, pick)
+		}),
+	}
+}
+
+// stopPickTimer stops timing a lobby's pick
+func (h *LobbyHub) stopPickTimer(lobbyID uuid.UUID) {
+	h.timersMu.Lock()
+	defer h.timersMu.Unlock()
+
+	if existing, ok := h.pickTimers[lobbyID]; ok {
+		existing.timer.Stop()
+		delete(h.pickTimers, lobbyID)
+	}
+}
+
+// stopAllPickTimers stops timing the picks of every lobby
+func (h *LobbyHub) stopAllPickTimers() {
+	h.timersMu.Lock()
+	defer h.timersMu.Unlock()
+
+	for lobbyID, existing := range h.pickTimers {
+		existing.timer.Stop()
+		delete(h.pickTimers, lobbyID)
+	}
+}
+
+// captainsDraftInfo converts a captain's draft to wire format, with the deadline of its current pick
+func (h *LobbyHub) captainsDraftInfo(lobbyID uuid.UUID, draft *domain.CaptainsDraft) *CaptainsDraftInfo {
+	info := &CaptainsDraftInfo{
+		BlueCaptainID: draft.BlueCaptainID.String(),
+		RedCaptainID:  draft.RedCaptainID.String(),
+		Picks:         draft.Picks,
+		TotalPicks:    draft.TotalPicks,
+		NextSide:      string(draft.NextSide),
+	}
+
+	h.timersMu.Lock()
+	if current, ok := h.pickTimers[lobbyID]; ok && current.pick == draft.Picks {
+		s := current.endsAt.Format(time.RFC3339)
+		info.PickEndsAt = &s
+	}
+	h.timersMu.Unlock()
+
+	if draft.LastPick != nil {
+		info.LastPick = &CaptainPickInfo{
+			UserID: draft.LastPick.UserID.String(),
+			Team:   string(draft.LastPick.Team),
+			Pick:   draft.LastPick.Pick,
+			Auto:   draft.LastPick.Auto,
+		}
+	}
+	return info
+}
@@ -0,0 +1,175 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+)
+
+// ChatManager handles the draft room's chat and ping markers.
+// Team channel messages and pings only ever go to the sender's side.
+// Messages are persisted with the room; pings are not.
+// It has no lock of its own and must be used with the room lock held.
+type ChatManager struct {
+	room     *Room
+	messages []*domain.RoomChatMessage
+}
+
+// NewChatManager creates a new chat manager.
+func NewChatManager(room *Room) *ChatManager {
+	return &ChatManager{room: room}
+}
+
+// Load restores the room's saved messages, such as after a server restart.
+func (cm *ChatManager) Load() {
+	if cm.room.chatRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages, err := cm.room.chatRepo.GetByRoomID(ctx, cm.room.id)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading chat for room %s: %v", cm.room.id, err)
+		}
+		return
+	}
+	cm.messages = messages
+}
+
+// Send posts a message from a player to their side or the whole room.
+func (cm *ChatManager) Send(client *Client, channel domain.ChatChannel, body string) error {
+	if client.side != "blue" && client.side != "red" {
+		return &ChatError{"unauthorized", "Spectators cannot chat"}
+	}
+
+	msg, err := domain.NewRoomChatMessage(cm.room.id, client.userID, channel, domain.Side(client.side), body)
+	if err != nil {
+		return &ChatError{"invalid_message", err.Error()}
+	}
+	msg.User = &domain.User{ID: client.userID, DisplayName: cm.room.getUserDisplayName(client.userID)}
+	cm.messages = append(cm.messages, msg)
+	cm.persist(msg)
+
+	out, _ := NewMessage(MessageTypeChatMessage, RoomChatMessagePayload{Message: cm.info(msg)})
+	cm.emit(channel, client.side, out)
+	return nil
+}
+
+// Ping points a champion or a pick/ban slot out to the player's side or the whole room.
+func (cm *ChatManager) Ping(client *Client, p CmdSendPingPayload) error {
+	if client.side != "blue" && client.side != "red" {
+		return &ChatError{"unauthorized", "Spectators cannot ping"}
+	}
+
+	channel := domain.ChatChannel(p.Channel)
+	if !channel.IsValid() {
+		return &ChatError{"invalid_ping", "Invalid chat channel"}
+	}
+
+	payload := PingMarkerPayload{
+		UserID:      client.userID.String(),
+		DisplayName: cm.room.getUserDisplayName(client.userID),
+		Side:        client.side,
+		Channel:     p.Channel,
+	}
+	switch {
+	case p.ChampionID != "" && p.SlotType == "":
+		payload.ChampionID = p.ChampionID
+	case p.ChampionID == "" && validPingSlot(p):
+		payload.SlotType, payload.Team, payload.SlotIndex = p.SlotType, p.Team, p.SlotIndex
+	default:
+		return &ChatError{"invalid_ping", "A ping needs either a champion or a slot"}
+	}
+
+	out, _ := NewMessage(MessageTypePingMarker, payload)
+	cm.emit(channel, client.side, out)
+	return nil
+}
+
+// History returns the messages a client on the given side can read, oldest first.
+func (cm *ChatManager) History(side string) []ChatMessageInfo {
+	var history []ChatMessageInfo
+	for _, msg := range cm.messages {
+		if msg.VisibleTo(side) {
+			history = append(history, cm.info(msg))
+		}
+	}
+	return history
+}
+
+// emit sends a chat event to the sender's side, or to everyone for the all channel.
+func (cm *ChatManager) emit(channel domain.ChatChannel, side string, msg *Message) {
+	if channel == domain.ChatChannelTeam {
+		cm.room.emitter.BroadcastToSide(side, msg)
+		return
+	}
+	cm.room.emitter.Broadcast(msg)
+}
+
+// persist saves a message asynchronously so the room isn't blocked on the database.
+func (cm *ChatManager) persist(msg *domain.RoomChatMessage) {
+	if cm.room.chatRepo == nil {
+		return
+	}
+
+	// Save a copy without the sender, since the message in memory is read under the room lock
+	saved := *msg
+	saved.User = nil
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := cm.room.chatRepo.Create(ctx, &saved); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Error saving chat message for room %s: %v", cm.room.id, err)
+			}
+		}
+	}()
+}
+
+func (cm *ChatManager) info(msg *domain.RoomChatMessage) ChatMessageInfo {
+	userID := msg.UserID.String()
+	displayName := "Unknown"
+	if msg.User != nil {
+		displayName = msg.User.DisplayName
+	}
+	info := ChatMessageInfo{
+		ID:          msg.ID.String(),
+		UserID:      &userID,
+		DisplayName: displayName,
+		Channel:     string(msg.Channel),
+		Body:        msg.Body,
+		CreatedAt:   msg.CreatedAt.Format(time.RFC3339),
+	}
+	if msg.Channel == domain.ChatChannelTeam {
+		team := string(msg.Side)
+		info.Team = &team
+	}
+	return info
+}
+
+// validPingSlot returns true if a ping names an existing kind of pick/ban slot.
+func validPingSlot(p CmdSendPingPayload) bool {
+	if p.SlotType != "ban" && p.SlotType != "pick" {
+		return false
+	}
+	if p.Team != "blue" && p.Team != "red" {
+		return false
+	}
+	return p.SlotIndex != nil && *p.SlotIndex >= 0 && *p.SlotIndex < 5
+}
+
+// ChatError represents a chat-related error.
+type ChatError struct {
+	Code    string
+	Message string
+}
+
+func (e *ChatError) Error() string {
+	return e.Message
+}
@@ -21,21 +21,30 @@ type Client struct {
 	hub    *Hub
 	conn   *websocket.Conn
 	send   chan []byte
-	room   *Room
+	room   *Room // guarded by mu, set on the hub goroutine
 	userID uuid.UUID
 	side   string // "blue", "red", "spectator"
 	ready  bool
 
-	mu     sync.RWMutex
-	closed bool
+	// lastSeq is the last event seq seen before reconnecting, set by the hub on join
+	lastSeq *int
+
+	chatLimiter chatLimiter
+	limiter     *messageLimiter
+
+	mu       sync.RWMutex
+	closed   bool
+	protocol int // ProtocolV1 or ProtocolV2, guarded by mu
 }
 
-func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID) *Client {
+func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID, protocol int) *Client {
 	return &Client{
-		hub:    hub,
-		conn:   conn,
-		send:   make(chan []byte, 256),
-		userID: userID,
+		hub:      hub,
+		conn:     conn,
+		send:     make(chan []byte, 256),
+		userID:   userID,
+		protocol: protocol,
+		limiter:  newMessageLimiter(hub.messageLimits),
 	}
 }
 
@@ -67,6 +76,17 @@ func (c *Client) ReadPump() {
 			continue
 		}
 
+		allowed, disconnect := c.limiter.Allow(draftMessageKind(&msg))
+		if disconnect {
+			log.Printf("Disconnecting user %s for sending too many messages", c.userID)
+			closeForFlooding(c.conn)
+			break
+		}
+		if !allowed {
+			c.sendError("RATE_LIMITED", "Too many messages, slow down")
+			continue
+		}
+
 		c.handleMessage(&msg)
 	}
 }
@@ -111,6 +131,9 @@ func (c *Client) handleMessage(msg *Message) {
 	msgTypeStr := string(msg.Type)
 
 	switch msgTypeStr {
+	case string(MsgTypeHello):
+		c.handleHello(msg)
+
 	case string(MsgTypeCommand):
 		v2Msg := &Msg{
 			Type:      MsgTypeCommand,
@@ -130,21 +153,89 @@ func (c *Client) handleMessage(msg *Message) {
 		handler.HandleQuery(v2Msg)
 
 	default:
+		// v1 shim: legacy client message types are translated to v2 commands
+		if c.Protocol() == ProtocolV1 {
+			if v2Msg, ok := legacyToV2(msg); ok {
+				if v2Msg.Type == MsgTypeQuery {
+					handler.HandleQuery(v2Msg)
+				} else {
+					handler.HandleCommand(v2Msg)
+				}
+				return
+			}
+		}
 		log.Printf("Unknown message type: %s", msg.Type)
 		c.sendError("UNKNOWN_MESSAGE", "Unknown message type")
 	}
 }
 
+// handleHello negotiates the protocol version from a client's HELLO frame.
+// The version can only change before the client joins a room.
+func (c *Client) handleHello(msg *Message) {
+	var p HelloPayload
+	if err := json.Unmarshal(msg.Payload, &p); err != nil {
+		c.sendError("INVALID_PAYLOAD", "Invalid hello payload")
+		return
+	}
+	if p.Version != ProtocolV1 && p.Version != ProtocolV2 {
+		c.sendError("UNSUPPORTED_PROTOCOL", "Unsupported protocol version")
+		return
+	}
+	if c.Room() != nil {
+		c.sendError("ALREADY_JOINED", "Protocol version must be negotiated before joining a room")
+		return
+	}
+
+	c.mu.Lock()
+	c.protocol = p.Version
+	c.mu.Unlock()
+
+	reply, _ := NewMsg(MsgTypeHello, HelloPayload{Version: p.Version})
+	data, _ := json.Marshal(reply)
+	c.trySend(data)
+}
+
+// Protocol returns the protocol version the client speaks.
+func (c *Client) Protocol() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.protocol
+}
+
+// Room returns the room the client is in, or nil if it isn't in one.
+func (c *Client) Room() *Room {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.room
+}
+
+// setRoom moves the client into a room. Only the hub goroutine calls it.
+func (c *Client) setRoom(room *Room) {
+	c.mu.Lock()
+	c.room = room
+	c.mu.Unlock()
+}
+
 func (c *Client) sendError(code, message string) {
 	msg, _ := NewMessage(MessageTypeError, ErrorPayload{
 		Code:    code,
 		Message: message,
 	})
-	data, _ := json.Marshal(msg)
-	c.trySend(data)
+	c.Send(msg)
 }
 
+// Send encodes msg for the client's protocol version and sends it.
 func (c *Client) Send(msg *Message) {
+	if c.Protocol() == ProtocolV2 {
+		data, err := json.Marshal(toV2(msg))
+		if err != nil {
+			log.Printf("failed to marshal message: %v", err)
+			return
+		}
+		c.trySend(data)
+		return
+	}
+
 	data, err := json.Marshal(msg)
 	if err != nil {
 		log.Printf("failed to marshal message: %v", err)
@@ -171,6 +262,11 @@ func (c *Client) trySend(data []byte) {
 	}
 }
 
+// trySendFrames sends the frame matching the client's protocol version.
+func (c *Client) trySendFrames(f frames) {
+	c.trySend(f.forProtocol(c.Protocol()))
+}
+
 // Close marks the client as closed and closes its send channel.
 // Safe to call multiple times.
 func (c *Client) Close() {
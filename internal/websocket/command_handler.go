@@ -45,6 +45,16 @@ func (ch *CommandHandler) HandleCommand(msg *Msg) {
 		ch.handleProposeEdit(cmd.Payload)
 	case CmdRespondEdit:
 		ch.handleRespondEdit(cmd.Payload)
+	case CmdSuggestChampion:
+		ch.handleSuggestChampion(cmd.Payload)
+	case CmdVoteSuggestion:
+		ch.handleVoteSuggestion(cmd.Payload)
+	case CmdProposeTrade, CmdRespondTrade, CmdAssignPick, CmdFinishTrades:
+		ch.handleTrade(cmd.Action, cmd.Payload)
+	case CmdSendChat:
+		ch.handleSendChat(cmd.Payload)
+	case CmdSendPing:
+		ch.handleSendPing(cmd.Payload)
 	default:
 		log.Printf("Unknown command action: %s", cmd.Action)
 		ch.client.sendError("UNKNOWN_COMMAND", "Unknown command action")
@@ -61,8 +71,11 @@ func (ch *CommandHandler) HandleQuery(msg *Msg) {
 
 	switch query.Query {
 	case QuerySyncState:
-		if ch.client.room != nil {
-			ch.client.room.syncState <- ch.client
+		if room := ch.client.Room(); room != nil {
+			select {
+			case room.syncState <- ch.client:
+			case <-room.done:
+			}
 		}
 	default:
 		ch.client.sendError("UNKNOWN_QUERY", "Unknown query type")
@@ -76,9 +89,10 @@ func (ch *CommandHandler) handleJoinRoom(payload json.RawMessage) {
 		return
 	}
 	ch.client.hub.joinRoom <- &JoinRoomRequest{
-		Client: ch.client,
-		RoomID: p.RoomID,
-		Side:   p.Side,
+		Client:  ch.client,
+		RoomID:  p.RoomID,
+		Side:    p.Side,
+		LastSeq: p.LastSeq,
 	}
 }
 
@@ -88,17 +102,24 @@ func (ch *CommandHandler) handleSelectChampion(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid select champion payload")
 		return
 	}
-	if ch.client.room != nil {
-		ch.client.room.selectChampion <- &SelectChampionRequest{
+	if room := ch.client.Room(); room != nil {
+		req := &SelectChampionRequest{
 			Client:     ch.client,
 			ChampionID: p.ChampionID,
 		}
+		select {
+		case room.selectChampion <- req:
+		case <-room.done:
+		}
 	}
 }
 
 func (ch *CommandHandler) handleLockIn() {
-	if ch.client.room != nil {
-		ch.client.room.lockIn <- ch.client
+	if room := ch.client.Room(); room != nil {
+		select {
+		case room.lockIn <- ch.client:
+		case <-room.done:
+		}
 	}
 }
 
@@ -108,11 +129,15 @@ func (ch *CommandHandler) handleHoverChampion(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid hover champion payload")
 		return
 	}
-	if ch.client.room != nil {
-		ch.client.room.hoverChampion <- &HoverChampionRequest{
+	if room := ch.client.Room(); room != nil {
+		req := &HoverChampionRequest{
 			Client:     ch.client,
 			ChampionID: p.ChampionID,
 		}
+		select {
+		case room.hoverChampion <- req:
+		case <-room.done:
+		}
 	}
 }
 
@@ -122,23 +147,33 @@ func (ch *CommandHandler) handleSetReady(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid set ready payload")
 		return
 	}
-	if ch.client.room != nil {
-		ch.client.room.ready <- &ReadyRequest{
+	if room := ch.client.Room(); room != nil {
+		req := &ReadyRequest{
 			Client: ch.client,
 			Ready:  p.Ready,
 		}
+		select {
+		case room.ready <- req:
+		case <-room.done:
+		}
 	}
 }
 
 func (ch *CommandHandler) handleStartDraft() {
-	if ch.client.room != nil {
-		ch.client.room.startDraft <- ch.client
+	if room := ch.client.Room(); room != nil {
+		select {
+		case room.startDraft <- ch.client:
+		case <-room.done:
+		}
 	}
 }
 
 func (ch *CommandHandler) handlePauseDraft() {
-	if ch.client.room != nil {
-		ch.client.room.pauseDraft <- ch.client
+	if room := ch.client.Room(); room != nil {
+		select {
+		case room.pauseDraft <- ch.client:
+		case <-room.done:
+		}
 	}
 }
 
@@ -148,11 +183,15 @@ func (ch *CommandHandler) handleResumeReady(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid resume ready payload")
 		return
 	}
-	if ch.client.room != nil {
-		ch.client.room.readyToResume <- &ReadyToResumeRequest{
+	if room := ch.client.Room(); room != nil {
+		req := &ReadyToResumeRequest{
 			Client: ch.client,
 			Ready:  p.Ready,
 		}
+		select {
+		case room.readyToResume <- req:
+		case <-room.done:
+		}
 	}
 }
 
@@ -162,8 +201,8 @@ func (ch *CommandHandler) handleProposeEdit(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid propose edit payload")
 		return
 	}
-	if ch.client.room != nil {
-		ch.client.room.proposeEdit <- &ProposeEditRequest{
+	if room := ch.client.Room(); room != nil {
+		req := &ProposeEditRequest{
 			Client: ch.client,
 			Payload: ProposeEditPayload{
 				SlotType:   p.SlotType,
@@ -172,6 +211,10 @@ func (ch *CommandHandler) handleProposeEdit(payload json.RawMessage) {
 				ChampionID: p.ChampionID,
 			},
 		}
+		select {
+		case room.proposeEdit <- req:
+		case <-room.done:
+		}
 	}
 }
 
@@ -181,11 +224,122 @@ func (ch *CommandHandler) handleRespondEdit(payload json.RawMessage) {
 		ch.client.sendError("INVALID_PAYLOAD", "Invalid respond edit payload")
 		return
 	}
-	if ch.client.room != nil {
+	if room := ch.client.Room(); room != nil {
 		if p.Accept {
-			ch.client.room.confirmEdit <- ch.client
+			select {
+			case room.confirmEdit <- ch.client:
+			case <-room.done:
+			}
 		} else {
-			ch.client.room.rejectEdit <- ch.client
+			select {
+			case room.rejectEdit <- ch.client:
+			case <-room.done:
+			}
+		}
+	}
+}
+
+func (ch *CommandHandler) handleSuggestChampion(payload json.RawMessage) {
+	var p CmdSuggestChampionPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		ch.client.sendError("INVALID_PAYLOAD", "Invalid suggest champion payload")
+		return
+	}
+	if room := ch.client.Room(); room != nil {
+		req := &SuggestionRequest{
+			Client:     ch.client,
+			ChampionID: p.ChampionID,
+		}
+		select {
+		case room.suggest <- req:
+		case <-room.done:
+		}
+	}
+}
+
+func (ch *CommandHandler) handleVoteSuggestion(payload json.RawMessage) {
+	var p CmdVoteSuggestionPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		ch.client.sendError("INVALID_PAYLOAD", "Invalid vote suggestion payload")
+		return
+	}
+	if room := ch.client.Room(); room != nil {
+		req := &SuggestionRequest{
+			Client:     ch.client,
+			ChampionID: p.ChampionID,
+			Vote:       p.Vote,
+		}
+		select {
+		case room.voteSuggestion <- req:
+		case <-room.done:
+		}
+	}
+}
+
+func (ch *CommandHandler) handleTrade(action CommandAction, payload json.RawMessage) {
+	req := &TradeRequest{Client: ch.client, Action: action}
+
+	var err error
+	switch action {
+	case CmdProposeTrade:
+		var p CmdProposeTradePayload
+		err = json.Unmarshal(payload, &p)
+		req.ChampionID = p.ChampionID
+	case CmdRespondTrade:
+		var p CmdRespondTradePayload
+		err = json.Unmarshal(payload, &p)
+		req.OfferID, req.Accept = p.OfferID, p.Accept
+	case CmdAssignPick:
+		var p CmdAssignPickPayload
+		err = json.Unmarshal(payload, &p)
+		req.ChampionID, req.UserID, req.Role = p.ChampionID, p.UserID, p.Role
+	}
+	if err != nil {
+		ch.client.sendError("INVALID_PAYLOAD", "Invalid trade payload")
+		return
+	}
+
+	if room := ch.client.Room(); room != nil {
+		select {
+		case room.trade <- req:
+		case <-room.done:
+		}
+	}
+}
+
+func (ch *CommandHandler) handleSendChat(payload json.RawMessage) {
+	var p CmdSendChatPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		ch.client.sendError("INVALID_PAYLOAD", "Invalid chat payload")
+		return
+	}
+	if room := ch.client.Room(); room != nil {
+		req := &ChatRequest{
+			Client:  ch.client,
+			Channel: p.Channel,
+			Body:    p.Body,
+		}
+		select {
+		case room.chat <- req:
+		case <-room.done:
+		}
+	}
+}
+
+func (ch *CommandHandler) handleSendPing(payload json.RawMessage) {
+	var p CmdSendPingPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		ch.client.sendError("INVALID_PAYLOAD", "Invalid ping payload")
+		return
+	}
+	if room := ch.client.Room(); room != nil {
+		req := &ChatRequest{
+			Client: ch.client,
+			Ping:   &p,
+		}
+		select {
+		case room.chat <- req:
+		case <-room.done:
 		}
 	}
 }
@@ -1,10 +1,15 @@
 package websocket_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/dom/league-draft-website/internal/websocket"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,7 +32,7 @@ func TestDraftFlow_JoinRoom(t *testing.T) {
 	testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect WebSocket client
-	wsClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
+	wsClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
 
 	// Join room
 	wsClient.JoinRoom(room.ID.String(), "blue")
@@ -57,8 +62,8 @@ func TestDraftFlow_ReadyUp(t *testing.T) {
 	testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect both players
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	// Join room
 	blueClient.JoinRoom(room.ID.String(), "blue")
@@ -111,8 +116,8 @@ func TestDraftFlow_StartDraft(t *testing.T) {
 	testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect and join
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -164,8 +169,8 @@ func TestDraftFlow_SelectAndLockIn(t *testing.T) {
 	champions := testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect and join
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -234,8 +239,8 @@ func TestDraftFlow_WrongTurnError(t *testing.T) {
 	champions := testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect and join
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -286,8 +291,8 @@ func TestDraftFlow_ChampionUnavailable(t *testing.T) {
 	champions := testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect and start draft
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -348,8 +353,8 @@ func TestDraftFlow_NoSelectionError(t *testing.T) {
 	testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect and start draft
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -408,8 +413,8 @@ func TestDraftFlow_FullDraft(t *testing.T) {
 	require.GreaterOrEqual(t, len(champions), 20, "need at least 20 champions for full draft")
 
 	// Connect and start draft
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -505,8 +510,8 @@ func TestDraftFlow_SpectatorView(t *testing.T) {
 	champions := testutil.SeedRealChampions(t, ts.DB.DB)
 
 	// Connect players
-	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(blueToken))
-	redClient := testutil.NewWSClient(t, ts.WebSocketURL(redToken))
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
 
 	blueClient.JoinRoom(room.ID.String(), "blue")
 	blueClient.ExpectStateSync(defaultTimeout)
@@ -515,7 +520,7 @@ func TestDraftFlow_SpectatorView(t *testing.T) {
 	redClient.ExpectStateSync(defaultTimeout)
 
 	// Connect spectator
-	spectatorClient := testutil.NewWSClient(t, ts.WebSocketURL(spectatorToken))
+	spectatorClient := testutil.NewWSClient(t, ts.WebSocketURL(t, spectatorToken))
 	spectatorClient.JoinRoom(room.ID.String(), "spectator")
 
 	// Spectator receives state sync
@@ -558,3 +563,931 @@ func TestDraftFlow_SpectatorView(t *testing.T) {
 	redClient.ExpectPhaseChanged(defaultTimeout)
 	spectatorClient.ExpectPhaseChanged(defaultTimeout)
 }
+
+func TestDraftFlow_SpectatorDelay(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+	_, spectatorToken := testutil.NewUserBuilder().
+		WithDisplayName("spectator").
+		BuildAndAuthenticate(t, ts)
+
+	const delay = 2 * time.Second
+	room := testutil.NewRoomBuilder().
+		WithSpectatorDelay(int(delay/time.Second)).
+		BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+	spectatorClient := testutil.NewWSClient(t, ts.WebSocketURL(t, spectatorToken))
+
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	blueClient.ExpectStateSync(defaultTimeout)
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectStateSync(defaultTimeout)
+	spectatorClient.JoinRoom(room.ID.String(), "spectator")
+	spectatorClient.ExpectStateSync(defaultTimeout)
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+	redClient.ExpectDraftStarted(defaultTimeout)
+
+	// The spectator sees the draft start only after the delay
+	spectatorClient.ExpectDraftStarted(delay + defaultTimeout)
+
+	blueClient.SelectChampion(champions[0].ID)
+	redClient.ExpectMessage(websocket.MessageTypeChampionHovered, defaultTimeout)
+	blueClient.LockIn()
+	redClient.ExpectChampionSelected(defaultTimeout)
+	lockedAt := time.Now()
+
+	// A state sync taken now still shows the draft before the ban
+	spectatorClient.SyncState()
+	stateSync := spectatorClient.ExpectStateSync(defaultTimeout)
+	assert.Empty(t, stateSync.Draft.BlueBans)
+
+	// The ban arrives after the delay, and the hover before it never does
+	for {
+		msg := spectatorClient.ExpectAnyMessage(delay + defaultTimeout)
+		require.NotEqual(t, websocket.MessageTypeChampionHovered, msg.Type)
+		if msg.Type == websocket.MessageTypeChampionSelected {
+			break
+		}
+	}
+	assert.GreaterOrEqual(t, time.Since(lockedAt), delay-200*time.Millisecond)
+
+	spectatorClient.SyncState()
+	stateSync = spectatorClient.ExpectStateSync(defaultTimeout)
+	assert.Equal(t, []string{champions[0].ID}, stateSync.Draft.BlueBans)
+}
+func TestDraftFlow_FearlessBans(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	// Game 1 of the series picked the first champion
+	seriesID := uuid.New()
+	require.NoError(t, ts.Repos.FearlessBan.Create(context.Background(), &domain.FearlessBan{
+		ID:           uuid.New(),
+		SeriesID:     seriesID,
+		ChampionID:   champions[0].ID,
+		BannedInGame: 1,
+		PickedByTeam: domain.SideBlue,
+	}))
+
+	// Create game 2 of the series
+	room := testutil.NewRoomBuilder().
+		WithDraftMode(domain.DraftModeFearless).
+		WithSeries(seriesID, 2).
+		BuildWithHub(t, ts)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	// State sync exposes the locked-out champions
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+	assert.Equal(t, "fearless", stateSync.Room.DraftMode)
+	assert.Equal(t, []string{champions[0].ID}, stateSync.FearlessBans)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectStateSync(defaultTimeout)
+
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+	blueClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+	redClient.ExpectDraftStarted(defaultTimeout)
+
+	// Blue tries to select the champion played in game 1
+	blueClient.SelectChampion(champions[0].ID)
+
+	errorPayload := blueClient.ExpectErrorWithCode("CHAMPION_UNAVAILABLE", defaultTimeout)
+	assert.Contains(t, errorPayload.Message, "earlier in this series")
+}
+
+func TestDraftFlow_BlindPickTemplate(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	template, err := ts.Services.DraftTemplate.GetTemplate(context.Background(), domain.DraftTemplateBlindPick)
+	require.NoError(t, err)
+
+	room := testutil.NewRoomBuilder().
+		WithDraftTemplate(template.ID).
+		BuildWithHub(t, ts)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	// State sync describes the template's phases
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+	require.NotNil(t, stateSync.Template)
+	assert.Equal(t, domain.DraftTemplateBlindPick, stateSync.Template.Name)
+	assert.Equal(t, "blind", stateSync.Template.Kind)
+	require.Len(t, stateSync.Template.Phases, 10)
+	assert.Equal(t, "pick", stateSync.Template.Phases[0].ActionType)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectStateSync(defaultTimeout)
+
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+	blueClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	started := blueClient.ExpectDraftStarted(defaultTimeout)
+	assert.Equal(t, "pick", started.ActionType)
+	redClient.ExpectDraftStarted(defaultTimeout)
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	// Blue's first pick is only visible to blue
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+
+	selected := blueClient.ExpectChampionSelected(defaultTimeout)
+	assert.Equal(t, champions[0].ID, selected.ChampionID)
+
+	hidden := redClient.ExpectChampionSelected(defaultTimeout)
+	assert.Equal(t, websocket.HiddenChampionID, hidden.ChampionID)
+
+	// Red can still pick the same champion blind
+	redClient.ExpectPhaseChanged(defaultTimeout)
+	redClient.SelectChampion(champions[0].ID)
+	msg := redClient.ExpectMessage(websocket.MessageTypeChampionHovered, defaultTimeout)
+	var hover websocket.ChampionHoveredPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &hover))
+	require.NotNil(t, hover.ChampionID)
+	assert.Equal(t, champions[0].ID, *hover.ChampionID)
+}
+
+func TestDraftFlow_RestoreAfterRestart(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ctx := context.Background()
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	blueClient.ExpectStateSync(defaultTimeout)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectStateSync(defaultTimeout)
+
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+	blueClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+	redClient.ExpectDraftStarted(defaultTimeout)
+
+	// Blue bans one champion
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+	blueClient.ExpectChampionSelected(defaultTimeout)
+	blueClient.ExpectPhaseChanged(defaultTimeout)
+
+	// Wait for the ban and in-progress status to reach the database
+	require.Eventually(t, func() bool {
+		saved, err := ts.Repos.DraftState.GetByRoomID(ctx, room.ID)
+		if err != nil || saved.CurrentPhase != 1 || saved.TimerDeadline == nil {
+			return false
+		}
+		stored, err := ts.Repos.Room.GetByID(ctx, room.ID)
+		return err == nil && stored.Status == domain.RoomStatusInProgress
+	}, defaultTimeout, 50*time.Millisecond)
+
+	blueClient.Close()
+	redClient.Close()
+
+	ts.Restart(t)
+	require.NotNil(t, ts.Hub.GetRoom(room.ID.String()))
+
+	// Reconnecting client gets the draft exactly where it left off
+	blueClient = testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+
+	assert.Equal(t, "in_progress", stateSync.Room.Status)
+	assert.Equal(t, 1, stateSync.Draft.CurrentPhase)
+	assert.Equal(t, "red", stateSync.Draft.CurrentTeam)
+	assert.Equal(t, []string{champions[0].ID}, stateSync.Draft.BlueBans)
+	assert.Greater(t, stateSync.Draft.TimerRemainingMs, 0)
+	assert.LessOrEqual(t, stateSync.Draft.TimerRemainingMs, room.TimerDurationSeconds*1000)
+}
+
+func TestDraftFlow_ReserveTime(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	// 1 second phase timer backed by a 2 second reserve per side
+	room := testutil.NewRoomBuilder().
+		WithTimerDuration(1).
+		WithReserveTime(2).
+		BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+	require.NotNil(t, stateSync.Draft.Reserve)
+	assert.Equal(t, 2000, stateSync.Draft.Reserve.BlueMs)
+	assert.Equal(t, 2000, stateSync.Draft.Reserve.RedMs)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectStateSync(defaultTimeout)
+
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+	blueClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+
+	// Once the phase timer runs out, ticks report blue drawing on its reserve
+	var reserve *websocket.ReserveInfo
+	for reserve == nil || !reserve.Active {
+		msg := blueClient.ExpectMessage(websocket.MessageTypeTimerTick, defaultTimeout)
+		var tick websocket.TimerTickPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &tick))
+		require.NotNil(t, tick.Reserve)
+		require.False(t, tick.IsBufferPeriod)
+		reserve = tick.Reserve
+	}
+	assert.Less(t, reserve.BlueMs, 2000)
+	assert.Equal(t, 2000, reserve.RedMs)
+
+	// Locking in during the reserve keeps what is left of it
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+	blueClient.ExpectChampionSelected(defaultTimeout)
+	blueClient.ExpectPhaseChanged(defaultTimeout)
+
+	blueClient.SyncState()
+	stateSync = blueClient.ExpectStateSync(defaultTimeout)
+	require.NotNil(t, stateSync.Draft.Reserve)
+	assert.Less(t, stateSync.Draft.Reserve.BlueMs, 2000)
+	assert.Greater(t, stateSync.Draft.Reserve.BlueMs, 0)
+	assert.Equal(t, 2000, stateSync.Draft.Reserve.RedMs)
+}
+
+func TestDraftFlow_ReconnectReplaysMissedEvents(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	blueClient.ExpectStateSync(defaultTimeout)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	syncMsg := redClient.ExpectMessage(websocket.MessageTypeStateSync, defaultTimeout)
+	assert.NotZero(t, syncMsg.Seq, "state sync should carry the current event seq")
+
+	blueClient.DrainMessages()
+	redClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	redClient.Ready(true)
+	redClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+	blueClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+	started := redClient.ExpectMessage(websocket.MessageTypeDraftStarted, defaultTimeout)
+	require.Greater(t, started.Seq, syncMsg.Seq)
+
+	// Red drops while blue bans
+	redClient.Close()
+
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+	selected := blueClient.ExpectMessage(websocket.MessageTypeChampionSelected, defaultTimeout)
+	blueClient.ExpectPhaseChanged(defaultTimeout)
+
+	t.Run("replays events after last seq", func(t *testing.T) {
+		redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+		defer redClient.Close()
+
+		redClient.RejoinRoom(room.ID.String(), "red", started.Seq)
+
+		msg := redClient.SkipUntilMessageType(websocket.MessageTypeChampionSelected, defaultTimeout)
+		assert.Equal(t, selected.Seq, msg.Seq)
+
+		var payload websocket.ChampionSelectedPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		assert.Equal(t, champions[0].ID, payload.ChampionID)
+
+		phase := redClient.ExpectMessage(websocket.MessageTypePhaseChanged, defaultTimeout)
+		assert.Greater(t, phase.Seq, msg.Seq)
+
+		// The replay replaces the full sync: next comes the join notification
+		next := redClient.ExpectAnyMessage(defaultTimeout)
+		for next.Type == websocket.MessageTypeTimerTick {
+			next = redClient.ExpectAnyMessage(defaultTimeout)
+		}
+		assert.Equal(t, websocket.MessageTypePlayerUpdate, next.Type)
+	})
+
+	t.Run("falls back to state sync when gap is too large", func(t *testing.T) {
+		redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+		defer redClient.Close()
+
+		redClient.RejoinRoom(room.ID.String(), "red", 1)
+
+		stateSync := redClient.ExpectStateSync(defaultTimeout)
+		assert.Equal(t, []string{champions[0].ID}, stateSync.Draft.BlueBans)
+	})
+}
+
+func TestDraftFlow_ProtocolV2(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	// Create users
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	// Blue opts into v2 with the query parameter, red negotiates with a HELLO frame
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken)+"&v=2")
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+
+	redClient.Hello(websocket.ProtocolV2)
+	hello := redClient.ExpectMessage(websocket.MessageType(websocket.MsgTypeHello), defaultTimeout)
+	var helloPayload websocket.HelloPayload
+	require.NoError(t, json.Unmarshal(hello.Payload, &helloPayload))
+	assert.Equal(t, websocket.ProtocolV2, helloPayload.Version)
+
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	state := blueClient.ExpectState(defaultTimeout)
+	assert.Equal(t, "blue", state.Client.YourSide)
+	assert.Equal(t, "1v1", state.Players.Mode)
+	require.NotNil(t, state.Draft.CurrentTeam)
+	assert.Equal(t, "blue", *state.Draft.CurrentTeam)
+
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectState(defaultTimeout)
+	blueClient.ExpectEvent(websocket.EvtPlayerJoined, defaultTimeout)
+
+	blueClient.Ready(true)
+	redClient.ExpectEvent(websocket.EvtPlayerReadyChanged, defaultTimeout)
+	redClient.Ready(true)
+	for {
+		evt := blueClient.ExpectEvent(websocket.EvtPlayerReadyChanged, defaultTimeout)
+		var ready websocket.EvtPlayerReadyChangedPayload
+		require.NoError(t, json.Unmarshal(evt.Payload, &ready))
+		if ready.Side == "red" {
+			assert.True(t, ready.Ready)
+			break
+		}
+	}
+
+	blueClient.StartDraft()
+	evt := blueClient.ExpectEvent(websocket.EvtDraftStarted, defaultTimeout)
+	var started websocket.EvtDraftStartedPayload
+	require.NoError(t, json.Unmarshal(evt.Payload, &started))
+	assert.Equal(t, "blue", started.Phase.CurrentTeam)
+	assert.Equal(t, "ban", started.Phase.ActionType)
+
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+	evt = redClient.ExpectEvent(websocket.EvtChampionSelected, defaultTimeout)
+	var selected websocket.EvtChampionSelectedPayload
+	require.NoError(t, json.Unmarshal(evt.Payload, &selected))
+	assert.Equal(t, champions[0].ID, selected.Selection.ChampionID)
+
+	// Errors come back as ERR envelopes
+	blueClient.LockIn()
+	errMsg := blueClient.ExpectMessage(websocket.MessageType(websocket.MsgTypeErr), defaultTimeout)
+	var errPayload websocket.Err
+	require.NoError(t, json.Unmarshal(errMsg.Payload, &errPayload))
+	assert.NotEmpty(t, errPayload.Code)
+
+	// Timer ticks arrive as TIMER envelopes
+	blueClient.ExpectMessage(websocket.MessageType(websocket.MsgTypeTimer), defaultTimeout)
+}
+
+func TestDraftFlow_LegacyClientMessages(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+
+	// v1 clients can still send the legacy message types
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	blueClient.SendLegacy(websocket.MessageTypeJoinRoom, websocket.JoinRoomPayload{
+		RoomID: room.ID.String(),
+		Side:   "blue",
+	})
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+	assert.Equal(t, "blue", stateSync.YourSide)
+
+	blueClient.SendLegacy(websocket.MessageTypeReady, websocket.ReadyPayload{Ready: true})
+	update := blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	require.NotNil(t, update.Player)
+	assert.True(t, update.Player.Ready)
+
+	blueClient.SendLegacy(websocket.MessageTypeSyncState, nil)
+	blueClient.ExpectStateSync(defaultTimeout)
+}
+
+func TestDraftFlow_BotSide(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+
+	_, redToken := testutil.NewUserBuilder().
+		WithDisplayName("redPlayer").
+		BuildAndAuthenticate(t, ts)
+
+	// Practice room with the bot drafting red
+	room := testutil.NewRoomBuilder().
+		WithBotSide(domain.SideRed).
+		BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	blueClient.JoinRoom(room.ID.String(), "blue")
+	stateSync := blueClient.ExpectStateSync(defaultTimeout)
+	require.NotNil(t, stateSync.Players.Red)
+	assert.Equal(t, "Bot", stateSync.Players.Red.DisplayName)
+	assert.True(t, stateSync.Players.Red.Ready)
+
+	// The bot's side cannot be taken by a player
+	redClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redToken))
+	redClient.JoinRoom(room.ID.String(), "red")
+	redClient.ExpectErrorWithCode("SIDE_TAKEN", defaultTimeout)
+
+	blueClient.DrainMessages()
+
+	blueClient.Ready(true)
+	blueClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+
+	blueClient.StartDraft()
+	blueClient.ExpectDraftStarted(defaultTimeout)
+
+	// Blue bans, then the bot bans for red without any input
+	blueClient.SelectChampion(champions[0].ID)
+	blueClient.LockIn()
+	selected := blueClient.ExpectChampionSelected(defaultTimeout)
+	assert.Equal(t, "blue", selected.Team)
+	blueClient.ExpectPhaseChanged(defaultTimeout)
+
+	selected = blueClient.ExpectChampionSelected(defaultTimeout)
+	assert.Equal(t, "red", selected.Team)
+	assert.Equal(t, "ban", selected.ActionType)
+	assert.NotEqual(t, champions[0].ID, selected.ChampionID)
+
+	phase := blueClient.ExpectPhaseChanged(defaultTimeout)
+	assert.Equal(t, 2, phase.CurrentPhase)
+}
+
+func TestDraftFlow_TeamSuggestions(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	blueCaptain, blueCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("blueCaptain").
+		BuildAndAuthenticate(t, ts)
+	blueMember, blueMemberToken := testutil.NewUserBuilder().
+		WithDisplayName("blueMember").
+		BuildAndAuthenticate(t, ts)
+	blueSupport, blueSupportToken := testutil.NewUserBuilder().
+		WithDisplayName("blueSupport").
+		BuildAndAuthenticate(t, ts)
+	redCaptain, redCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("redCaptain").
+		BuildAndAuthenticate(t, ts)
+	_, spectatorToken := testutil.NewUserBuilder().
+		WithDisplayName("spectator").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(blueMember, domain.SideBlue, domain.RoleMid, false).
+		WithTeamPlayer(blueSupport, domain.SideBlue, domain.RoleSupport, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueCaptainToken))
+	blueMemberClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueMemberToken))
+	blueSupportClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueSupportToken))
+	redCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redCaptainToken))
+	spectatorClient := testutil.NewWSClient(t, ts.WebSocketURL(t, spectatorToken))
+
+	for _, client := range []*testutil.WSClient{blueCaptainClient, blueMemberClient, blueSupportClient, redCaptainClient, spectatorClient} {
+		client.JoinRoom(room.ID.String(), "")
+		client.ExpectStateSync(defaultTimeout)
+	}
+
+	blueCaptainClient.Ready(true)
+	blueCaptainClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redCaptainClient.Ready(true)
+	redCaptainClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueCaptainClient.StartDraft()
+	for _, client := range []*testutil.WSClient{blueCaptainClient, blueMemberClient, blueSupportClient, redCaptainClient, spectatorClient} {
+		client.SkipUntilMessageType(websocket.MessageTypeDraftStarted, defaultTimeout)
+	}
+
+	// Captains draft rather than suggest
+	blueCaptainClient.SuggestChampion(champions[0].ID)
+	blueCaptainClient.ExpectErrorWithCode("UNAUTHORIZED", defaultTimeout)
+
+	// Each teammate suggests a champion
+	blueMemberClient.SuggestChampion(champions[0].ID)
+	blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeSuggestionsUpdated, defaultTimeout)
+	blueSupportClient.SuggestChampion(champions[1].ID)
+
+	msg := blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeSuggestionsUpdated, defaultTimeout)
+	var updated websocket.SuggestionsUpdatedPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+	assert.Equal(t, "blue", updated.Side)
+	require.Len(t, updated.Suggestions, 2)
+	assert.Equal(t, champions[0].ID, updated.Suggestions[0].ChampionID)
+	assert.Equal(t, "blueMember", updated.Suggestions[0].SuggestedByName)
+	assert.Equal(t, champions[1].ID, updated.Suggestions[1].ChampionID)
+
+	// Backing the first suggestion moves the voter's vote off their own, which nobody backs any more
+	blueSupportClient.VoteSuggestion(champions[0].ID, true)
+
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeSuggestionsUpdated, defaultTimeout)
+	require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+	require.Len(t, updated.Suggestions, 1)
+	assert.Equal(t, champions[0].ID, updated.Suggestions[0].ChampionID)
+	assert.Equal(t, 2, updated.Suggestions[0].Votes)
+
+	// Suggesting something else only withdraws the suggester's vote, so teammates' votes stay
+	blueMemberClient.SuggestChampion(champions[2].ID)
+
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeSuggestionsUpdated, defaultTimeout)
+	require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+	require.Len(t, updated.Suggestions, 2)
+	assert.Equal(t, champions[0].ID, updated.Suggestions[0].ChampionID)
+	assert.Equal(t, 1, updated.Suggestions[0].Votes)
+	assert.Equal(t, champions[2].ID, updated.Suggestions[1].ChampionID)
+	assert.Equal(t, 1, updated.Suggestions[1].Votes)
+
+	// A member who only votes backs one suggestion at a time too
+	blueSupportClient.VoteSuggestion(champions[2].ID, true)
+
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeSuggestionsUpdated, defaultTimeout)
+	require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+	require.Len(t, updated.Suggestions, 1)
+	assert.Equal(t, champions[2].ID, updated.Suggestions[0].ChampionID)
+	assert.Equal(t, 2, updated.Suggestions[0].Votes)
+
+	// The captain's state sync ranks them too
+	blueCaptainClient.SyncState()
+	stateSync := blueCaptainClient.ExpectStateSync(defaultTimeout)
+	require.Len(t, stateSync.Suggestions, 1)
+	assert.Equal(t, champions[2].ID, stateSync.Suggestions[0].ChampionID)
+
+	// Neither the other team nor spectators see suggestions
+	for _, client := range []*testutil.WSClient{redCaptainClient, spectatorClient} {
+		client.SyncState()
+		for {
+			msg := client.ExpectAnyMessage(defaultTimeout)
+			require.NotEqual(t, websocket.MessageTypeSuggestionsUpdated, msg.Type)
+			if msg.Type == websocket.MessageTypeStateSync {
+				var sync websocket.StateSyncPayload
+				require.NoError(t, json.Unmarshal(msg.Payload, &sync))
+				assert.Empty(t, sync.Suggestions)
+				break
+			}
+		}
+	}
+}
+
+func TestDraftFlow_TeamChat(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	blueCaptain, blueCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("blueCaptain").
+		BuildAndAuthenticate(t, ts)
+	blueMember, blueMemberToken := testutil.NewUserBuilder().
+		WithDisplayName("blueMember").
+		BuildAndAuthenticate(t, ts)
+	redCaptain, redCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("redCaptain").
+		BuildAndAuthenticate(t, ts)
+	_, spectatorToken := testutil.NewUserBuilder().
+		WithDisplayName("spectator").
+		BuildAndAuthenticate(t, ts)
+
+	room := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(blueMember, domain.SideBlue, domain.RoleMid, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueCaptainToken))
+	blueMemberClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueMemberToken))
+	redCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redCaptainToken))
+	spectatorClient := testutil.NewWSClient(t, ts.WebSocketURL(t, spectatorToken))
+
+	for _, client := range []*testutil.WSClient{blueCaptainClient, blueMemberClient, redCaptainClient, spectatorClient} {
+		client.JoinRoom(room.ID.String(), "")
+		client.ExpectStateSync(defaultTimeout)
+	}
+
+	// Spectators only read the all channel
+	spectatorClient.SendChat("all", "go blue")
+	spectatorClient.ExpectErrorWithCode("UNAUTHORIZED", defaultTimeout)
+
+	blueMemberClient.SendChat("team", "ban their jungler")
+	blueMemberClient.PingChampion("team", champions[0].ID)
+	blueMemberClient.SendChat("all", "glhf")
+
+	msg := blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeChatMessage, defaultTimeout)
+	var chat websocket.RoomChatMessagePayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &chat))
+	assert.Equal(t, "ban their jungler", chat.Message.Body)
+	assert.Equal(t, "blueMember", chat.Message.DisplayName)
+	require.NotNil(t, chat.Message.Team)
+	assert.Equal(t, "blue", *chat.Message.Team)
+
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypePingMarker, defaultTimeout)
+	var ping websocket.PingMarkerPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &ping))
+	assert.Equal(t, champions[0].ID, ping.ChampionID)
+	assert.Equal(t, "blue", ping.Side)
+
+	// The other team and spectators only get the all channel message
+	for _, client := range []*testutil.WSClient{redCaptainClient, spectatorClient} {
+		for {
+			msg := client.ExpectAnyMessage(defaultTimeout)
+			require.NotEqual(t, websocket.MessageTypePingMarker, msg.Type)
+			if msg.Type == websocket.MessageTypeChatMessage {
+				require.NoError(t, json.Unmarshal(msg.Payload, &chat))
+				assert.Equal(t, "glhf", chat.Message.Body)
+				assert.Nil(t, chat.Message.Team)
+				break
+			}
+		}
+
+		client.SyncState()
+		stateSync := client.ExpectStateSync(defaultTimeout)
+		require.Len(t, stateSync.Chat, 1)
+		assert.Equal(t, "glhf", stateSync.Chat[0].Body)
+	}
+
+	blueCaptainClient.SyncState()
+	stateSync := blueCaptainClient.ExpectStateSync(defaultTimeout)
+	require.Len(t, stateSync.Chat, 2)
+	assert.Equal(t, "ban their jungler", stateSync.Chat[0].Body)
+}
+
+func TestDraftFlow_TradePhase(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	blueCaptain, blueCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("blueCaptain").
+		BuildAndAuthenticate(t, ts)
+	blueMember, blueMemberToken := testutil.NewUserBuilder().
+		WithDisplayName("blueMember").
+		BuildAndAuthenticate(t, ts)
+	redCaptain, redCaptainToken := testutil.NewUserBuilder().
+		WithDisplayName("redCaptain").
+		BuildAndAuthenticate(t, ts)
+
+	// Every pick needs a player, so both teams are full
+	builder := testutil.NewRoomBuilder().
+		WithTeamPlayer(blueCaptain, domain.SideBlue, domain.RoleTop, true).
+		WithTeamPlayer(blueMember, domain.SideBlue, domain.RoleJungle, false).
+		WithTeamPlayer(redCaptain, domain.SideRed, domain.RoleTop, true).
+		WithTradePhase(60)
+	for _, role := range []domain.Role{domain.RoleMid, domain.RoleADC, domain.RoleSupport} {
+		bluePlayer, _ := testutil.NewUserBuilder().Build(t, ts.DB.DB)
+		builder = builder.WithTeamPlayer(bluePlayer, domain.SideBlue, role, false)
+	}
+	for _, role := range []domain.Role{domain.RoleJungle, domain.RoleMid, domain.RoleADC, domain.RoleSupport} {
+		redPlayer, _ := testutil.NewUserBuilder().Build(t, ts.DB.DB)
+		builder = builder.WithTeamPlayer(redPlayer, domain.SideRed, role, false)
+	}
+	room := builder.BuildWithHub(t, ts)
+	champions := testutil.SeedRealChampions(t, ts.DB.DB)
+
+	blueCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueCaptainToken))
+	blueMemberClient := testutil.NewWSClient(t, ts.WebSocketURL(t, blueMemberToken))
+	redCaptainClient := testutil.NewWSClient(t, ts.WebSocketURL(t, redCaptainToken))
+
+	for _, client := range []*testutil.WSClient{blueCaptainClient, blueMemberClient, redCaptainClient} {
+		client.JoinRoom(room.ID.String(), "")
+		client.ExpectStateSync(defaultTimeout)
+	}
+
+	blueCaptainClient.Ready(true)
+	blueCaptainClient.ExpectPlayerUpdateForSide("blue", defaultTimeout)
+	redCaptainClient.Ready(true)
+	redCaptainClient.ExpectPlayerUpdateForSide("red", defaultTimeout)
+
+	blueCaptainClient.StartDraft()
+	blueCaptainClient.ExpectDraftStarted(defaultTimeout)
+	redCaptainClient.ExpectDraftStarted(defaultTimeout)
+
+	phaseTeams := []string{
+		"blue", "red", "blue", "red", "blue", "red",
+		"blue", "red", "red", "blue", "blue", "red",
+		"red", "blue", "red", "blue",
+		"red", "blue", "blue", "red",
+	}
+	for phase, team := range phaseTeams {
+		activeClient := blueCaptainClient
+		if team == "red" {
+			activeClient = redCaptainClient
+		}
+		activeClient.SelectChampion(champions[phase].ID)
+		activeClient.LockIn()
+
+		blueCaptainClient.ExpectChampionSelected(defaultTimeout)
+		redCaptainClient.ExpectChampionSelected(defaultTimeout)
+		if phase < len(phaseTeams)-1 {
+			blueCaptainClient.ExpectPhaseChanged(defaultTimeout)
+			redCaptainClient.ExpectPhaseChanged(defaultTimeout)
+		}
+	}
+
+	// The last pick opens trading instead of completing the draft
+	blueCaptainClient.ExpectMessage(websocket.MessageTypeTradePhaseStarted, defaultTimeout)
+	msg := blueMemberClient.SkipUntilMessageType(websocket.MessageTypeTradeUpdated, defaultTimeout)
+	var updated websocket.TradeUpdatedPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+	assert.Equal(t, "blue", updated.Side)
+	require.Len(t, updated.Assignments, 5)
+
+	// Players are paired with picks in role order: top gets the first pick, jungle the second
+	assert.Equal(t, champions[6].ID, updated.Assignments[0].ChampionID)
+	assert.Equal(t, blueCaptain.ID.String(), updated.Assignments[0].UserID)
+	assert.Equal(t, champions[9].ID, updated.Assignments[1].ChampionID)
+	assert.Equal(t, blueMember.ID.String(), updated.Assignments[1].UserID)
+
+	// The member offers their champion for the captain's
+	blueMemberClient.ProposeTrade(champions[6].ID)
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeTradeUpdated, defaultTimeout)
+	for {
+		require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+		if len(updated.Offers) > 0 {
+			break
+		}
+		msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeTradeUpdated, defaultTimeout)
+	}
+	require.Len(t, updated.Offers, 1)
+	assert.Equal(t, blueCaptain.ID.String(), updated.Offers[0].ToUserID)
+
+	blueCaptainClient.RespondTrade(updated.Offers[0].ID, true)
+	msg = blueMemberClient.SkipUntilMessageType(websocket.MessageTypeTradeUpdated, defaultTimeout)
+	for {
+		require.NoError(t, json.Unmarshal(msg.Payload, &updated))
+		if len(updated.Offers) == 0 && updated.Assignments[0].ChampionID == champions[9].ID {
+			break
+		}
+		msg = blueMemberClient.SkipUntilMessageType(websocket.MessageTypeTradeUpdated, defaultTimeout)
+	}
+	assert.Equal(t, blueCaptain.ID.String(), updated.Assignments[0].UserID)
+	assert.Equal(t, champions[6].ID, updated.Assignments[1].ChampionID)
+	assert.Equal(t, blueMember.ID.String(), updated.Assignments[1].UserID)
+
+	// Only captains can end trading
+	blueMemberClient.FinishTrades()
+	blueMemberClient.ExpectErrorWithCode("UNAUTHORIZED", defaultTimeout)
+
+	// The other team hears when one is done
+	blueCaptainClient.FinishTrades()
+	msg = redCaptainClient.SkipUntilMessageType(websocket.MessageTypeTradeFinished, defaultTimeout)
+	var finished websocket.TradeFinishedPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &finished))
+	assert.Equal(t, "blue", finished.Side)
+	assert.False(t, finished.PhaseEnded)
+
+	redCaptainClient.FinishTrades()
+	msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeTradeFinished, defaultTimeout)
+	require.NoError(t, json.Unmarshal(msg.Payload, &finished))
+	for finished.Side != "red" {
+		msg = blueCaptainClient.SkipUntilMessageType(websocket.MessageTypeTradeFinished, defaultTimeout)
+		require.NoError(t, json.Unmarshal(msg.Payload, &finished))
+	}
+	assert.True(t, finished.PhaseEnded)
+
+	completed := blueCaptainClient.ExpectDraftCompleted(defaultTimeout)
+	require.Len(t, completed.BlueAssignments, 5)
+	assert.Equal(t, champions[9].ID, completed.BlueAssignments[0].ChampionID)
+	assert.Equal(t, blueCaptain.ID.String(), completed.BlueAssignments[0].UserID)
+	assert.Equal(t, champions[6].ID, completed.BlueAssignments[1].ChampionID)
+	assert.Equal(t, blueMember.ID.String(), completed.BlueAssignments[1].UserID)
+	require.Len(t, completed.RedAssignments, 5)
+	assert.Equal(t, redCaptain.ID.String(), completed.RedAssignments[0].UserID)
+}
@@ -2,12 +2,25 @@ package websocket
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log"
 	"math/rand"
 	"time"
 
 	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// botTurnDelay is how long the bot waits before making its selection
+	botTurnDelay = 1 * time.Second
+	// opponentHistorySize is how many recent picks by the opponent the bot considers when banning
+	opponentHistorySize = 100
+	// fearlessBanRetries is how many more times a game's fearless bans are saved after a failure
+	fearlessBanRetries = 3
 )
 
 // DraftState holds the current state of the draft.
@@ -30,12 +43,36 @@ type DraftStateManager struct {
 	championRepo    repository.ChampionRepository
 	roomRepo        repository.RoomRepository
 	draftActionRepo repository.DraftActionRepository
+	fearlessBanRepo repository.FearlessBanRepository
+	templateRepo    repository.DraftTemplateRepository
 	timerDuration   int
 	room            *Room
+
+	// Series context, loaded from the Room entity
+	draftMode    domain.DraftMode
+	seriesID     *uuid.UUID
+	gameNumber   int
+	fearlessBans []string // champions locked out by earlier games in a fearless series
+
+	// Template context, loaded from the room's draft template
+	templateName  string
+	templateKind  domain.DraftTemplateKind
+	poolSize      int
+	phases        []domain.Phase
+	championPools map[string][]string // side -> champions offered in a random pool draft
+
+	// Bot context, loaded from the Room entity
+	drafter       Drafter
+	botSide       string                    // side drafted by the bot, empty if none
+	botOnTimeout  bool                      // timed-out turns are drafted by the bot instead of at random
+	opponentPicks map[string]map[string]int // side -> champion pick counts of that side's opponent, loaded before the bot's first turn
+	botChampions  []*domain.Champion        // champions the bot drafts from, loaded before its first turn
+
+	tradePhaseMs int // post-draft trade phase length in team drafts, 0 if disabled
 }
 
 // NewDraftStateManager creates a new draft state manager.
-func NewDraftStateManager(room *Room, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository, timerDuration int) *DraftStateManager {
+func NewDraftStateManager(room *Room, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository, fearlessBanRepo repository.FearlessBanRepository, templateRepo repository.DraftTemplateRepository, timerDuration int) *DraftStateManager {
 	return &DraftStateManager{
 		state: &DraftState{
 			CurrentPhase: 0,
@@ -48,8 +85,19 @@ func NewDraftStateManager(room *Room, championRepo repository.ChampionRepository
 		championRepo:    championRepo,
 		roomRepo:        roomRepo,
 		draftActionRepo: draftActionRepo,
+		fearlessBanRepo: fearlessBanRepo,
+		templateRepo:    templateRepo,
 		timerDuration:   timerDuration,
 		room:            room,
+		draftMode:       domain.DraftModeProPlay,
+		gameNumber:      1,
+		fearlessBans:    []string{},
+		templateName:    domain.DraftTemplateProPlay,
+		templateKind:    domain.DraftTemplateKindStandard,
+		phases:          domain.ProPlayPhases,
+		championPools:   make(map[string][]string),
+		drafter:         NewHeuristicDrafter(),
+		opponentPicks:   make(map[string]map[string]int),
 	}
 }
 
@@ -73,9 +121,437 @@ func (dm *DraftStateManager) GetCurrentPhase() int {
 	return dm.state.CurrentPhase
 }
 
+// GetDraftMode returns the room's draft mode.
+func (dm *DraftStateManager) GetDraftMode() string {
+	return string(dm.draftMode)
+}
+
+// GetFearlessBans returns the champions locked out by earlier games in the series.
+func (dm *DraftStateManager) GetFearlessBans() []string {
+	return dm.fearlessBans
+}
+
+// GetPhase returns the phase at the given index of the room's template, or nil if out of range.
+func (dm *DraftStateManager) GetPhase(index int) *domain.Phase {
+	if index < 0 || index >= len(dm.phases) {
+		return nil
+	}
+	return &dm.phases[index]
+}
+
+// TotalPhases returns the number of phases in the room's template.
+func (dm *DraftStateManager) TotalPhases() int {
+	return len(dm.phases)
+}
+
+// GetTemplateInfo returns the room's template for state sync.
+func (dm *DraftStateManager) GetTemplateInfo() *DraftTemplateInfo {
+	info := &DraftTemplateInfo{
+		Name:   dm.templateName,
+		Kind:   string(dm.templateKind),
+		Phases: make([]TemplatePhaseInfo, len(dm.phases)),
+	}
+	for i, p := range dm.phases {
+		info.Phases[i] = TemplatePhaseInfo{Team: string(p.Team), ActionType: string(p.ActionType)}
+	}
+	return info
+}
+
+// IsBlind returns whether picks are hidden from the other team until the draft completes.
+func (dm *DraftStateManager) IsBlind() bool {
+	return dm.templateKind == domain.DraftTemplateKindBlind
+}
+
+// IsRandomPool returns whether each team picks from its own random champion pool.
+func (dm *DraftStateManager) IsRandomPool() bool {
+	return dm.templateKind == domain.DraftTemplateKindRandomPool
+}
+
+// GetChampionPool returns the random pool offered to a side, or nil outside random pool drafts.
+func (dm *DraftStateManager) GetChampionPool(side string) []string {
+	return dm.championPools[side]
+}
+
+// IsInChampionPool checks if a champion was rolled into a side's pool.
+func (dm *DraftStateManager) IsInChampionPool(side, championID string) bool {
+	return contains(dm.championPools[side], championID)
+}
+
+// IsFearlessBanned checks if a champion was played earlier in a fearless series.
+func (dm *DraftStateManager) IsFearlessBanned(championID string) bool {
+	for _, id := range dm.fearlessBans {
+		if id == championID {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRoomContext loads the draft mode, template and series info for the room and,
+// in fearless mode, the champions picked in earlier games of the series.
+func (dm *DraftStateManager) loadRoomContext() {
+	if dm.roomRepo == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := dm.roomRepo.GetByID(ctx, dm.room.id)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading room %s for series context: %v", dm.room.id, err)
+		}
+		return
+	}
+
+	dm.draftMode = room.DraftMode
+	dm.seriesID = room.SeriesID
+	if room.GameNumber > 0 {
+		dm.gameNumber = room.GameNumber
+	}
+
+	if room.ReserveTimeSeconds > 0 {
+		dm.room.timerMgr.EnableReserve(room.ReserveTimeSeconds * 1000)
+	}
+
+	dm.botOnTimeout = room.BotOnTimeout
+	dm.tradePhaseMs = room.TradePhaseSeconds * 1000
+	if room.SpectatorDelaySeconds > 0 {
+		dm.room.spectatorFeed.Enable(room.SpectatorDelaySeconds * 1000)
+	}
+	if room.BotSide != nil {
+		// The bot is always ready
+		dm.botSide = string(*room.BotSide)
+		dm.SetReady(dm.botSide, true)
+	}
+
+	if room.DraftTemplateID != nil {
+		dm.loadTemplate(ctx, *room.DraftTemplateID)
+	}
+
+	if dm.draftMode != domain.DraftModeFearless || dm.seriesID == nil || dm.fearlessBanRepo == nil {
+		return
+	}
+
+	bans, err := dm.fearlessBanRepo.GetBySeriesID(ctx, *dm.seriesID)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading fearless bans for series %s: %v", *dm.seriesID, err)
+		}
+		return
+	}
+
+	for _, ban := range bans {
+		// Only earlier games lock champions out of this one
+		if ban.BannedInGame < dm.gameNumber && !dm.IsFearlessBanned(ban.ChampionID) {
+			dm.fearlessBans = append(dm.fearlessBans, ban.ChampionID)
+		}
+	}
+
+	log.Printf("Room %s loaded %d fearless bans for series %s game %d", dm.room.id, len(dm.fearlessBans), *dm.seriesID, dm.gameNumber)
+}
+
+// restoreState reloads a draft saved before a restart and resumes its timer.
+// Rooms that never started only get their ready flags back.
+func (dm *DraftStateManager) restoreState() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	saved, err := dm.room.persister.Load(ctx)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) && ctx.Err() == nil {
+			log.Printf("Error loading saved draft state for room %s: %v", dm.room.id, err)
+		}
+		return
+	}
+
+	dm.state.BlueReady = saved.BlueReady || dm.IsBotSide("blue")
+	dm.state.RedReady = saved.RedReady || dm.IsBotSide("red")
+	if !saved.IsStarted {
+		return
+	}
+
+	dm.state.Started = true
+	dm.state.IsComplete = saved.IsComplete
+	dm.state.CurrentPhase = saved.CurrentPhase
+	dm.state.BlueBans = decodeChampionIDs(saved.BlueBans)
+	dm.state.RedBans = decodeChampionIDs(saved.RedBans)
+	dm.state.BluePicks = decodeChampionIDs(saved.BluePicks)
+	dm.state.RedPicks = decodeChampionIDs(saved.RedPicks)
+
+	if len(saved.ChampionPools) > 0 {
+		pools := make(map[string][]string)
+		if err := json.Unmarshal(saved.ChampionPools, &pools); err == nil {
+			dm.championPools = pools
+		}
+	}
+
+	if dm.state.IsComplete {
+		return
+	}
+	if dm.GetPhase(dm.state.CurrentPhase) == nil {
+		// Stopped during the trade phase; trades in flight are lost, so finish with the picks as made
+		log.Printf("Room %s restored after its last pick, completing draft", dm.room.id)
+		dm.completeDraft()
+		return
+	}
+
+	dm.room.timerMgr.RestoreReserve(saved.BlueReserveMs, saved.RedReserveMs)
+
+	switch {
+	case saved.IsPaused:
+		dm.room.pauseMgr.Restore(saved.TimerRemaining)
+	case saved.TimerDeadline != nil:
+		dm.room.timerMgr.StartFrom(int(time.Until(*saved.TimerDeadline).Milliseconds()))
+	default:
+		dm.room.timerMgr.Start()
+	}
+	if !saved.IsPaused {
+		dm.scheduleBotTurn()
+	}
+
+	log.Printf("Room %s restored draft at phase %d (paused=%v)", dm.room.id, dm.state.CurrentPhase, saved.IsPaused)
+}
+
+// persistState queues a snapshot of the draft, including the timer deadline, for writing.
+// Called after every state transition.
+func (dm *DraftStateManager) persistState() {
+	if dm.room.persister == nil {
+		return
+	}
+
+	snapshot := &domain.DraftState{
+		RoomID:       dm.room.id,
+		CurrentPhase: dm.state.CurrentPhase,
+		BlueBans:     encodeChampionIDs(dm.state.BlueBans),
+		RedBans:      encodeChampionIDs(dm.state.RedBans),
+		BluePicks:    encodeChampionIDs(dm.state.BluePicks),
+		RedPicks:     encodeChampionIDs(dm.state.RedPicks),
+		IsComplete:   dm.state.IsComplete,
+		IsStarted:    dm.state.Started,
+		BlueReady:    dm.state.BlueReady,
+		RedReady:     dm.state.RedReady,
+	}
+
+	pools, _ := json.Marshal(dm.championPools)
+	snapshot.ChampionPools = pools
+
+	snapshot.BlueReserveMs, snapshot.RedReserveMs = dm.room.timerMgr.GetBankedReserve()
+
+	if phase := dm.GetPhase(dm.state.CurrentPhase); phase != nil && !dm.state.IsComplete {
+		team := phase.Team
+		actionType := phase.ActionType
+		snapshot.CurrentTeam = &team
+		snapshot.ActionType = &actionType
+	}
+
+	if dm.state.Started && !dm.state.IsComplete {
+		if dm.room.timerMgr.IsPaused() {
+			snapshot.IsPaused = true
+			snapshot.TimerRemaining = dm.room.timerMgr.GetFrozenMs()
+		} else {
+			deadline := dm.room.timerMgr.GetDeadline()
+			startedAt := deadline.Add(-time.Duration(dm.room.timerMgr.GetDuration()) * time.Millisecond)
+			snapshot.TimerDeadline = &deadline
+			snapshot.TimerStartedAt = &startedAt
+			snapshot.TimerRemaining = dm.room.timerMgr.GetRemaining()
+		}
+	}
+
+	dm.room.persister.Save(snapshot)
+}
+
+func encodeChampionIDs(ids []string) []byte {
+	data, _ := json.Marshal(ids)
+	return data
+}
+
+func decodeChampionIDs(data []byte) []string {
+	ids := []string{}
+	if len(data) > 0 {
+		json.Unmarshal(data, &ids)
+	}
+	return ids
+}
+
+// loadTemplate replaces the default pro play phases with the room's template.
+// A template that fails to load or decode leaves the defaults in place.
+func (dm *DraftStateManager) loadTemplate(ctx context.Context, templateID uuid.UUID) {
+	if dm.templateRepo == nil {
+		return
+	}
+
+	template, err := dm.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading draft template %s for room %s: %v", templateID, dm.room.id, err)
+		}
+		return
+	}
+
+	phases, err := template.GetPhases()
+	if err != nil || domain.ValidatePhases(phases) != nil {
+		log.Printf("Draft template %s has invalid phases, using pro play: %v", template.Name, err)
+		return
+	}
+
+	dm.templateName = template.Name
+	dm.templateKind = template.Kind
+	dm.poolSize = template.PoolSize
+	dm.phases = phases
+
+	log.Printf("Room %s using draft template %s (%s, %d phases)", dm.room.id, template.Name, template.Kind, len(phases))
+}
+
+// rollChampionPools deals each team a disjoint random pool in random pool drafts.
+func (dm *DraftStateManager) rollChampionPools() {
+	if !dm.IsRandomPool() || dm.championRepo == nil {
+		return
+	}
+
+	champions, err := dm.championRepo.GetAll(context.Background())
+	if err != nil {
+		log.Printf("Error getting champions for random pools: %v", err)
+		return
+	}
+
+	var available []string
+	for _, c := range champions {
+		if !dm.IsFearlessBanned(c.ID) {
+			available = append(available, c.ID)
+		}
+	}
+	rand.Shuffle(len(available), func(i, j int) {
+		available[i], available[j] = available[j], available[i]
+	})
+
+	size := dm.poolSize
+	if size*2 > len(available) {
+		size = len(available) / 2
+	}
+
+	dm.championPools = map[string][]string{
+		"blue": available[:size],
+		"red":  available[size : size*2],
+	}
+}
+
+// CheckChampionAvailable checks if a side may pick or ban a champion in the current state.
+func (dm *DraftStateManager) CheckChampionAvailable(side, championID string) *DraftError {
+	// Check if champion was locked out by an earlier game in a fearless series
+	if dm.IsFearlessBanned(championID) {
+		return &DraftError{"champion_unavailable", "Champion was already played earlier in this series"}
+	}
+
+	if dm.IsBlind() {
+		// Opponent picks are hidden, so only bans and your own picks block a champion
+		if dm.isChampionBanned(championID) || contains(dm.picksFor(side), championID) {
+			return &DraftError{"champion_unavailable", "Champion is already picked or banned"}
+		}
+	} else if dm.IsChampionUsed(championID) {
+		return &DraftError{"champion_unavailable", "Champion is already picked or banned"}
+	}
+
+	if dm.IsRandomPool() {
+		phase := dm.GetPhase(dm.state.CurrentPhase)
+		if phase != nil && phase.ActionType == domain.ActionTypePick && !dm.IsInChampionPool(side, championID) {
+			return &DraftError{"champion_unavailable", "Champion is not in your team's pool"}
+		}
+	}
+
+	return nil
+}
+
+// picksFor returns the picks made by a side.
+func (dm *DraftStateManager) picksFor(side string) []string {
+	if side == "red" {
+		return dm.state.RedPicks
+	}
+	return dm.state.BluePicks
+}
+
+// isChampionBanned checks if either team banned a champion.
+func (dm *DraftStateManager) isChampionBanned(championID string) bool {
+	return contains(dm.state.BlueBans, championID) || contains(dm.state.RedBans, championID)
+}
+
+// emitHover broadcasts a hover; blind and team drafts only show it to the hovering team.
+// Must be called with room lock held.
+func (dm *DraftStateManager) emitHover(side string, championID *string) {
+	if !dm.IsBlind() && !dm.room.isTeamDraft {
+		dm.room.emitter.ChampionHovered(side, championID)
+		return
+	}
+	msg, _ := NewMessage(MessageTypeChampionHovered, ChampionHoveredPayload{
+		Side:       side,
+		ChampionID: championID,
+	})
+	dm.room.emitter.BroadcastToSide(side, msg)
+}
+
+// emitSelection broadcasts a lock-in; blind drafts hide picks from everyone but the picking team.
+// Must be called with room lock held.
+func (dm *DraftStateManager) emitSelection(phase *domain.Phase, championID string) {
+	if !dm.IsBlind() || phase.ActionType != domain.ActionTypePick {
+		dm.room.emitter.ChampionSelected(dm.state.CurrentPhase, string(phase.Team), string(phase.ActionType), championID)
+		return
+	}
+
+	side := string(phase.Team)
+	own, _ := NewMessage(MessageTypeChampionSelected, ChampionSelectedPayload{
+		Phase:      dm.state.CurrentPhase,
+		Team:       side,
+		ActionType: string(phase.ActionType),
+		ChampionID: championID,
+	})
+	hidden, _ := NewMessage(MessageTypeChampionSelected, ChampionSelectedPayload{
+		Phase:      dm.state.CurrentPhase,
+		Team:       side,
+		ActionType: string(phase.ActionType),
+		ChampionID: HiddenChampionID,
+	})
+	dm.room.emitter.BroadcastToSide(side, own)
+	dm.room.emitter.BroadcastExceptSide(side, hidden)
+}
+
+// VisiblePicks returns the picks a viewer on the given side may see.
+// In blind drafts the other team's picks are masked until the draft completes.
+func (dm *DraftStateManager) VisiblePicks(viewerSide string) (bluePicks, redPicks []string) {
+	bluePicks, redPicks = dm.state.BluePicks, dm.state.RedPicks
+	if !dm.IsBlind() || dm.state.IsComplete {
+		return bluePicks, redPicks
+	}
+	if viewerSide != "blue" {
+		bluePicks = maskPicks(bluePicks)
+	}
+	if viewerSide != "red" {
+		redPicks = maskPicks(redPicks)
+	}
+	return bluePicks, redPicks
+}
+
+// maskPicks replaces every pick with HiddenChampionID, keeping the count.
+func maskPicks(picks []string) []string {
+	masked := make([]string, len(picks))
+	for i := range masked {
+		masked[i] = HiddenChampionID
+	}
+	return masked
+}
+
+func contains(ids []string, championID string) bool {
+	for _, id := range ids {
+		if id == championID {
+			return true
+		}
+	}
+	return false
+}
+
 // GetCurrentSide returns the current team's side ("blue" or "red").
 func (dm *DraftStateManager) GetCurrentSide() string {
-	phase := domain.GetPhase(dm.state.CurrentPhase)
+	phase := dm.GetPhase(dm.state.CurrentPhase)
 	if phase == nil {
 		return ""
 	}
@@ -115,8 +591,9 @@ func (dm *DraftStateManager) ClearHover() {
 // Start starts the draft.
 func (dm *DraftStateManager) Start() {
 	dm.state.Started = true
+	dm.rollChampionPools()
 
-	phase := domain.GetPhase(0)
+	phase := dm.GetPhase(0)
 
 	// Broadcast draft started
 	dm.room.emitter.DraftStarted(
@@ -131,6 +608,9 @@ func (dm *DraftStateManager) Start() {
 
 	// Start the timer
 	dm.room.timerMgr.Start()
+
+	dm.persistState()
+	dm.persistRoomStatus(domain.RoomStatusInProgress)
 }
 
 // SelectChampion handles champion selection (hover before lock).
@@ -139,7 +619,7 @@ func (dm *DraftStateManager) SelectChampion(side string, championID string) erro
 		return &DraftError{"invalid_state", "Draft not in progress"}
 	}
 
-	phase := domain.GetPhase(dm.state.CurrentPhase)
+	phase := dm.GetPhase(dm.state.CurrentPhase)
 	if phase == nil {
 		return &DraftError{"invalid_phase", "Invalid phase"}
 	}
@@ -149,16 +629,15 @@ func (dm *DraftStateManager) SelectChampion(side string, championID string) erro
 		return &DraftError{"not_your_turn", "It's not your turn"}
 	}
 
-	// Check if champion is already picked/banned
-	if dm.IsChampionUsed(championID) {
-		return &DraftError{"champion_unavailable", "Champion is already picked or banned"}
+	if err := dm.CheckChampionAvailable(currentSide, championID); err != nil {
+		return err
 	}
 
 	// Store selection (will be confirmed on lock in)
 	dm.currentHover[currentSide] = &championID
 
 	// Broadcast hover
-	dm.room.emitter.ChampionHovered(currentSide, &championID)
+	dm.emitHover(currentSide, &championID)
 
 	return nil
 }
@@ -169,7 +648,7 @@ func (dm *DraftStateManager) LockIn(side string) error {
 		return &DraftError{"invalid_state", "Draft not in progress"}
 	}
 
-	phase := domain.GetPhase(dm.state.CurrentPhase)
+	phase := dm.GetPhase(dm.state.CurrentPhase)
 	if phase == nil {
 		return &DraftError{"invalid_phase", "Invalid phase"}
 	}
@@ -192,12 +671,7 @@ func (dm *DraftStateManager) LockIn(side string) error {
 	dm.room.timerMgr.Stop()
 
 	// Broadcast selection
-	dm.room.emitter.ChampionSelected(
-		dm.state.CurrentPhase,
-		string(phase.Team),
-		string(phase.ActionType),
-		*championID,
-	)
+	dm.emitSelection(phase, *championID)
 
 	// Move to next phase
 	dm.advancePhase()
@@ -210,7 +684,7 @@ func (dm *DraftStateManager) HoverChampion(side string, championID *string) {
 	if !dm.state.Started {
 		return
 	}
-	dm.room.emitter.ChampionHovered(side, championID)
+	dm.emitHover(side, championID)
 }
 
 // HandleTimerExpired handles timer expiration (auto-pick/ban).
@@ -219,61 +693,202 @@ func (dm *DraftStateManager) HandleTimerExpired() {
 		return
 	}
 
-	phase := domain.GetPhase(dm.state.CurrentPhase)
+	phase := dm.GetPhase(dm.state.CurrentPhase)
 	if phase == nil {
 		return
 	}
 
 	var championID string
 
-	if phase.ActionType == domain.ActionTypeBan {
+	switch {
+	case dm.botOnTimeout:
+		// Let the bot draft for the absent side
+		championID = dm.chooseForBot(phase)
+	case phase.ActionType == domain.ActionTypeBan:
 		// Missed ban - use "None" (skip the ban)
 		championID = "None"
-	} else {
+	default:
 		// Missed pick - select a random available champion
-		championID = dm.getRandomAvailableChampion()
+		championID = dm.getRandomAvailableChampion(string(phase.Team))
 	}
 
 	dm.applySelection(phase, championID)
 
 	// Broadcast selection
-	dm.room.emitter.ChampionSelected(
-		dm.state.CurrentPhase,
-		string(phase.Team),
-		string(phase.ActionType),
-		championID,
-	)
+	dm.emitSelection(phase, championID)
 
 	dm.advancePhase()
 }
 
-// advancePhase moves to the next draft phase.
-func (dm *DraftStateManager) advancePhase() {
-	dm.state.CurrentPhase++
+// IsBotSide returns whether the side is drafted by the bot.
+func (dm *DraftStateManager) IsBotSide(side string) bool {
+	return dm.botSide != "" && dm.botSide == side
+}
 
-	// Clear hover for next phase
-	dm.currentHover = make(map[string]*string)
+// scheduleBotTurn lets the bot act shortly after a phase for its side begins.
+func (dm *DraftStateManager) scheduleBotTurn() {
+	if dm.botSide == "" || dm.state.IsComplete || dm.GetCurrentSide() != dm.botSide {
+		return
+	}
+	phaseIndex := dm.state.CurrentPhase
+	time.AfterFunc(botTurnDelay, func() {
+		dm.room.handleBotTurn(phaseIndex)
+	})
+}
+
+// PlayBotTurn makes the bot's selection if phaseIndex is still the bot's current turn.
+func (dm *DraftStateManager) PlayBotTurn(phaseIndex int) {
+	if dm.state.IsComplete || dm.state.CurrentPhase != phaseIndex {
+		return
+	}
+	phase := dm.GetPhase(phaseIndex)
+	if phase == nil || !dm.IsBotSide(string(phase.Team)) {
+		return
+	}
+
+	championID := dm.chooseForBot(phase)
+
+	dm.room.timerMgr.Stop()
+	dm.applySelection(phase, championID)
+	dm.emitSelection(phase, championID)
+	dm.advancePhase()
+}
+
+// chooseForBot asks the drafter for the side's selection in the given phase.
+// The champion list is loaded by the room before it takes the lock for the bot's turn.
+func (dm *DraftStateManager) chooseForBot(phase *domain.Phase) string {
+	champions := dm.botChampions
+	if len(champions) == 0 {
+		return "None"
+	}
+
+	side := string(phase.Team)
+	view := &DraftView{
+		Side:          side,
+		ActionType:    phase.ActionType,
+		OwnPicks:      dm.picksFor(side),
+		Lanes:         make(map[string][]string, len(champions)),
+		OpponentPicks: dm.opponentPicks[side],
+	}
+	for _, c := range champions {
+		view.Lanes[c.ID] = championLanes(c)
+		if dm.CheckChampionAvailable(side, c.ID) == nil {
+			view.Available = append(view.Available, c)
+		}
+	}
+
+	return dm.drafter.Choose(view)
+}
+
+// botTurnSide returns the side the bot may draft for in the current phase, or "" if none.
+func (dm *DraftStateManager) botTurnSide() string {
+	if dm.state.IsComplete {
+		return ""
+	}
+	side := dm.GetCurrentSide()
+	if side == "" || (!dm.IsBotSide(side) && !dm.botOnTimeout) {
+		return ""
+	}
+	return side
+}
+
+// needsBotChampions returns whether the bot may draft this phase without a champion list yet.
+// Caller must hold the room lock.
+func (dm *DraftStateManager) needsBotChampions() bool {
+	return dm.championRepo != nil && len(dm.botChampions) == 0 && dm.botTurnSide() != ""
+}
+
+// fetchBotChampions returns every champion the bot can draft.
+// It queries the repository, so it must be called without holding the room lock.
+func (dm *DraftStateManager) fetchBotChampions() []*domain.Champion {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	champions, err := dm.championRepo.GetAll(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error getting champions for bot: %v", err)
+		}
+		return nil
+	}
+	return champions
+}
 
-	if dm.state.CurrentPhase >= domain.TotalPhases() {
-		dm.state.IsComplete = true
+// opponentToLoad returns the side the bot may draft for next and the opposing user whose
+// pick history has not been loaded yet, or a nil user if there is nothing to load.
+// Caller must hold the room lock.
+func (dm *DraftStateManager) opponentToLoad() (string, *uuid.UUID) {
+	if dm.draftActionRepo == nil {
+		return "", nil
+	}
+
+	side := dm.botTurnSide()
+	if side == "" {
+		return "", nil
+	}
+	if _, ok := dm.opponentPicks[side]; ok {
+		return "", nil
+	}
+
+	opponent := domain.SideBlue
+	if side == string(domain.SideBlue) {
+		opponent = domain.SideRed
+	}
+	return side, dm.room.sideUserID(string(opponent))
+}
+
+// fetchOpponentPicks returns how often a user picked each champion recently.
+// It queries the repository, so it must be called without holding the room lock.
+func (dm *DraftStateManager) fetchOpponentPicks(userID uuid.UUID) map[string]int {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts := make(map[string]int)
+	actions, err := dm.draftActionRepo.GetRecentPicksByUser(ctx, userID, opponentHistorySize)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading pick history for user %s: %v", userID, err)
+		}
+		return counts
+	}
+	for _, action := range actions {
+		counts[action.ChampionID]++
+	}
+	return counts
+}
 
-		// Persist room completion to database
-		dm.persistRoomCompletion()
+// setOpponentPicks caches a side's opponent pick counts for the rest of the draft.
+// Caller must hold the room lock.
+func (dm *DraftStateManager) setOpponentPicks(side string, counts map[string]int) {
+	if _, ok := dm.opponentPicks[side]; !ok {
+		dm.opponentPicks[side] = counts
+	}
+}
 
-		dm.room.emitter.DraftCompleted(
-			dm.state.BlueBans,
-			dm.state.RedBans,
-			dm.state.BluePicks,
-			dm.state.RedPicks,
-		)
+// advancePhase moves to the next draft phase.
+func (dm *DraftStateManager) advancePhase() {
+	dm.state.CurrentPhase++
 
-		// Send state sync to all clients
-		dm.room.syncAllClients()
+	// Clear hover and team suggestions for next phase
+	dm.currentHover = make(map[string]*string)
+	dm.room.suggestionMgr.Clear()
+
+	if dm.state.CurrentPhase >= dm.TotalPhases() {
+		// Team drafts can trade champions before the draft is final
+		if dm.tradePhaseMs > 0 && dm.room.isTeamDraft {
+			dm.persistState()
+			err := dm.room.tradeMgr.Start(dm.tradePhaseMs)
+			if err == nil {
+				return
+			}
+			log.Printf("Room %s skipping trade phase: %v", dm.room.id, err)
+		}
 
+		dm.completeDraft()
 		return
 	}
 
-	phase := domain.GetPhase(dm.state.CurrentPhase)
+	phase := dm.GetPhase(dm.state.CurrentPhase)
 
 	dm.room.emitter.PhaseChanged(
 		dm.state.CurrentPhase,
@@ -284,6 +899,65 @@ func (dm *DraftStateManager) advancePhase() {
 
 	// Start timer for next phase
 	dm.room.timerMgr.Start()
+
+	dm.persistState()
+	dm.scheduleBotTurn()
+}
+
+// completeDraft finalizes the draft once every phase (and any trade phase) is done.
+func (dm *DraftStateManager) completeDraft() {
+	dm.state.IsComplete = true
+	dm.room.tradeMgr.Stop()
+
+	// Lock this game's picks out of the rest of a fearless series. This is saved before the
+	// room is marked completed, since the series' next game can't start until it is.
+	dm.recordFearlessBans()
+
+	// Persist final state and room completion to database
+	dm.persistState()
+	dm.persistRoomStatus(domain.RoomStatusCompleted)
+
+	var blueAssignments, redAssignments []PickAssignmentInfo
+	if dm.room.isTeamDraft {
+		dm.persistAssignments()
+		blueAssignments = dm.room.tradeMgr.AssignmentInfo("blue")
+		redAssignments = dm.room.tradeMgr.AssignmentInfo("red")
+	}
+
+	dm.room.emitter.DraftCompleted(
+		dm.state.BlueBans,
+		dm.state.RedBans,
+		dm.state.BluePicks,
+		dm.state.RedPicks,
+		blueAssignments,
+		redAssignments,
+	)
+
+	// Send state sync to all clients
+	dm.room.syncAllClients()
+}
+
+// persistAssignments saves which player plays each pick so match history can show it.
+func (dm *DraftStateManager) persistAssignments() {
+	if dm.room.assignmentRepo == nil {
+		return
+	}
+
+	assignments := dm.room.tradeMgr.DomainAssignments()
+	if len(assignments) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := dm.room.assignmentRepo.ReplaceForRoom(ctx, dm.room.id, assignments); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Error saving pick assignments for room %s: %v", dm.room.id, err)
+			}
+		}
+	}()
 }
 
 // applySelection applies a selection to the draft state.
@@ -321,6 +995,10 @@ func (dm *DraftStateManager) recordDraftAction(phase *domain.Phase, championID s
 		ChampionID: championID,
 		ActionTime: time.Now(),
 	}
+	// Attribute human selections to the side's player for pick history
+	if !dm.IsBotSide(string(phase.Team)) {
+		action.UserID = dm.room.sideUserID(string(phase.Team))
+	}
 
 	// Run async to avoid blocking WebSocket message flow
 	go func() {
@@ -336,8 +1014,8 @@ func (dm *DraftStateManager) recordDraftAction(phase *domain.Phase, championID s
 	}()
 }
 
-// persistRoomCompletion updates the Room entity when draft completes asynchronously
-func (dm *DraftStateManager) persistRoomCompletion() {
+// persistRoomStatus updates the Room entity's status asynchronously, stamping CompletedAt on completion
+func (dm *DraftStateManager) persistRoomStatus(status domain.RoomStatus) {
 	if dm.roomRepo == nil {
 		return
 	}
@@ -352,25 +1030,104 @@ func (dm *DraftStateManager) persistRoomCompletion() {
 		room, err := dm.roomRepo.GetByID(ctx, roomID)
 		if err != nil {
 			if ctx.Err() == nil {
-				log.Printf("Error getting room %s for completion: %v", roomID, err)
+				log.Printf("Error getting room %s for status update: %v", roomID, err)
 			}
 			return
 		}
 
-		now := time.Now()
-		room.Status = domain.RoomStatusCompleted
-		room.CompletedAt = &now
+		room.Status = status
+		if status == domain.RoomStatusCompleted {
+			now := time.Now()
+			room.CompletedAt = &now
+		}
 
 		if err := dm.roomRepo.Update(ctx, room); err != nil {
 			if ctx.Err() == nil {
-				log.Printf("Error updating room %s completion: %v", roomID, err)
+				log.Printf("Error updating room %s status to %s: %v", roomID, status, err)
 			}
 		} else {
-			log.Printf("Room %s marked as completed at %v", roomID, now)
+			log.Printf("Room %s marked as %s", roomID, status)
 		}
 	}()
 }
 
+// recordFearlessBans saves the game's picks as fearless bans for the series. A failed save is
+// retried in the background, and the room is told if the bans still couldn't be saved, since
+// the series' next game would otherwise offer these champions again.
+func (dm *DraftStateManager) recordFearlessBans() {
+	if dm.fearlessBanRepo == nil || dm.draftMode != domain.DraftModeFearless || dm.seriesID == nil {
+		return
+	}
+
+	bans := dm.fearlessBansForGame()
+	if err := dm.persistFearlessBans(bans); err != nil {
+		log.Printf("Error recording fearless bans for room %s, retrying: %v", dm.room.id, err)
+		go dm.retryFearlessBans(bans)
+	}
+}
+
+// fearlessBansForGame returns the bans this game's picks add to the series.
+func (dm *DraftStateManager) fearlessBansForGame() []*domain.FearlessBan {
+
+	var bans []*domain.FearlessBan
+	addPicks := func(picks []string, team domain.Side) {
+		for _, championID := range picks {
+			if championID == "" || championID == "None" {
+				continue
+			}
+			bans = append(bans, &domain.FearlessBan{
+				ID:           uuid.New(),
+				SeriesID:     *dm.seriesID,
+				ChampionID:   championID,
+				BannedInGame: dm.gameNumber,
+				PickedByTeam: team,
+			})
+		}
+	}
+	addPicks(dm.state.BluePicks, domain.SideBlue)
+	addPicks(dm.state.RedPicks, domain.SideRed)
+	return bans
+}
+
+// persistFearlessBans saves all of a game's bans in one transaction, so a failure leaves
+// none of them saved and the whole batch can be retried.
+func (dm *DraftStateManager) persistFearlessBans(bans []*domain.FearlessBan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return dm.fearlessBanRepo.CreateMany(ctx, bans)
+}
+
+// retryFearlessBans retries saving bans whose first save failed, without holding the room lock.
+func (dm *DraftStateManager) retryFearlessBans(bans []*domain.FearlessBan) {
+	delay := time.Second
+	for attempt := 0; attempt < fearlessBanRetries; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-dm.room.done:
+			return
+		}
+		err := dm.persistFearlessBans(bans)
+		if err == nil {
+			log.Printf("Recorded fearless bans for room %s after %d retries", dm.room.id, attempt+1)
+			return
+		}
+		log.Printf("Error recording fearless bans for room %s (retry %d): %v", dm.room.id, attempt+1, err)
+		delay *= 2
+	}
+
+	msg, err := NewMessage(MessageTypeError, ErrorPayload{
+		Code:    "FEARLESS_BANS_NOT_SAVED",
+		Message: "This game's picks could not be saved as fearless bans for the rest of the series",
+	})
+	if err != nil {
+		return
+	}
+	select {
+	case dm.room.broadcast <- msg:
+	case <-dm.room.done:
+	}
+}
+
 // IsChampionUsed checks if a champion is already picked or banned.
 func (dm *DraftStateManager) IsChampionUsed(championID string) bool {
 	for _, id := range dm.state.BlueBans {
@@ -454,11 +1211,12 @@ func (dm *DraftStateManager) ApplyEdit(edit *PendingEdit) {
 
 	if arr != nil && edit.SlotIndex < len(*arr) {
 		(*arr)[edit.SlotIndex] = edit.NewChampionID
+		dm.persistState()
 	}
 }
 
-// getRandomAvailableChampion returns a random champion that hasn't been picked or banned.
-func (dm *DraftStateManager) getRandomAvailableChampion() string {
+// getRandomAvailableChampion returns a random champion the side is still allowed to pick.
+func (dm *DraftStateManager) getRandomAvailableChampion(side string) string {
 	if dm.championRepo == nil {
 		log.Printf("Warning: championRepo is nil, cannot get random champion")
 		return "None"
@@ -473,7 +1231,7 @@ func (dm *DraftStateManager) getRandomAvailableChampion() string {
 	// Filter out used champions
 	var available []string
 	for _, c := range champions {
-		if !dm.IsChampionUsed(c.ID) {
+		if dm.CheckChampionAvailable(side, c.ID) == nil {
 			available = append(available, c.ID)
 		}
 	}
@@ -0,0 +1,133 @@
+package websocket
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	"github.com/dom/league-draft-website/internal/domain"
+)
+
+// Drafter chooses a champion for the current phase on behalf of a side.
+// It is used for bot-controlled sides and, if the room opts in, for timed-out turns.
+type Drafter interface {
+	Choose(view *DraftView) string
+}
+
+// DraftView is what a Drafter sees when making a selection.
+type DraftView struct {
+	Side       string
+	ActionType domain.ActionType
+	// OwnPicks are the champions the side has already picked, in pick order
+	OwnPicks []string
+	// Available are the champions the side may select right now
+	Available []*domain.Champion
+	// Lanes maps every champion ID to its lanes, ordered by playrate
+	Lanes map[string][]string
+	// OpponentPicks counts how often the opposing player picked each champion in earlier drafts
+	OpponentPicks map[string]int
+}
+
+// HeuristicDrafter is the built-in bot.
+// It bans the opponent's most picked champions and picks champions for lanes
+// its team has not filled yet, preferring each champion's main lane.
+type HeuristicDrafter struct{}
+
+// NewHeuristicDrafter creates the built-in heuristic bot.
+func NewHeuristicDrafter() *HeuristicDrafter {
+	return &HeuristicDrafter{}
+}
+
+// Choose returns the champion to ban or pick, or "None" if nothing is available.
+func (d *HeuristicDrafter) Choose(view *DraftView) string {
+	if len(view.Available) == 0 {
+		return "None"
+	}
+
+	var best []string
+	if view.ActionType == domain.ActionTypeBan {
+		best = d.banCandidates(view)
+	} else {
+		best = d.pickCandidates(view)
+	}
+
+	if len(best) == 0 {
+		return view.Available[rand.Intn(len(view.Available))].ID
+	}
+	return best[rand.Intn(len(best))]
+}
+
+// banCandidates returns the available champions the opponent picked most often.
+func (d *HeuristicDrafter) banCandidates(view *DraftView) []string {
+	var best []string
+	bestCount := 0
+	for _, c := range view.Available {
+		count := view.OpponentPicks[c.ID]
+		if count == 0 || count < bestCount {
+			continue
+		}
+		if count > bestCount {
+			best = best[:0]
+			bestCount = count
+		}
+		best = append(best, c.ID)
+	}
+	return best
+}
+
+// pickCandidates returns the available champions that fill an open lane,
+// preferring those for which the lane is highest in their playrate order.
+func (d *HeuristicDrafter) pickCandidates(view *DraftView) []string {
+	filled := d.filledLanes(view)
+
+	var best []string
+	bestRank := -1
+	for _, c := range view.Available {
+		rank := -1
+		for i, lane := range view.Lanes[c.ID] {
+			if !filled[lane] {
+				rank = i
+				break
+			}
+		}
+		if rank < 0 || (bestRank >= 0 && rank > bestRank) {
+			continue
+		}
+		if rank != bestRank {
+			best = best[:0]
+			bestRank = rank
+		}
+		best = append(best, c.ID)
+	}
+	return best
+}
+
+// filledLanes assigns each of the side's picks to its first lane not already taken.
+func (d *HeuristicDrafter) filledLanes(view *DraftView) map[string]bool {
+	filled := make(map[string]bool)
+	for _, id := range view.OwnPicks {
+		for _, lane := range view.Lanes[id] {
+			if !filled[lane] {
+				filled[lane] = true
+				break
+			}
+		}
+	}
+	return filled
+}
+
+// championLanes decodes a champion's lanes.
+func championLanes(c *domain.Champion) []string {
+	var lanes []string
+	if len(c.Lanes) > 0 {
+		json.Unmarshal(c.Lanes, &lanes)
+	}
+	return lanes
+}
+
+// botPlayerInfo describes a bot-drafted side in state syncs.
+func botPlayerInfo() *PlayerInfo {
+	return &PlayerInfo{
+		DisplayName: "Bot",
+		Ready:       true,
+	}
+}
@@ -70,6 +70,21 @@ func (em *EditManager) ProposeEdit(userID uuid.UUID, side string, payload Propos
 		return &EditError{"invalid_slot", err.Error()}
 	}
 
+	// Blind drafts keep picks hidden until completion, so slots can't be edited
+	if em.room.draftMgr.IsBlind() {
+		return &EditError{"invalid_edit", "Edits are not available in blind drafts"}
+	}
+
+	// Random pool picks must stay inside the team's pool
+	if em.room.draftMgr.IsRandomPool() && payload.SlotType == "pick" && !em.room.draftMgr.IsInChampionPool(payload.Team, payload.ChampionID) {
+		return &EditError{"champion_unavailable", "Champion is not in the team's pool"}
+	}
+
+	// Validate new champion was not locked out earlier in a fearless series
+	if em.room.draftMgr.IsFearlessBanned(payload.ChampionID) {
+		return &EditError{"champion_unavailable", "Champion already played earlier in this series"}
+	}
+
 	// Validate new champion is available (not already picked/banned elsewhere)
 	if em.room.draftMgr.IsChampionUsedExcept(payload.ChampionID, payload.SlotType, payload.Team, payload.SlotIndex) {
 		return &EditError{"champion_unavailable", "Champion already used"}
@@ -1,9 +1,5 @@
 package websocket
 
-import (
-	"encoding/json"
-)
-
 // EventEmitter provides centralized message broadcasting for the room.
 // All managers use this to send messages to clients.
 type EventEmitter struct {
@@ -16,12 +12,39 @@ func NewEventEmitter(room *Room) *EventEmitter {
 }
 
 // Broadcast sends a message to all clients in the room.
+// Delayed spectators get it through the spectator feed instead.
 // Must be called with room lock held.
 func (e *EventEmitter) Broadcast(msg *Message) {
-	data, _ := json.Marshal(msg)
+	encoded := e.room.events.Record(msg, audienceAll, "")
 	for client := range e.room.clients {
-		e.trySend(client, data)
+		if !e.room.spectatorFeed.Delays(client) {
+			e.trySend(client, encoded)
+		}
 	}
+	e.room.spectatorFeed.Push(msg, encoded)
+}
+
+// BroadcastToSide sends a message to the clients on one side (players and team members).
+// Must be called with room lock held.
+func (e *EventEmitter) BroadcastToSide(side string, msg *Message) {
+	encoded := e.room.events.Record(msg, audienceSide, side)
+	for client := range e.room.clients {
+		if client.side == side {
+			e.trySend(client, encoded)
+		}
+	}
+}
+
+// BroadcastExceptSide sends a message to every client not on the given side, spectators included.
+// Must be called with room lock held.
+func (e *EventEmitter) BroadcastExceptSide(side string, msg *Message) {
+	encoded := e.room.events.Record(msg, audienceExceptSide, side)
+	for client := range e.room.clients {
+		if client.side != side && !e.room.spectatorFeed.Delays(client) {
+			e.trySend(client, encoded)
+		}
+	}
+	e.room.spectatorFeed.Push(msg, encoded)
 }
 
 // BroadcastAsync sends a message through the broadcast channel (for use without lock).
@@ -29,14 +52,33 @@ func (e *EventEmitter) BroadcastAsync(msg *Message) {
 	e.room.broadcast <- msg
 }
 
+// Replay sends a reconnecting client the events it missed since lastSeq.
+// Returns false if the gap is no longer covered by the event log, or the client
+// is a delayed spectator, since the log holds events they must not see yet.
+// Must be called with room lock held.
+func (e *EventEmitter) Replay(client *Client, lastSeq int) bool {
+	if e.room.spectatorFeed.Delays(client) {
+		return false
+	}
+	missed, ok := e.room.events.Since(lastSeq, client.side)
+	if !ok {
+		return false
+	}
+	for _, encoded := range missed {
+		e.trySend(client, encoded)
+	}
+	return true
+}
+
 // SendTo sends a message to a specific client.
 func (e *EventEmitter) SendTo(client *Client, msg *Message) {
 	client.Send(msg)
 }
 
-// trySend attempts to send to a client using the client's safe send method.
-func (e *EventEmitter) trySend(client *Client, data []byte) {
-	client.trySend(data)
+// trySend attempts to send to a client using the client's safe send method,
+// picking the frame for the client's protocol version.
+func (e *EventEmitter) trySend(client *Client, encoded frames) {
+	client.trySendFrames(encoded)
 }
 
 // --- Draft lifecycle events ---
@@ -63,13 +105,15 @@ func (e *EventEmitter) PhaseChanged(currentPhase int, team, actionType string, t
 	e.Broadcast(msg)
 }
 
-// DraftCompleted broadcasts that the draft has finished.
-func (e *EventEmitter) DraftCompleted(blueBans, redBans, bluePicks, redPicks []string) {
+// DraftCompleted broadcasts that the draft has finished, with who plays each pick in team drafts.
+func (e *EventEmitter) DraftCompleted(blueBans, redBans, bluePicks, redPicks []string, blueAssignments, redAssignments []PickAssignmentInfo) {
 	msg, _ := NewMessage(MessageTypeDraftCompleted, DraftCompletedPayload{
-		BlueBans:  blueBans,
-		RedBans:   redBans,
-		BluePicks: bluePicks,
-		RedPicks:  redPicks,
+		BlueBans:        blueBans,
+		RedBans:         redBans,
+		BluePicks:       bluePicks,
+		RedPicks:        redPicks,
+		BlueAssignments: blueAssignments,
+		RedAssignments:  redAssignments,
 	})
 	e.Broadcast(msg)
 }
@@ -111,10 +155,12 @@ func (e *EventEmitter) PlayerUpdate(side string, player *PlayerInfo, action stri
 // --- Timer events ---
 
 // TimerTick broadcasts a timer update.
-func (e *EventEmitter) TimerTick(remainingMs int, isBufferPeriod bool) {
+// reserve is nil when the room has no reserve time.
+func (e *EventEmitter) TimerTick(remainingMs int, isBufferPeriod bool, reserve *ReserveInfo) {
 	msg, _ := NewMessage(MessageTypeTimerTick, TimerTickPayload{
 		RemainingMs:    remainingMs,
 		IsBufferPeriod: isBufferPeriod,
+		Reserve:        reserve,
 	})
 	e.BroadcastAsync(msg)
 }
@@ -0,0 +1,128 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+)
+
+// eventLogSize is how many recent events a room keeps for replay to reconnecting clients.
+const eventLogSize = 256
+
+// eventAudience records which clients an event was broadcast to, so replay
+// never shows a client something it would not have received live (e.g. blind picks).
+type eventAudience int
+
+const (
+	audienceAll eventAudience = iota
+	audienceSide
+	audienceExceptSide
+)
+
+type loggedEvent struct {
+	seq      int
+	frames   frames
+	audience eventAudience
+	side     string
+}
+
+// includes returns whether a client on the given side received the event.
+func (e *loggedEvent) includes(side string) bool {
+	switch e.audience {
+	case audienceSide:
+		return side == e.side
+	case audienceExceptSide:
+		return side != e.side
+	default:
+		return true
+	}
+}
+
+// EventLog stamps a room's outbound events with increasing sequence numbers and
+// keeps the most recent ones in a ring buffer for replay after a reconnect.
+//
+// Sequence numbers are only increasing, not contiguous per client: events sent to
+// one side still use up a number for clients on the other side.
+type EventLog struct {
+	events  []loggedEvent
+	next    int // ring index the next event is written to
+	count   int
+	lastSeq int
+
+	mu sync.Mutex
+}
+
+// NewEventLog creates an empty event log.
+// Numbering starts from the current time in milliseconds so that sequence numbers
+// handed out before a server restart never line up with the restored room's log.
+func NewEventLog() *EventLog {
+	return &EventLog{
+		events:  make([]loggedEvent, eventLogSize),
+		lastSeq: int(time.Now().UnixMilli()),
+	}
+}
+
+// Record stamps msg with the next sequence number, stores it and returns its encoded frames.
+// Transient messages such as timer ticks are encoded without a sequence number and not stored.
+func (l *EventLog) Record(msg *Message, audience eventAudience, side string) frames {
+	if isTransientMessage(msg.Type) {
+		return encodeFrames(msg)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastSeq++
+	msg.Seq = l.lastSeq
+	encoded := encodeFrames(msg)
+
+	l.events[l.next] = loggedEvent{seq: l.lastSeq, frames: encoded, audience: audience, side: side}
+	l.next = (l.next + 1) % len(l.events)
+	if l.count < len(l.events) {
+		l.count++
+	}
+
+	return encoded
+}
+
+// LastSeq returns the sequence number of the most recent event.
+func (l *EventLog) LastSeq() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastSeq
+}
+
+// Since returns the events after seq that a client on the given side received.
+// ok is false when the log no longer covers the gap (or seq is unknown) and the
+// client needs a full state sync instead.
+func (l *EventLog) Since(seq int, side string) (missed []frames, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if seq > l.lastSeq {
+		return nil, false
+	}
+	if seq == l.lastSeq {
+		return nil, true
+	}
+
+	// Oldest retained event must directly follow the client's last one
+	oldest := l.lastSeq - l.count + 1
+	if l.count == 0 || seq+1 < oldest {
+		return nil, false
+	}
+
+	start := (l.next - l.count + len(l.events)) % len(l.events)
+	for i := 0; i < l.count; i++ {
+		event := &l.events[(start+i)%len(l.events)]
+		if event.seq > seq && event.includes(side) {
+			missed = append(missed, event.frames)
+		}
+	}
+	return missed, true
+}
+
+// isTransientMessage reports message types that are superseded by the next one and
+// so are neither sequenced nor replayed.
+func isTransientMessage(msgType MessageType) bool {
+	return msgType == MessageTypeTimerTick
+}
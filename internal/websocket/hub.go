@@ -5,6 +5,7 @@ import (
 	"log"
 	"sync"
 
+	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/dom/league-draft-website/internal/repository"
 	"github.com/google/uuid"
 )
@@ -15,6 +16,7 @@ type Hub struct {
 	register        chan *Client
 	unregister      chan *Client
 	joinRoom        chan *JoinRoomRequest
+	closeRoom       chan *Room
 	stop            chan struct{}
 	done            chan struct{} // closed when Run() exits
 	stopped         bool
@@ -23,22 +25,30 @@ type Hub struct {
 	championRepo    repository.ChampionRepository
 	roomRepo        repository.RoomRepository
 	draftActionRepo repository.DraftActionRepository
+	fearlessBanRepo repository.FearlessBanRepository
+	templateRepo    repository.DraftTemplateRepository
+	draftStateRepo  repository.DraftStateRepository
+	assignmentRepo  repository.PickAssignmentRepository
+	chatRepo        repository.RoomChatMessageRepository
+	messageLimits   MessageLimits
 	mu              sync.RWMutex
 }
 
 type JoinRoomRequest struct {
-	Client *Client
-	RoomID string
-	Side   string
+	Client  *Client
+	RoomID  string
+	Side    string
+	LastSeq *int
 }
 
-func NewHub(userRepo repository.UserRepository, roomPlayerRepo repository.RoomPlayerRepository, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository) *Hub {
+func NewHub(userRepo repository.UserRepository, roomPlayerRepo repository.RoomPlayerRepository, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository, fearlessBanRepo repository.FearlessBanRepository, templateRepo repository.DraftTemplateRepository, draftStateRepo repository.DraftStateRepository, assignmentRepo repository.PickAssignmentRepository, chatRepo repository.RoomChatMessageRepository) *Hub {
 	return &Hub{
 		rooms:           make(map[string]*Room),
 		clients:         make(map[*Client]bool),
 		register:        make(chan *Client),
 		unregister:      make(chan *Client),
 		joinRoom:        make(chan *JoinRoomRequest),
+		closeRoom:       make(chan *Room),
 		stop:            make(chan struct{}),
 		done:            make(chan struct{}),
 		userRepo:        userRepo,
@@ -46,6 +56,11 @@ func NewHub(userRepo repository.UserRepository, roomPlayerRepo repository.RoomPl
 		championRepo:    championRepo,
 		roomRepo:        roomRepo,
 		draftActionRepo: draftActionRepo,
+		fearlessBanRepo: fearlessBanRepo,
+		templateRepo:    templateRepo,
+		draftStateRepo:  draftStateRepo,
+		assignmentRepo:  assignmentRepo,
+		chatRepo:        chatRepo,
 	}
 }
 
@@ -99,8 +114,11 @@ func (h *Hub) Run() {
 					delete(h.clients, client)
 					client.Close()
 
-					if client.room != nil {
-						client.room.leave <- client
+					if room := client.Room(); room != nil {
+						select {
+						case room.leave <- client:
+						case <-room.done:
+						}
 					}
 				}
 			}
@@ -113,6 +131,9 @@ func (h *Hub) Run() {
 			if !stopped {
 				h.handleJoinRoom(req)
 			}
+
+		case room := <-h.closeRoom:
+			h.handleCloseRoom(room)
 		}
 	}
 }
@@ -142,8 +163,11 @@ func (h *Hub) handleJoinRoom(req *JoinRoomRequest) {
 	}
 
 	// Leave current room if in one
-	if req.Client.room != nil {
-		req.Client.room.leave <- req.Client
+	if current := req.Client.Room(); current != nil {
+		select {
+		case current.leave <- req.Client:
+		case <-current.done:
+		}
 	}
 
 	// Parse room ID as UUID for database lookups
@@ -192,7 +216,8 @@ func (h *Hub) handleJoinRoom(req *JoinRoomRequest) {
 		req.Client.side = req.Side
 	}
 
-	req.Client.room = room
+	req.Client.setRoom(room)
+	req.Client.lastSeq = req.LastSeq
 	room.join <- req.Client
 }
 
@@ -200,7 +225,7 @@ func (h *Hub) CreateRoom(roomID uuid.UUID, shortCode string, timerDurationMs int
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	room := NewRoom(roomID, shortCode, timerDurationMs, h.userRepo, h.championRepo, h.roomRepo, h.draftActionRepo)
+	room := NewRoom(roomID, shortCode, timerDurationMs, h.userRepo, h.championRepo, h.roomRepo, h.draftActionRepo, h.fearlessBanRepo, h.templateRepo, h.draftStateRepo, h.assignmentRepo, h.chatRepo)
 	h.rooms[roomID.String()] = room
 	h.rooms[shortCode] = room
 
@@ -210,6 +235,81 @@ func (h *Hub) CreateRoom(roomID uuid.UUID, shortCode string, timerDurationMs int
 	return room
 }
 
+// RestoreRooms recreates the rooms whose drafts were in progress when the server last stopped.
+// Each room reloads its saved draft state and resumes its timer as it starts running.
+func (h *Hub) RestoreRooms(ctx context.Context) (int, error) {
+	if h.roomRepo == nil {
+		return 0, nil
+	}
+
+	rooms, err := h.roomRepo.GetByStatus(ctx, domain.RoomStatusInProgress)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, room := range rooms {
+		if h.GetRoom(room.ID.String()) != nil {
+			continue
+		}
+		h.CreateRoom(room.ID, room.ShortCode, room.TimerDurationSeconds*1000)
+		restored++
+	}
+
+	return restored, nil
+}
+
+// SetRoomSeries tells a live room it became a game of a series, so a fearless draft records
+// its picks as bans for the series.
+func (h *Hub) SetRoomSeries(roomID uuid.UUID, seriesID uuid.UUID, gameNumber int) {
+	if room := h.GetRoom(roomID.String()); room != nil {
+		room.setSeries(seriesID, gameNumber)
+	}
+}
+
+// NotifySeriesUpdated broadcasts a series update to everyone in the previous game's room
+// and, when a next game was created, moves them into its room.
+func (h *Hub) NotifySeriesUpdated(previousRoomID uuid.UUID, payload SeriesUpdatedPayload) {
+	room := h.GetRoom(previousRoomID.String())
+	if room == nil {
+		return
+	}
+
+	msg, err := NewMessage(MessageTypeSeriesUpdated, payload)
+	if err != nil {
+		log.Printf("Error building series update for room %s: %v", previousRoomID, err)
+		return
+	}
+
+	clientSides := room.getClientSides()
+
+	select {
+	case room.broadcast <- msg:
+	case <-room.done:
+		return
+	}
+
+	if payload.NextGame == nil {
+		return
+	}
+
+	for client, side := range clientSides {
+		req := &JoinRoomRequest{
+			Client: client,
+			RoomID: payload.NextGame.RoomID,
+			Side:   side,
+		}
+		select {
+		case h.joinRoom <- req:
+		case <-h.done:
+			return
+		}
+	}
+
+	log.Printf("Moved %d clients from room %s to series game %d (room %s)",
+		len(clientSides), previousRoomID, payload.NextGame.GameNumber, payload.NextGame.RoomID)
+}
+
 func (h *Hub) GetRoom(roomID string) *Room {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -224,6 +324,65 @@ func (h *Hub) DeleteRoom(roomID uuid.UUID, shortCode string) {
 	delete(h.rooms, shortCode)
 }
 
+// CloseRoom force-closes a live room: its clients are told and detached, and the room stops.
+// Returns false if the room isn't running on this hub.
+func (h *Hub) CloseRoom(roomID uuid.UUID) bool {
+	h.mu.Lock()
+	room, exists := h.rooms[roomID.String()]
+	if !exists {
+		h.mu.Unlock()
+		return false
+	}
+	delete(h.rooms, roomID.String())
+	delete(h.rooms, room.shortCode)
+	h.mu.Unlock()
+
+	// Clients are detached on the hub goroutine, like unregister, since it's the only one that moves them between rooms
+	select {
+	case h.closeRoom <- room:
+	case <-h.done:
+		// The hub stopped every room on its way out
+	}
+	return true
+}
+
+// handleCloseRoom detaches a closed room's clients, tells them, and stops the room.
+func (h *Hub) handleCloseRoom(room *Room) {
+	h.mu.Lock()
+	var detached []*Client
+	for client := range h.clients {
+		if client.Room() == room {
+			client.setRoom(nil)
+			detached = append(detached, client)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, client := range detached {
+		client.sendError("ROOM_CLOSED", "This room was closed by an admin")
+	}
+	room.Stop()
+
+	log.Printf("Closed room %s (code: %s), detached %d clients", room.id, room.shortCode, len(detached))
+}
+
+// ActiveRooms returns a summary of every room running on the hub
+func (h *Hub) ActiveRooms() []ActiveRoomInfo {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	rooms := make([]ActiveRoomInfo, 0, len(h.rooms)/2)
+	seen := make(map[*Room]bool) // Rooms are stored by both ID and short code
+	for _, room := range h.rooms {
+		if seen[room] {
+			continue
+		}
+		seen[room] = true
+		rooms = append(rooms, room.Summary())
+	}
+	return rooms
+}
+
 func (h *Hub) Register(client *Client) {
 	h.register <- client
 }
@@ -247,6 +406,18 @@ func (h *Hub) Unregister(client *Client) {
 	}
 }
 
+// ActiveRoomInfo summarizes a running room for admins
+type ActiveRoomInfo struct {
+	RoomID         string `json:"roomId"`
+	ShortCode      string `json:"shortCode"`
+	Status         string `json:"status"`
+	IsTeamDraft    bool   `json:"isTeamDraft"`
+	IsPaused       bool   `json:"isPaused"`
+	CurrentPhase   int    `json:"currentPhase"`
+	ClientCount    int    `json:"clientCount"`
+	SpectatorCount int    `json:"spectatorCount"`
+}
+
 // DraftPendingAction represents a pending action in a draft room for a user
 type DraftPendingAction struct {
 	RoomID         string `json:"roomId"`
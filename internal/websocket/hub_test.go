@@ -0,0 +1,55 @@
+package websocket_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/dom/league-draft-website/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with -race: the hub detaches clients while their read pumps are still sending commands.
+func TestHub_CloseRoomWhileClientSendsCommands(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+
+	_, token := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+	nextRoom := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+
+	client := testutil.NewWSClient(t, ts.WebSocketURL(t, token))
+	client.JoinRoom(room.ID.String(), "blue")
+	client.ExpectStateSync(defaultTimeout)
+	client.DrainMessages()
+
+	closed := make(chan bool)
+	go func() {
+		closed <- ts.Hub.CloseRoom(room.ID)
+	}()
+
+	champion := "Ahri"
+	for i := 0; i < 20; i++ {
+		client.HoverChampion(&champion)
+		client.LockIn()
+		client.SyncState()
+	}
+	require.True(t, <-closed)
+
+	// Commands sent before the room closed may have been rejected, so skip their errors
+	for {
+		msg := client.SkipUntilMessageType(websocket.MessageTypeError, defaultTimeout)
+		var payload websocket.ErrorPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		if payload.Code == "ROOM_CLOSED" {
+			break
+		}
+	}
+	assert.Nil(t, ts.Hub.GetRoom(room.ID.String()))
+
+	// The read pump never blocked on the stopped room, so the client can still join another
+	client.JoinRoom(nextRoom.ID.String(), "blue")
+	client.SkipUntilMessageType(websocket.MessageTypeStateSync, defaultTimeout)
+}
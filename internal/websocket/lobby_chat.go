@@ -0,0 +1,173 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	// chatRateLimit is how many chat messages a connection can send per chatRateWindow
+	chatRateLimit  = 5
+	chatRateWindow = 10 * time.Second
+)
+
+// LobbyChat stores the messages of lobby chats
+type LobbyChat interface {
+	SendChatMessage(ctx context.Context, lobbyID, userID uuid.UUID, channel domain.ChatChannel, body string) (*domain.ChatMessage, error)
+	PostSystemMessage(ctx context.Context, lobbyID uuid.UUID, body string) (*domain.ChatMessage, error)
+	GetChatHistory(ctx context.Context, lobbyID, userID uuid.UUID) ([]*domain.ChatMessage, error)
+}
+
+// chatLimiter limits how fast one connection can chat
+type chatLimiter struct {
+	mu   sync.Mutex
+	sent []time.Time
+}
+
+// Allow records a message and returns false if the connection is over its limit
+func (l *chatLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	recent := l.sent[:0]
+	for _, t := range l.sent {
+		if now.Sub(t) < chatRateWindow {
+			recent = append(recent, t)
+		}
+	}
+	l.sent = recent
+
+	if len(l.sent) >= chatRateLimit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+// SetLobbyChat sets where lobby chat messages are stored
+func (h *LobbyHub) SetLobbyChat(chat LobbyChat) {
+	h.chat = chat
+}
+
+// handleChatSend posts a player's chat message and sends it to everyone who can read it
+func (h *LobbyHub) handleChatSend(client *LobbyClient, channel domain.ChatChannel, body string) {
+	lobbyID := client.LobbyID()
+	if lobbyID == uuid.Nil {
+		client.sendError("NOT_IN_LOBBY", "Join a lobby before chatting")
+		return
+	}
+	if h.chat == nil {
+		client.sendError("CHAT_FAILED", "Chat is not available")
+		return
+	}
+
+	ctx := context.Background()
+	msg, err := h.chat.SendChatMessage(ctx, lobbyID, client.UserID(), channel, body)
+	if err != nil {
+		client.sendError("CHAT_FAILED", err.Error())
+		return
+	}
+	h.BroadcastChatMessage(ctx, lobbyID, msg)
+}
+
+// sendChatHistory sends a client the lobby's recent messages it can read
+func (h *LobbyHub) sendChatHistory(ctx context.Context, client *LobbyClient, lobbyID uuid.UUID) {
+	if h.chat == nil {
+		return
+	}
+
+	messages, err := h.chat.GetChatHistory(ctx, lobbyID, client.UserID())
+	if err != nil {
+		log.Printf("LobbyHub.sendChatHistory: failed to load chat for lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	infos := make([]ChatMessageInfo, len(messages))
+	for i, msg := range messages {
+		infos[i] = chatMessageInfo(msg)
+	}
+	client.Send(NewLobbyMessage(LobbyMsgChatHistory, ChatHistoryPayload{Messages: infos}))
+}
+
+// BroadcastChatMessage sends a chat message to the connected clients who can read it
+func (h *LobbyHub) BroadcastChatMessage(ctx context.Context, lobbyID uuid.UUID, msg *domain.ChatMessage) {
+	state := h.GetLobbyStateIfExists(lobbyID)
+	if state == nil {
+		return
+	}
+
+	out := NewLobbyMessage(LobbyMsgChatMessage, ChatMessagePayload{Message: chatMessageInfo(msg)})
+	if msg.Channel != domain.ChatChannelTeam {
+		state.Broadcast(out)
+		return
+	}
+
+	// Team messages only reach the players currently on that team
+	players, err := h.lobbyPlayerRepo.GetByLobbyID(ctx, lobbyID)
+	if err != nil {
+		log.Printf("LobbyHub.BroadcastChatMessage: failed to get players for lobby %s: %v", lobbyID, err)
+		return
+	}
+	teams := make(map[uuid.UUID]*domain.Side, len(players))
+	for _, p := range players {
+		teams[p.UserID] = p.Team
+	}
+	state.BroadcastWhere(out, func(userID uuid.UUID) bool {
+		return msg.VisibleTo(teams[userID])
+	})
+}
+
+// postSystemMessage posts a server message to the lobby's chat, such as a captain change
+func (h *LobbyHub) postSystemMessage(lobbyID uuid.UUID, format string, args ...interface{}) {
+	if h.chat == nil {
+		return
+	}
+
+	ctx := context.Background()
+	msg, err := h.chat.PostSystemMessage(ctx, lobbyID, fmt.Sprintf(format, args...))
+	if err != nil {
+		log.Printf("LobbyHub.postSystemMessage: failed to post to lobby %s: %v", lobbyID, err)
+		return
+	}
+	h.BroadcastChatMessage(ctx, lobbyID, msg)
+}
+
+// BroadcastChatMuted tells the lobby a player was muted or unmuted by a captain
+func (h *LobbyHub) BroadcastChatMuted(ctx context.Context, lobbyID uuid.UUID, displayName, mutedBy string, muted bool) {
+	if muted {
+		h.postSystemMessage(lobbyID, "%s was muted by %s", displayName, mutedBy)
+	} else {
+		h.postSystemMessage(lobbyID, "%s was unmuted by %s", displayName, mutedBy)
+	}
+	h.BroadcastLobbyUpdate(ctx, lobbyID)
+}
+
+// chatMessageInfo converts a chat message to wire format
+func chatMessageInfo(msg *domain.ChatMessage) ChatMessageInfo {
+	info := ChatMessageInfo{
+		ID:        msg.ID.String(),
+		Channel:   string(msg.Channel),
+		Body:      msg.Body,
+		IsSystem:  msg.IsSystem(),
+		CreatedAt: msg.CreatedAt.Format(time.RFC3339),
+	}
+	if msg.UserID != nil {
+		s := msg.UserID.String()
+		info.UserID = &s
+	}
+	if msg.User != nil {
+		info.DisplayName = msg.User.DisplayName
+	}
+	if msg.Team != nil {
+		s := string(*msg.Team)
+		info.Team = &s
+	}
+	return info
+}
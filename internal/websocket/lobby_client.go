@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dom/league-draft-website/internal/domain"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
@@ -18,6 +19,9 @@ type LobbyClient struct {
 	userID  uuid.UUID
 	lobbyID uuid.UUID
 
+	chatLimiter chatLimiter
+	limiter     *messageLimiter
+
 	mu     sync.RWMutex
 	closed bool
 }
@@ -25,10 +29,11 @@ type LobbyClient struct {
 // NewLobbyClient creates a new lobby client
 func NewLobbyClient(hub *LobbyHub, conn *websocket.Conn, userID uuid.UUID) *LobbyClient {
 	return &LobbyClient{
-		hub:    hub,
-		conn:   conn,
-		send:   make(chan []byte, 256),
-		userID: userID,
+		hub:     hub,
+		conn:    conn,
+		send:    make(chan []byte, 256),
+		userID:  userID,
+		limiter: newMessageLimiter(hub.messageLimits),
 	}
 }
 
@@ -61,6 +66,17 @@ func (c *LobbyClient) ReadPump() {
 			continue
 		}
 
+		allowed, disconnect := c.limiter.Allow(string(msg.Type))
+		if disconnect {
+			log.Printf("Disconnecting lobby user %s for sending too many messages", c.userID)
+			closeForFlooding(c.conn)
+			break
+		}
+		if !allowed {
+			c.sendError("RATE_LIMITED", "Too many messages, slow down")
+			continue
+		}
+
 		c.handleMessage(&msg)
 	}
 }
@@ -105,6 +121,10 @@ func (c *LobbyClient) handleMessage(msg *LobbyMessage) {
 	switch msg.Type {
 	case LobbyMsgJoinLobby:
 		c.handleJoinLobby(msg)
+	case LobbyMsgCaptainPick:
+		c.handleCaptainPick(msg)
+	case LobbyMsgChatSend:
+		c.handleChatSend(msg)
 	default:
 		log.Printf("LobbyClient unknown message type: %s", msg.Type)
 		c.sendError("UNKNOWN_MESSAGE", "Unknown message type")
@@ -137,6 +157,51 @@ func (c *LobbyClient) handleJoinLobby(msg *LobbyMessage) {
 	}
 }
 
+// handleCaptainPick processes a captain's pick in a captain's draft
+func (c *LobbyClient) handleCaptainPick(msg *LobbyMessage) {
+	payloadBytes, err := json.Marshal(msg.Payload)
+	if err != nil {
+		c.sendError("INVALID_PAYLOAD", "Invalid payload")
+		return
+	}
+
+	var payload CaptainPickPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		c.sendError("INVALID_PAYLOAD", "Invalid captain pick payload")
+		return
+	}
+
+	pickedUserID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		c.sendError("INVALID_USER_ID", "Invalid user ID format")
+		return
+	}
+
+	c.hub.handleCaptainPick(c, pickedUserID)
+}
+
+// handleChatSend processes a chat message from the client
+func (c *LobbyClient) handleChatSend(msg *LobbyMessage) {
+	payloadBytes, err := json.Marshal(msg.Payload)
+	if err != nil {
+		c.sendError("INVALID_PAYLOAD", "Invalid payload")
+		return
+	}
+
+	var payload ChatSendPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		c.sendError("INVALID_PAYLOAD", "Invalid chat payload")
+		return
+	}
+
+	if !c.chatLimiter.Allow() {
+		c.sendError("RATE_LIMITED", "You are sending messages too quickly")
+		return
+	}
+
+	c.hub.handleChatSend(c, domain.ChatChannel(payload.Channel), payload.Body)
+}
+
 // Send sends a message to the client
 func (c *LobbyClient) Send(msg *LobbyMessage) {
 	data, err := json.Marshal(msg)
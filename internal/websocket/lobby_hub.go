@@ -33,6 +33,15 @@ type LobbyHub struct {
 	matchOptionRepo repository.MatchOptionRepository
 	userRepo        repository.UserRepository
 
+	// Captain's drafts: each LobbyState serializes its picks, timersMu guards the pick timers
+	captainPicker CaptainPicker
+	timersMu      sync.Mutex
+	pickTimers    map[uuid.UUID]*pickTimer
+
+	chat LobbyChat
+
+	messageLimits MessageLimits
+
 	mu sync.RWMutex
 }
 
@@ -55,6 +64,7 @@ func NewLobbyHub(
 		lobbyPlayerRepo: lobbyPlayerRepo,
 		matchOptionRepo: matchOptionRepo,
 		userRepo:        userRepo,
+		pickTimers:      make(map[uuid.UUID]*pickTimer),
 	}
 }
 
@@ -78,6 +88,7 @@ func (h *LobbyHub) Run() {
 			h.clients = make(map[*LobbyClient]bool)
 			h.lobbies = make(map[uuid.UUID]*LobbyState)
 			h.mu.Unlock()
+			h.stopAllPickTimers()
 			return
 
 		case client := <-h.register:
@@ -100,7 +111,7 @@ func (h *LobbyHub) Run() {
 						if state, exists := h.lobbies[lobbyID]; exists {
 							state.RemoveClient(client)
 							// Clean up empty lobbies
-							if state.ClientCount() == 0 {
+							if state.ClientCount() == 0 && state.pickers == 0 {
 								delete(h.lobbies, lobbyID)
 							}
 						}
@@ -171,7 +182,7 @@ func (h *LobbyHub) handleJoinLobby(req *JoinLobbyRequest) {
 		h.mu.Lock()
 		if state, exists := h.lobbies[oldLobbyID]; exists {
 			state.RemoveClient(req.Client)
-			if state.ClientCount() == 0 {
+			if state.ClientCount() == 0 && state.pickers == 0 {
 				delete(h.lobbies, oldLobbyID)
 			}
 		}
@@ -191,9 +202,17 @@ func (h *LobbyHub) handleJoinLobby(req *JoinLobbyRequest) {
 	state.AddClient(req.Client)
 	req.Client.SetLobbyID(req.LobbyID)
 
+	// Keep a captain's draft moving if its pick timer was lost, such as after a restart
+	if lobby.Status == domain.LobbyStatusCaptainsDraft {
+		if players, err := h.lobbyPlayerRepo.GetByLobbyID(ctx, lobby.ID); err == nil {
+			h.resumeCaptainsDraft(lobby.ID, players, lobby.PlayersPerTeam())
+		}
+	}
+
 	// Build and send state sync
 	syncPayload := h.buildStateSyncPayload(ctx, lobby, state)
 	req.Client.Send(NewLobbyMessage(LobbyMsgStateSync, syncPayload))
+	h.sendChatHistory(ctx, req.Client, req.LobbyID)
 
 	log.Printf("LobbyHub: Client %s joined lobby %s (now %d clients)", req.Client.userID, req.LobbyID, state.ClientCount())
 }
@@ -232,6 +251,7 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 			AssignedRole: role,
 			IsReady:      p.IsReady,
 			IsCaptain:    p.IsCaptain,
+			ChatMuted:    p.ChatMuted,
 			JoinOrder:    p.JoinOrder,
 		}
 	}
@@ -256,6 +276,8 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 		SelectedMatchOption:  lobby.SelectedMatchOption,
 		DraftMode:            string(lobby.DraftMode),
 		TimerDurationSeconds: lobby.TimerDurationSeconds,
+		TeamSize:             lobby.PlayersPerTeam(),
+		MaxPlayers:           lobby.MaxPlayers(),
 		RoomID:               roomID,
 		VotingEnabled:        lobby.VotingEnabled,
 		VotingMode:           string(lobby.VotingMode),
@@ -283,6 +305,7 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 					ComfortRating: a.ComfortRating,
 				}
 			}
+			satisfied, unsatisfied, _ := opt.GetPreferenceResults()
 			matchOptionInfos[i] = MatchOptionInfo{
 				OptionNumber:   opt.OptionNumber,
 				AlgorithmType:  string(opt.AlgorithmType),
@@ -294,6 +317,9 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 				AvgRedComfort:  opt.AvgRedComfort,
 				MaxLaneDiff:    opt.MaxLaneDiff,
 				Assignments:    assignments,
+
+				SatisfiedPreferences:   preferenceIDStrings(satisfied),
+				UnsatisfiedPreferences: preferenceIDStrings(unsatisfied),
 			}
 		}
 	}
@@ -335,6 +361,11 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 		}
 	}
 
+	var captainsDraft *CaptainsDraftInfo
+	if lobby.Status == domain.LobbyStatusCaptainsDraft {
+		captainsDraft = h.captainsDraftInfo(lobby.ID, domain.NewCaptainsDraft(players, lobby.PlayersPerTeam()))
+	}
+
 	return &LobbyStateSyncPayload{
 		Lobby:         lobbyInfo,
 		Players:       playerInfos,
@@ -342,6 +373,7 @@ func (h *LobbyHub) buildStateSyncPayload(ctx context.Context, lobby *domain.Lobb
 		VotingStatus:  votingStatus,
 		Votes:         state.GetVotes(),
 		PendingAction: state.GetPendingAction().ToInfo(),
+		CaptainsDraft: captainsDraft,
 	}
 }
 
@@ -424,6 +456,7 @@ func (h *LobbyHub) BroadcastPlayerJoined(lobbyID uuid.UUID, player *domain.Lobby
 			AssignedRole: role,
 			IsReady:      player.IsReady,
 			IsCaptain:    player.IsCaptain,
+			ChatMuted:    player.ChatMuted,
 			JoinOrder:    player.JoinOrder,
 		},
 	}))
@@ -494,6 +527,7 @@ func (h *LobbyHub) BroadcastMatchOptionsGenerated(lobbyID uuid.UUID, options []*
 				ComfortRating: a.ComfortRating,
 			}
 		}
+		satisfied, unsatisfied, _ := opt.GetPreferenceResults()
 		optionInfos[i] = MatchOptionInfo{
 			OptionNumber:   opt.OptionNumber,
 			AlgorithmType:  string(opt.AlgorithmType),
@@ -505,6 +539,9 @@ func (h *LobbyHub) BroadcastMatchOptionsGenerated(lobbyID uuid.UUID, options []*
 			AvgRedComfort:  opt.AvgRedComfort,
 			MaxLaneDiff:    opt.MaxLaneDiff,
 			Assignments:    assignments,
+
+			SatisfiedPreferences:   preferenceIDStrings(satisfied),
+			UnsatisfiedPreferences: preferenceIDStrings(unsatisfied),
 		}
 	}
 
@@ -513,6 +550,15 @@ func (h *LobbyHub) BroadcastMatchOptionsGenerated(lobbyID uuid.UUID, options []*
 	}))
 }
 
+// preferenceIDStrings converts the preference IDs recorded on a match option for the client
+func preferenceIDStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
+
 // BroadcastTeamSelected broadcasts team selection
 func (h *LobbyHub) BroadcastTeamSelected(lobbyID uuid.UUID, optionNumber int, players []LobbyPlayerInfo, stats *TeamStatsInfo) {
 	state := h.GetLobbyStateIfExists(lobbyID)
@@ -641,6 +687,8 @@ func (h *LobbyHub) BroadcastDraftStarting(lobbyID uuid.UUID, roomID uuid.UUID, s
 
 // BroadcastCaptainChanged broadcasts captain change
 func (h *LobbyHub) BroadcastCaptainChanged(lobbyID uuid.UUID, team string, newCaptainID uuid.UUID, newCaptainName string, oldCaptainID *uuid.UUID) {
+	h.postSystemMessage(lobbyID, "%s is now %s captain", newCaptainName, team)
+
 	state := h.GetLobbyStateIfExists(lobbyID)
 	if state == nil {
 		return
@@ -660,6 +708,8 @@ func (h *LobbyHub) BroadcastCaptainChanged(lobbyID uuid.UUID, team string, newCa
 
 // BroadcastPlayerKicked broadcasts player kick
 func (h *LobbyHub) BroadcastPlayerKicked(lobbyID uuid.UUID, userID uuid.UUID, displayName string, kickedBy string) {
+	h.postSystemMessage(lobbyID, "%s was kicked by %s", displayName, kickedBy)
+
 	state := h.GetLobbyStateIfExists(lobbyID)
 	if state == nil {
 		return
@@ -672,6 +722,21 @@ func (h *LobbyHub) BroadcastPlayerKicked(lobbyID uuid.UUID, userID uuid.UUID, di
 	}))
 }
 
+// CloseLobby tells the lobby's clients it was deleted and forgets its state
+func (h *LobbyHub) CloseLobby(lobbyID uuid.UUID, reason string) {
+	h.stopPickTimer(lobbyID)
+
+	h.mu.Lock()
+	state := h.lobbies[lobbyID]
+	delete(h.lobbies, lobbyID)
+	h.mu.Unlock()
+
+	if state == nil {
+		return
+	}
+	state.Broadcast(NewLobbyMessage(LobbyMsgLobbyClosed, LobbyClosedPayload{Reason: reason}))
+}
+
 // BroadcastTeamStatsUpdated broadcasts team stats update
 func (h *LobbyHub) BroadcastTeamStatsUpdated(lobbyID uuid.UUID, stats *TeamStatsInfo) {
 	state := h.GetLobbyStateIfExists(lobbyID)
@@ -28,10 +28,16 @@ const (
 	LobbyMsgPlayerKicked          LobbyMessageType = "player_kicked"
 	LobbyMsgTeamStatsUpdated      LobbyMessageType = "team_stats_updated"
 	LobbyMsgVotingStatusUpdated   LobbyMessageType = "voting_status_updated"
+	LobbyMsgCaptainsDraftUpdated  LobbyMessageType = "captains_draft_updated"
+	LobbyMsgChatMessage           LobbyMessageType = "chat_message"
+	LobbyMsgChatHistory           LobbyMessageType = "chat_history"
+	LobbyMsgLobbyClosed           LobbyMessageType = "lobby_closed"
 	LobbyMsgError                 LobbyMessageType = "error"
 
 	// Client -> Server commands
-	LobbyMsgJoinLobby LobbyMessageType = "join_lobby"
+	LobbyMsgJoinLobby   LobbyMessageType = "join_lobby"
+	LobbyMsgCaptainPick LobbyMessageType = "captain_pick"
+	LobbyMsgChatSend    LobbyMessageType = "chat_send"
 )
 
 // LobbyMessage is the envelope for all lobby WebSocket messages
@@ -61,6 +67,7 @@ type LobbyStateSyncPayload struct {
 	VotingStatus  *VotingStatusInfo     `json:"votingStatus,omitempty"`
 	Votes         map[string]int        `json:"votes"`         // userID -> optionNumber (in-memory)
 	PendingAction *InMemoryActionInfo   `json:"pendingAction"` // in-memory pending action
+	CaptainsDraft *CaptainsDraftInfo    `json:"captainsDraft,omitempty"`
 }
 
 // LobbyInfo contains lobby metadata
@@ -72,6 +79,8 @@ type LobbyInfo struct {
 	SelectedMatchOption  *int    `json:"selectedMatchOption"`
 	DraftMode            string  `json:"draftMode"`
 	TimerDurationSeconds int     `json:"timerDurationSeconds"`
+	TeamSize             int     `json:"teamSize"`
+	MaxPlayers           int     `json:"maxPlayers"`
 	RoomID               *string `json:"roomId"`
 	VotingEnabled        bool    `json:"votingEnabled"`
 	VotingMode           string  `json:"votingMode"`
@@ -87,6 +96,7 @@ type LobbyPlayerInfo struct {
 	AssignedRole *string `json:"assignedRole"`
 	IsReady      bool    `json:"isReady"`
 	IsCaptain    bool    `json:"isCaptain"`
+	ChatMuted    bool    `json:"chatMuted"`
 	JoinOrder    int     `json:"joinOrder"`
 }
 
@@ -102,6 +112,9 @@ type MatchOptionInfo struct {
 	AvgRedComfort  float64                `json:"avgRedComfort"`
 	MaxLaneDiff    int                    `json:"maxLaneDiff"`
 	Assignments    []AssignmentInfo       `json:"assignments"`
+	// IDs of the lobby preferences the option keeps and breaks
+	SatisfiedPreferences   []string `json:"satisfiedPreferences"`
+	UnsatisfiedPreferences []string `json:"unsatisfiedPreferences"`
 }
 
 // AssignmentInfo contains player assignment data
@@ -158,6 +171,37 @@ type InMemoryActionInfo struct {
 	ExpiresAt      string    `json:"expiresAt"`
 }
 
+// CaptainsDraftInfo contains captain's draft progress
+type CaptainsDraftInfo struct {
+	BlueCaptainID string           `json:"blueCaptainId"`
+	RedCaptainID  string           `json:"redCaptainId"`
+	Picks         int              `json:"picks"`
+	TotalPicks    int              `json:"totalPicks"`
+	NextSide      string           `json:"nextSide,omitempty"`
+	PickEndsAt    *string          `json:"pickEndsAt,omitempty"` // when the next pick is made for the captain
+	LastPick      *CaptainPickInfo `json:"lastPick,omitempty"`
+}
+
+// CaptainPickInfo contains a pick made in a captain's draft
+type CaptainPickInfo struct {
+	UserID string `json:"userId"`
+	Team   string `json:"team"`
+	Pick   int    `json:"pick"`
+	Auto   bool   `json:"auto"`
+}
+
+// ChatMessageInfo contains a chat message
+type ChatMessageInfo struct {
+	ID          string  `json:"id"`
+	UserID      *string `json:"userId"` // nil for system messages
+	DisplayName string  `json:"displayName"`
+	Channel     string  `json:"channel"`
+	Team        *string `json:"team,omitempty"`
+	Body        string  `json:"body"`
+	IsSystem    bool    `json:"isSystem"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
 // ============== Event payloads ==============
 
 // PlayerJoinedPayload is sent when a player joins
@@ -252,6 +296,11 @@ type PlayerKickedPayload struct {
 	KickedBy    string `json:"kickedBy"`
 }
 
+// LobbyClosedPayload is sent when an admin deletes the lobby
+type LobbyClosedPayload struct {
+	Reason string `json:"reason"`
+}
+
 // TeamStatsUpdatedPayload is sent when team stats are recalculated
 type TeamStatsUpdatedPayload struct {
 	Stats TeamStatsInfo `json:"stats"`
@@ -262,6 +311,21 @@ type VotingStatusUpdatedPayload struct {
 	Status VotingStatusInfo `json:"status"`
 }
 
+// CaptainsDraftUpdatedPayload is sent when a captain's draft starts and after each pick
+type CaptainsDraftUpdatedPayload struct {
+	Draft CaptainsDraftInfo `json:"draft"`
+}
+
+// ChatMessagePayload is sent to everyone who can read a new chat message
+type ChatMessagePayload struct {
+	Message ChatMessageInfo `json:"message"`
+}
+
+// ChatHistoryPayload is sent to a client when it joins a lobby
+type ChatHistoryPayload struct {
+	Messages []ChatMessageInfo `json:"messages"`
+}
+
 // LobbyErrorPayload is sent on errors
 type LobbyErrorPayload struct {
 	Code    string `json:"code"`
@@ -275,6 +339,17 @@ type JoinLobbyPayload struct {
 	LobbyID string `json:"lobbyId"`
 }
 
+// CaptainPickPayload is sent by the captain whose turn it is to pick a bench player
+type CaptainPickPayload struct {
+	UserID string `json:"userId"`
+}
+
+// ChatSendPayload is sent by a player to post to the all channel or their team's channel
+type ChatSendPayload struct {
+	Channel string `json:"channel"`
+	Body    string `json:"body"`
+}
+
 // ============== In-memory structures ==============
 
 // InMemoryPendingAction stores a pending action in memory
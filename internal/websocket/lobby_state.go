@@ -15,6 +15,11 @@ type LobbyState struct {
 	clients       map[*LobbyClient]bool
 	votes         map[uuid.UUID]map[int]bool // userID -> optionNumbers (set)
 	pendingAction *InMemoryPendingAction
+
+	// pickMu serializes the picks of the lobby's captain's draft. pickers counts who holds or
+	// waits for it and is guarded by the hub's mu, so the state is never dropped mid-pick
+	pickMu  sync.Mutex
+	pickers int
 }
 
 // NewLobbyState creates a new lobby state
@@ -69,6 +74,18 @@ func (s *LobbyState) BroadcastExcept(msg *LobbyMessage, except *LobbyClient) {
 	}
 }
 
+// BroadcastWhere sends a message to the clients whose user matches the filter
+func (s *LobbyState) BroadcastWhere(msg *LobbyMessage, include func(userID uuid.UUID) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for client := range s.clients {
+		if include(client.userID) {
+			client.Send(msg)
+		}
+	}
+}
+
 // SendToUser sends a message to a specific user
 func (s *LobbyState) SendToUser(userID uuid.UUID, msg *LobbyMessage) {
 	s.mu.RLock()
@@ -22,6 +22,8 @@ const (
 	MessageTypeConfirmEdit     MessageType = "CONFIRM_EDIT"
 	MessageTypeRejectEdit      MessageType = "REJECT_EDIT"
 	MessageTypeReadyToResume   MessageType = "READY_TO_RESUME"
+	MessageTypeSendChat        MessageType = "SEND_CHAT"
+	MessageTypeSendPing        MessageType = "SEND_PING"
 
 	// Server to Client
 	MessageTypeStateSync        MessageType = "STATE_SYNC"
@@ -40,6 +42,13 @@ const (
 	MessageTypeEditRejected      MessageType = "EDIT_REJECTED"
 	MessageTypeResumeReadyUpdate MessageType = "RESUME_READY_UPDATE"
 	MessageTypeResumeCountdown   MessageType = "RESUME_COUNTDOWN"
+	MessageTypeSeriesUpdated     MessageType = "SERIES_UPDATED"
+	MessageTypeSuggestionsUpdated MessageType = "SUGGESTIONS_UPDATED"
+	MessageTypeTradePhaseStarted MessageType = "TRADE_PHASE_STARTED"
+	MessageTypeTradeUpdated      MessageType = "TRADE_UPDATED"
+	MessageTypeTradeFinished     MessageType = "TRADE_FINISHED"
+	MessageTypeChatMessage       MessageType = "CHAT_MESSAGE"
+	MessageTypePingMarker        MessageType = "PING_MARKER"
 	MessageTypeError             MessageType = "ERROR"
 )
 
@@ -84,15 +93,33 @@ type ReadyPayload struct {
 // Server to Client payloads
 
 type StateSyncPayload struct {
-	Room           RoomInfo         `json:"room"`
-	Draft          DraftInfo        `json:"draft"`
-	Players        PlayersInfo      `json:"players"`
-	YourSide       string           `json:"yourSide"`
-	IsCaptain      bool             `json:"isCaptain"`
-	IsTeamDraft    bool             `json:"isTeamDraft"`
-	TeamPlayers    []TeamPlayerInfo `json:"teamPlayers,omitempty"`
-	SpectatorCount int              `json:"spectatorCount"`
-	FearlessBans   []string         `json:"fearlessBans,omitempty"`
+	Room           RoomInfo           `json:"room"`
+	Draft          DraftInfo          `json:"draft"`
+	Players        PlayersInfo        `json:"players"`
+	YourSide       string             `json:"yourSide"`
+	IsCaptain      bool               `json:"isCaptain"`
+	IsTeamDraft    bool               `json:"isTeamDraft"`
+	TeamPlayers    []TeamPlayerInfo   `json:"teamPlayers,omitempty"`
+	SpectatorCount int                `json:"spectatorCount"`
+	FearlessBans   []string           `json:"fearlessBans,omitempty"`
+	Template       *DraftTemplateInfo `json:"template,omitempty"`
+	ChampionPool   []string           `json:"championPool,omitempty"`
+	Suggestions    []SuggestionInfo   `json:"suggestions,omitempty"`
+	Chat           []ChatMessageInfo  `json:"chat,omitempty"`
+}
+
+// HiddenChampionID replaces opponent picks in blind drafts until the draft completes
+const HiddenChampionID = "Hidden"
+
+type DraftTemplateInfo struct {
+	Name   string              `json:"name"`
+	Kind   string              `json:"kind"`
+	Phases []TemplatePhaseInfo `json:"phases"`
+}
+
+type TemplatePhaseInfo struct {
+	Team       string `json:"team"`
+	ActionType string `json:"actionType"`
 }
 
 type TeamPlayerInfo struct {
@@ -128,6 +155,8 @@ type DraftInfo struct {
 	BlueResumeReady  bool     `json:"blueResumeReady,omitempty"`
 	RedResumeReady   bool     `json:"redResumeReady,omitempty"`
 	ResumeCountdown  int      `json:"resumeCountdown,omitempty"`
+	Reserve          *ReserveInfo `json:"reserve,omitempty"`
+	Trade            *TradeInfo   `json:"trade,omitempty"`
 }
 
 type PendingEditInfo struct {
@@ -185,8 +214,17 @@ type PhaseChangedPayload struct {
 }
 
 type TimerTickPayload struct {
-	RemainingMs    int  `json:"remainingMs"`
-	IsBufferPeriod bool `json:"isBufferPeriod"`
+	RemainingMs    int          `json:"remainingMs"`
+	IsBufferPeriod bool         `json:"isBufferPeriod"`
+	Reserve        *ReserveInfo `json:"reserve,omitempty"`
+}
+
+// ReserveInfo is each side's remaining chess-clock reserve.
+// Active is set while the acting side is drawing on its reserve.
+type ReserveInfo struct {
+	BlueMs int  `json:"blueMs"`
+	RedMs  int  `json:"redMs"`
+	Active bool `json:"active"`
 }
 
 type TimerExpiredPayload struct {
@@ -199,6 +237,9 @@ type DraftCompletedPayload struct {
 	RedBans   []string `json:"redBans"`
 	BluePicks []string `json:"bluePicks"`
 	RedPicks  []string `json:"redPicks"`
+	// Which player plays each pick, in team drafts
+	BlueAssignments []PickAssignmentInfo `json:"blueAssignments,omitempty"`
+	RedAssignments  []PickAssignmentInfo `json:"redAssignments,omitempty"`
 }
 
 type ErrorPayload struct {
@@ -270,3 +311,106 @@ type ResumeCountdownPayload struct {
 	SecondsRemaining int    `json:"secondsRemaining"`
 	CancelledBy      string `json:"cancelledBy,omitempty"`
 }
+
+// Series payloads
+
+type SeriesUpdatedPayload struct {
+	SeriesID   string          `json:"seriesId"`
+	Format     string          `json:"format"`
+	Status     string          `json:"status"`
+	BlueWins   int             `json:"blueWins"`
+	RedWins    int             `json:"redWins"`
+	WinnerSide string          `json:"winnerSide,omitempty"`
+	NextGame   *SeriesGameInfo `json:"nextGame,omitempty"`
+}
+
+type SeriesGameInfo struct {
+	GameNumber int    `json:"gameNumber"`
+	RoomID     string `json:"roomId"`
+	ShortCode  string `json:"shortCode"`
+}
+
+// Suggestion payloads
+
+// SuggestionsUpdatedPayload is a team's suggestion list, most voted first.
+// It is only sent to that team; suggestions reset when the phase changes.
+type SuggestionsUpdatedPayload struct {
+	Side        string           `json:"side"`
+	Suggestions []SuggestionInfo `json:"suggestions"`
+}
+
+type SuggestionInfo struct {
+	ChampionID      string   `json:"championId"`
+	SuggestedBy     string   `json:"suggestedBy"`
+	SuggestedByName string   `json:"suggestedByName"`
+	Votes           int      `json:"votes"`
+	VoterIDs        []string `json:"voterIds"`
+}
+
+// Trade payloads
+
+type TradePhaseStartedPayload struct {
+	DurationMs int   `json:"durationMs"`
+	EndsAt     int64 `json:"endsAt"`
+}
+
+// TradeUpdatedPayload is a team's champion assignments and open trade offers.
+// It is only sent to that team.
+type TradeUpdatedPayload struct {
+	Side        string               `json:"side"`
+	Assignments []PickAssignmentInfo `json:"assignments"`
+	Offers      []TradeOfferInfo     `json:"offers"`
+	Finished    bool                 `json:"finished"`
+}
+
+// TradeFinishedPayload is sent to everyone when a team's captain is done trading.
+// PhaseEnded is set once both teams are, and the draft completes.
+type TradeFinishedPayload struct {
+	Side       string `json:"side"`
+	PhaseEnded bool   `json:"phaseEnded"`
+}
+
+// TradeInfo describes the trade phase in a STATE_SYNC.
+// Assignments and offers are only filled in for the client's own team.
+type TradeInfo struct {
+	EndsAt      int64                `json:"endsAt"`
+	Assignments []PickAssignmentInfo `json:"assignments,omitempty"`
+	Offers      []TradeOfferInfo     `json:"offers,omitempty"`
+	Finished    bool                 `json:"finished"`
+}
+
+type PickAssignmentInfo struct {
+	ChampionID  string `json:"championId"`
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Role        string `json:"role"`
+}
+
+type TradeOfferInfo struct {
+	ID             string `json:"id"`
+	FromUserID     string `json:"fromUserId"`
+	ToUserID       string `json:"toUserId"`
+	FromChampionID string `json:"fromChampionId"`
+	ToChampionID   string `json:"toChampionId"`
+}
+
+// Chat payloads
+
+// RoomChatMessagePayload is a chat message in the draft room.
+// Team channel messages are only sent to the sender's side.
+type RoomChatMessagePayload struct {
+	Message ChatMessageInfo `json:"message"`
+}
+
+// PingMarkerPayload points a champion or a pick/ban slot out to a side or the whole room.
+// Pings are not persisted.
+type PingMarkerPayload struct {
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Side        string `json:"side"`
+	Channel     string `json:"channel"`
+	ChampionID  string `json:"championId,omitempty"`
+	SlotType    string `json:"slotType,omitempty"`
+	Team        string `json:"team,omitempty"`
+	SlotIndex   *int   `json:"slotIndex,omitempty"`
+}
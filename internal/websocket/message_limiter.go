@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/ratelimit"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	budgetHover = "hover"
+	budgetOther = "other"
+
+	// maxMessageBudgets caps how many message kinds get their own bucket, since clients
+	// choose the type names they send.
+	maxMessageBudgets = 16
+)
+
+// MessageLimits are the budgets for the messages a single connection sends.
+// A zero limit turns that budget off.
+type MessageLimits struct {
+	Message       ratelimit.Limit // each kind of message
+	Hover         ratelimit.Limit // champion hovers
+	MaxViolations int             // messages over budget per minute before the connection is dropped
+}
+
+// SetMessageLimits sets the budgets for clients that connect from now on.
+func (h *Hub) SetMessageLimits(limits MessageLimits) {
+	h.messageLimits = limits
+}
+
+// SetMessageLimits sets the budgets for clients that connect from now on.
+func (h *LobbyHub) SetMessageLimits(limits MessageLimits) {
+	h.messageLimits = limits
+}
+
+// messageLimiter gives each kind of message a connection sends its own token bucket, and
+// counts the messages it drops. It is only used from the connection's read pump.
+type messageLimiter struct {
+	limits     MessageLimits
+	buckets    map[string]*ratelimit.Bucket
+	violations *ratelimit.Bucket
+}
+
+func newMessageLimiter(limits MessageLimits) *messageLimiter {
+	l := &messageLimiter{
+		limits:  limits,
+		buckets: make(map[string]*ratelimit.Bucket),
+	}
+	if limits.MaxViolations > 0 {
+		l.violations = ratelimit.NewBucket(ratelimit.PerMinute(limits.MaxViolations, limits.MaxViolations))
+	}
+	return l
+}
+
+// Allow takes a token for a message of the given kind. It returns false if the message is
+// over budget, and disconnect once the connection has gone over budget too often.
+func (l *messageLimiter) Allow(kind string) (allowed, disconnect bool) {
+	now := time.Now()
+
+	bucket, ok := l.buckets[kind]
+	if !ok {
+		if len(l.buckets) >= maxMessageBudgets {
+			kind = budgetOther
+			bucket, ok = l.buckets[kind]
+		}
+		if !ok {
+			limit := l.limits.Message
+			if kind == budgetHover {
+				limit = l.limits.Hover
+			}
+			bucket = ratelimit.NewBucket(limit)
+			l.buckets[kind] = bucket
+		}
+	}
+
+	if allowed, _ := bucket.Allow(now); allowed {
+		return true, false
+	}
+	if l.violations == nil {
+		return false, false
+	}
+	ok, _ = l.violations.Allow(now)
+	return false, !ok
+}
+
+// draftMessageKind names the budget a draft room message counts against. Hovers get their
+// own, since clients send them far more often than anything else.
+func draftMessageKind(msg *Message) string {
+	switch msg.Type {
+	case MessageTypeHoverChampion:
+		return budgetHover
+	case MessageType(MsgTypeCommand):
+		var cmd Command
+		if json.Unmarshal(msg.Payload, &cmd) == nil && cmd.Action == CmdHoverChampion {
+			return budgetHover
+		}
+		return string(msg.Type) + ":" + string(cmd.Action)
+	}
+	return string(msg.Type)
+}
+
+// closeForFlooding tells a client it sent too many messages before the read pump drops it.
+func closeForFlooding(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "Too many messages")
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+}
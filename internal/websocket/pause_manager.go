@@ -99,8 +99,7 @@ func (pm *PauseManager) Pause(userID uuid.UUID, side string) error {
 	pm.pausedAt = time.Now()
 
 	// Reset resume-ready state
-	pm.blueResumeReady = false
-	pm.redResumeReady = false
+	pm.resetResumeReadyLocked()
 	pm.resumeCountdown = 0
 
 	// Start auto-resume timer (5 minutes)
@@ -114,9 +113,32 @@ func (pm *PauseManager) Pause(userID uuid.UUID, side string) error {
 	// Broadcast pause event
 	pm.room.emitter.DraftPaused(pm.room.getUserDisplayName(userID), side, pm.frozenTimerMs, pm.maxPauseDurationMs)
 
+	pm.room.draftMgr.persistState()
+
 	return nil
 }
 
+// Restore puts a rehydrated draft back into the paused state with the saved timer value.
+// The usual ready-to-resume flow and auto-resume timeout apply from here.
+func (pm *PauseManager) Restore(frozenMs int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.room.timerMgr.Freeze(frozenMs)
+
+	pm.isPaused = true
+	pm.frozenTimerMs = frozenMs
+	pm.pausedAt = time.Now()
+	pm.resetResumeReadyLocked()
+
+	pm.pauseTimer = time.AfterFunc(
+		time.Duration(pm.maxPauseDurationMs)*time.Millisecond,
+		pm.handleAutoResume,
+	)
+
+	log.Printf("Draft restored as paused, timer frozen at %dms", frozenMs)
+}
+
 // SetResumeReady updates the resume-ready status for a player.
 func (pm *PauseManager) SetResumeReady(userID uuid.UUID, side string, ready bool) error {
 	pm.mu.Lock()
@@ -150,6 +172,12 @@ func (pm *PauseManager) SetResumeReady(userID uuid.UUID, side string, ready bool
 	return nil
 }
 
+// resetResumeReadyLocked clears the resume-ready flags. A bot-drafted side is always ready.
+func (pm *PauseManager) resetResumeReadyLocked() {
+	pm.blueResumeReady = pm.room.draftMgr.IsBotSide("blue")
+	pm.redResumeReady = pm.room.draftMgr.IsBotSide("red")
+}
+
 // startCountdownLocked starts the 5-second countdown before resuming.
 // Must be called with lock held.
 func (pm *PauseManager) startCountdownLocked() {
@@ -209,8 +237,7 @@ func (pm *PauseManager) cancelCountdownLocked(cancelledBy uuid.UUID) {
 
 	// Reset state
 	pm.resumeCountdown = 0
-	pm.blueResumeReady = false
-	pm.redResumeReady = false
+	pm.resetResumeReadyLocked()
 
 	log.Printf("Resume countdown cancelled by %s", cancelledBy)
 
@@ -250,8 +277,9 @@ func (pm *PauseManager) doResumeLocked() {
 	if pm.room.editMgr != nil {
 		pm.room.editMgr.Clear()
 	}
-	pm.room.timerMgr.SetDuration(remainingMs)
-	pm.room.timerMgr.Start()
+	pm.room.timerMgr.StartFrom(remainingMs)
+	pm.room.draftMgr.persistState()
+	pm.room.draftMgr.scheduleBotTurn()
 }
 
 // handleAutoResume is called when the pause timer expires.
@@ -289,8 +317,9 @@ func (pm *PauseManager) handleAutoResume() {
 	if pm.room.editMgr != nil {
 		pm.room.editMgr.Clear()
 	}
-	pm.room.timerMgr.SetDuration(remainingMs)
-	pm.room.timerMgr.Start()
+	pm.room.timerMgr.StartFrom(remainingMs)
+	pm.room.draftMgr.persistState()
+	pm.room.draftMgr.scheduleBotTurn()
 }
 
 // Errors
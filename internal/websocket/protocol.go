@@ -0,0 +1,463 @@
+package websocket
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Protocol versions spoken on the draft socket.
+//
+// v1 clients receive the legacy MessageType frames from message.go. v2 clients
+// receive only EVENT/STATE/TIMER/ERR envelopes from types.go. Both send v2
+// COMMAND/QUERY frames; v1 clients may also still send the legacy client
+// message types, which are translated to commands below.
+const (
+	ProtocolV1 = 1
+	ProtocolV2 = 2
+)
+
+// MsgTypeHello is the optional first frame a client sends to negotiate a protocol
+// version; the server answers with a HELLO carrying the version it will speak.
+const MsgTypeHello MsgType = "HELLO"
+
+type HelloPayload struct {
+	Version int `json:"version"`
+}
+
+// ParseProtocolVersion maps the ?v= query parameter to a protocol version.
+// Anything other than "2" keeps the v1 compatibility shim.
+func ParseProtocolVersion(v string) int {
+	if v == "2" {
+		return ProtocolV2
+	}
+	return ProtocolV1
+}
+
+// frames holds one outbound message encoded for each protocol version, so a
+// broadcast is only encoded once per version regardless of client count.
+type frames struct {
+	v1 []byte
+	v2 []byte
+}
+
+// encodeFrames encodes msg for v1 and v2 clients.
+func encodeFrames(msg *Message) frames {
+	v1, _ := json.Marshal(msg)
+	v2, _ := json.Marshal(toV2(msg))
+	return frames{v1: v1, v2: v2}
+}
+
+// forProtocol returns the frame for a client speaking the given version.
+func (f frames) forProtocol(version int) []byte {
+	if version == ProtocolV2 {
+		return f.v2
+	}
+	return f.v1
+}
+
+// toV2 converts a legacy server message into its v2 envelope.
+// Message types without a dedicated mapping become an EVENT named after the
+// lowercased type with the payload passed through unchanged.
+func toV2(msg *Message) *Msg {
+	msgType, payload := convertPayload(msg)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		msgType = MsgTypeEvent
+		data, _ = json.Marshal(Event{Event: legacyEventType(msg.Type), Payload: msg.Payload})
+	}
+
+	return &Msg{
+		Type:      msgType,
+		Payload:   data,
+		Timestamp: msg.Timestamp,
+		Seq:       msg.Seq,
+	}
+}
+
+// convertPayload returns the v2 envelope type and payload for a legacy message.
+func convertPayload(msg *Message) (MsgType, interface{}) {
+	switch msg.Type {
+	case MessageTypeStateSync:
+		var p StateSyncPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return MsgTypeState, stateToV2(&p)
+		}
+
+	case MessageTypeTimerTick:
+		var p TimerTickPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return MsgTypeTimer, timerFrame(TimerTick, TimerTickPayloadV2{
+				Remaining:      p.RemainingMs,
+				IsBufferPeriod: p.IsBufferPeriod,
+				Reserve:        p.Reserve,
+			})
+		}
+
+	case MessageTypeTimerExpired:
+		var p TimerExpiredPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return MsgTypeTimer, timerFrame(TimerExpired, TimerExpiredPayloadV2{
+				Phase:        p.Phase,
+				AutoSelected: p.AutoSelected,
+			})
+		}
+
+	case MessageTypeError:
+		var p ErrorPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return MsgTypeErr, Err{Code: p.Code, Message: p.Message}
+		}
+
+	default:
+		if evtType, payload, ok := eventToV2(msg); ok {
+			evt, _ := NewEvent(evtType, payload)
+			return MsgTypeEvent, evt
+		}
+	}
+
+	return MsgTypeEvent, Event{Event: legacyEventType(msg.Type), Payload: msg.Payload}
+}
+
+// eventToV2 converts a legacy event message into a v2 event type and payload.
+func eventToV2(msg *Message) (EventType, interface{}, bool) {
+	switch msg.Type {
+	case MessageTypePlayerUpdate:
+		var p PlayerUpdatePayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return "", nil, false
+		}
+		switch p.Action {
+		case "joined":
+			player := PlayerData{}
+			if p.Player != nil {
+				player = PlayerData{UserID: p.Player.UserID, DisplayName: p.Player.DisplayName, Ready: p.Player.Ready}
+			}
+			return EvtPlayerJoined, EvtPlayerJoinedPayload{Side: p.Side, Player: player}, true
+		case "left":
+			return EvtPlayerLeft, EvtPlayerLeftPayload{Side: p.Side}, true
+		case "ready_changed":
+			ready := p.Player != nil && p.Player.Ready
+			return EvtPlayerReadyChanged, EvtPlayerReadyChangedPayload{Side: p.Side, Ready: ready}, true
+		}
+
+	case MessageTypeDraftStarted:
+		var p DraftStartedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtDraftStarted, EvtDraftStartedPayload{Phase: PhaseInfo{
+				CurrentPhase:     p.CurrentPhase,
+				CurrentTeam:      p.CurrentTeam,
+				ActionType:       p.ActionType,
+				TimerRemainingMs: p.TimerRemainingMs,
+			}}, true
+		}
+
+	case MessageTypePhaseChanged:
+		var p PhaseChangedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtPhaseChanged, EvtPhaseChangedPayload{Phase: PhaseInfo{
+				CurrentPhase:     p.CurrentPhase,
+				CurrentTeam:      p.CurrentTeam,
+				ActionType:       p.ActionType,
+				TimerRemainingMs: p.TimerRemainingMs,
+			}}, true
+		}
+
+	case MessageTypeChampionHovered:
+		var p ChampionHoveredPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtChampionHovered, EvtChampionHoveredPayload{Side: p.Side, ChampionID: p.ChampionID}, true
+		}
+
+	case MessageTypeChampionSelected:
+		var p ChampionSelectedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtChampionSelected, EvtChampionSelectedPayload{Selection: ChampionSelection{
+				Phase:      p.Phase,
+				Team:       p.Team,
+				ActionType: p.ActionType,
+				ChampionID: p.ChampionID,
+			}}, true
+		}
+
+	case MessageTypeDraftCompleted:
+		var p DraftCompletedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtDraftCompleted, EvtDraftCompletedPayload{Result: DraftResult{
+				BlueBans:        p.BlueBans,
+				RedBans:         p.RedBans,
+				BluePicks:       p.BluePicks,
+				RedPicks:        p.RedPicks,
+				BlueAssignments: p.BlueAssignments,
+				RedAssignments:  p.RedAssignments,
+			}}, true
+		}
+
+	case MessageTypeDraftPaused:
+		var p DraftPausedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtDraftPaused, EvtDraftPausedPayload{
+				PausedBy:     p.PausedBy,
+				Side:         p.PausedBySide,
+				TimerFrozen:  p.TimerFrozenAt,
+				MaxPauseTime: p.MaxPauseDuration,
+			}, true
+		}
+
+	case MessageTypeDraftResumed:
+		var p DraftResumedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtDraftResumed, EvtDraftResumedPayload{TimerRemaining: p.TimerRemainingMs, ResumedBy: p.ResumedBy}, true
+		}
+
+	case MessageTypeResumeReadyUpdate:
+		var p ResumeReadyUpdatePayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtResumeReadyChanged, EvtResumeReadyChangedPayload{Blue: p.BlueReady, Red: p.RedReady}, true
+		}
+
+	case MessageTypeResumeCountdown:
+		var p ResumeCountdownPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtResumeCountdown, EvtResumeCountdownPayload{
+				Seconds:     p.SecondsRemaining,
+				Cancelled:   p.CancelledBy != "",
+				CancelledBy: p.CancelledBy,
+			}, true
+		}
+
+	case MessageTypeEditProposed:
+		var p EditProposedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtEditProposed, EvtEditProposedPayload{
+				Proposal: EditProposal{
+					SlotType:      p.SlotType,
+					Team:          p.Team,
+					SlotIndex:     p.SlotIndex,
+					OldChampionID: p.OldChampionID,
+					NewChampionID: p.NewChampionID,
+				},
+				ProposedBy: p.ProposedBy,
+				Side:       p.ProposedSide,
+				ExpiresAt:  p.ExpiresAt,
+			}, true
+		}
+
+	case MessageTypeEditApplied:
+		var p EditAppliedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtEditApplied, EvtEditAppliedPayload{
+				Edit: EditProposal{
+					SlotType:      p.SlotType,
+					Team:          p.Team,
+					SlotIndex:     p.SlotIndex,
+					OldChampionID: p.OldChampionID,
+					NewChampionID: p.NewChampionID,
+				},
+				NewState: PicksAndBansV2{
+					BlueBans:  p.BlueBans,
+					RedBans:   p.RedBans,
+					BluePicks: p.BluePicks,
+					RedPicks:  p.RedPicks,
+				},
+			}, true
+		}
+
+	case MessageTypeEditRejected:
+		var p EditRejectedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			// Edits that time out are rejected by the system with no side
+			if p.RejectedSide == "" {
+				return EvtEditRejected, EvtEditRejectedPayload{Expired: true}, true
+			}
+			return EvtEditRejected, EvtEditRejectedPayload{RejectedBy: p.RejectedBy, Side: p.RejectedSide}, true
+		}
+
+	case MessageTypeSeriesUpdated:
+		var p SeriesUpdatedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtSeriesUpdated, p, true
+		}
+
+	case MessageTypeSuggestionsUpdated:
+		var p SuggestionsUpdatedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtSuggestionsUpdated, p, true
+		}
+
+	case MessageTypeTradePhaseStarted:
+		var p TradePhaseStartedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtTradePhaseStarted, p, true
+		}
+
+	case MessageTypeTradeUpdated:
+		var p TradeUpdatedPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtTradeUpdated, p, true
+		}
+
+	case MessageTypeChatMessage:
+		var p RoomChatMessagePayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtChatMessage, p, true
+		}
+
+	case MessageTypePingMarker:
+		var p PingMarkerPayload
+		if json.Unmarshal(msg.Payload, &p) == nil {
+			return EvtPingMarker, p, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// stateToV2 converts a STATE_SYNC payload into a v2 STATE payload.
+func stateToV2(p *StateSyncPayload) StatePayload {
+	d := p.Draft
+
+	draft := DraftStateV2{
+		CurrentPhase:     d.CurrentPhase,
+		TimerRemainingMs: d.TimerRemainingMs,
+		Picks: PicksAndBansV2{
+			BlueBans:  d.BlueBans,
+			RedBans:   d.RedBans,
+			BluePicks: d.BluePicks,
+			RedPicks:  d.RedPicks,
+		},
+		IsComplete:   d.IsComplete,
+		IsPaused:     d.IsPaused,
+		FearlessBans: p.FearlessBans,
+		Reserve:      d.Reserve,
+		Template:     p.Template,
+		ChampionPool: p.ChampionPool,
+		Suggestions:  p.Suggestions,
+		Trade:        d.Trade,
+		Chat:         p.Chat,
+	}
+	if d.CurrentTeam != "" {
+		team, actionType := d.CurrentTeam, d.ActionType
+		draft.CurrentTeam = &team
+		draft.ActionType = &actionType
+	}
+	if d.IsPaused {
+		draft.PauseInfo = &PauseState{
+			PausedBy:    d.PausedBy,
+			PausedSide:  d.PausedBySide,
+			TimerFrozen: d.TimerRemainingMs,
+		}
+		draft.ResumeState = &ResumeState{
+			BlueReady: d.BlueResumeReady,
+			RedReady:  d.RedResumeReady,
+			Countdown: d.ResumeCountdown,
+		}
+	}
+	if e := d.PendingEdit; e != nil {
+		draft.PendingEdit = &PendingEditState{
+			Proposal: EditProposal{
+				SlotType:      e.SlotType,
+				Team:          e.Team,
+				SlotIndex:     e.SlotIndex,
+				OldChampionID: e.OldChampionID,
+				NewChampionID: e.NewChampionID,
+			},
+			ProposedBy: e.ProposedBy,
+			Side:       e.ProposedSide,
+			ExpiresAt:  e.ExpiresAt,
+		}
+	}
+
+	players := PlayerStateV2{Mode: "1v1"}
+	if p.IsTeamDraft {
+		players.Mode = "team"
+		players.TeamPlayers = make([]TeamPlayerData, 0, len(p.TeamPlayers))
+		for _, tp := range p.TeamPlayers {
+			players.TeamPlayers = append(players.TeamPlayers, TeamPlayerData(tp))
+		}
+	} else {
+		players.Players = &Players1v1{
+			Blue: playerToV2(p.Players.Blue),
+			Red:  playerToV2(p.Players.Red),
+		}
+	}
+
+	return StatePayload{
+		Room:    RoomStateV2(p.Room),
+		Draft:   draft,
+		Players: players,
+		Client: ClientStateV2{
+			YourSide:       p.YourSide,
+			IsCaptain:      p.IsCaptain,
+			SpectatorCount: p.SpectatorCount,
+		},
+	}
+}
+
+func playerToV2(p *PlayerInfo) *PlayerData {
+	if p == nil {
+		return nil
+	}
+	return &PlayerData{UserID: p.UserID, DisplayName: p.DisplayName, Ready: p.Ready}
+}
+
+func timerFrame(timerType TimerType, payload interface{}) Timer {
+	data, _ := json.Marshal(payload)
+	return Timer{Timer: timerType, Payload: data}
+}
+
+// legacyEventType names the v2 event for a legacy message type without a dedicated mapping.
+func legacyEventType(msgType MessageType) EventType {
+	return EventType(strings.ToLower(string(msgType)))
+}
+
+// legacyCommands maps the v1 client message types whose payloads match the v2
+// command payloads onto their command actions.
+var legacyCommands = map[MessageType]CommandAction{
+	MessageTypeJoinRoom:       CmdJoinRoom,
+	MessageTypeSelectChampion: CmdSelectChampion,
+	MessageTypeLockIn:         CmdLockIn,
+	MessageTypeHoverChampion:  CmdHoverChampion,
+	MessageTypeReady:          CmdSetReady,
+	MessageTypeStartDraft:     CmdStartDraft,
+	MessageTypePauseDraft:     CmdPauseDraft,
+	MessageTypeReadyToResume:  CmdResumeReady,
+	MessageTypeProposeEdit:    CmdProposeEdit,
+	MessageTypeSendChat:       CmdSendChat,
+	MessageTypeSendPing:       CmdSendPing,
+}
+
+// legacyToV2 translates a v1 client message into the equivalent v2 COMMAND or QUERY.
+// Returns false for message types with no v2 equivalent.
+func legacyToV2(msg *Message) (*Msg, bool) {
+	var envelope MsgType
+	var payload interface{}
+
+	switch msg.Type {
+	case MessageTypeSyncState:
+		envelope, payload = MsgTypeQuery, Query{Query: QuerySyncState}
+
+	case MessageTypeResumeDraft:
+		// Clicking resume marks the side ready to resume
+		envelope, payload = MsgTypeCommand, legacyCommand(CmdResumeReady, CmdResumeReadyPayload{Ready: true})
+
+	case MessageTypeConfirmEdit, MessageTypeRejectEdit:
+		accept := msg.Type == MessageTypeConfirmEdit
+		envelope, payload = MsgTypeCommand, legacyCommand(CmdRespondEdit, CmdRespondEditPayload{Accept: accept})
+
+	default:
+		action, ok := legacyCommands[msg.Type]
+		if !ok {
+			return nil, false
+		}
+		envelope, payload = MsgTypeCommand, Command{Action: action, Payload: msg.Payload}
+	}
+
+	data, _ := json.Marshal(payload)
+	return &Msg{Type: envelope, Payload: data, Timestamp: msg.Timestamp}, true
+}
+
+func legacyCommand(action CommandAction, payload interface{}) Command {
+	data, _ := json.Marshal(payload)
+	return Command{Action: action, Payload: data}
+}
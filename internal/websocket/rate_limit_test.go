@@ -0,0 +1,48 @@
+package websocket_test
+
+import (
+	"testing"
+
+	"github.com/dom/league-draft-website/internal/ratelimit"
+	"github.com/dom/league-draft-website/internal/testutil"
+	"github.com/dom/league-draft-website/internal/websocket"
+	gorillaWS "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftFlow_HoverFlood(t *testing.T) {
+	ts := testutil.NewTestServer(t)
+	ts.Hub.SetMessageLimits(websocket.MessageLimits{
+		Message:       ratelimit.PerMinute(1, 5),
+		Hover:         ratelimit.PerMinute(1, 3),
+		MaxViolations: 5,
+	})
+
+	_, blueToken := testutil.NewUserBuilder().
+		WithDisplayName("bluePlayer").
+		BuildAndAuthenticate(t, ts)
+	room := testutil.NewRoomBuilder().BuildWithHub(t, ts)
+	testutil.SeedRealChampions(t, ts.DB.DB)
+
+	client := testutil.NewWSClient(t, ts.WebSocketURL(t, blueToken))
+	client.JoinRoom(room.ID.String(), "blue")
+	client.ExpectStateSync(defaultTimeout)
+	client.DrainMessages()
+
+	// Hovers past the budget are dropped with an error
+	champion := "Ahri"
+	for i := 0; i < 4; i++ {
+		client.HoverChampion(&champion)
+	}
+	client.ExpectErrorWithCode("RATE_LIMITED", defaultTimeout)
+
+	// Hovers have their own budget, so other commands still go through
+	client.SyncState()
+	client.SkipUntilMessageType(websocket.MessageTypeStateSync, defaultTimeout)
+
+	// A client that keeps flooding is disconnected on its sixth dropped message
+	for i := 0; i < 5; i++ {
+		client.HoverChampion(&champion)
+	}
+	assert.Equal(t, gorillaWS.ClosePolicyViolation, client.ExpectClosed(defaultTimeout))
+}
@@ -22,6 +22,11 @@ type Room struct {
 	championRepo    repository.ChampionRepository
 	roomRepo        repository.RoomRepository
 	draftActionRepo repository.DraftActionRepository
+	fearlessBanRepo repository.FearlessBanRepository
+	templateRepo    repository.DraftTemplateRepository
+	assignmentRepo  repository.PickAssignmentRepository
+	chatRepo        repository.RoomChatMessageRepository
+	persister       *StatePersister
 
 	// Team draft mode (5v5)
 	isTeamDraft      bool
@@ -31,12 +36,21 @@ type Room struct {
 	blueTeamClients  map[*Client]bool // Non-captain blue team members
 	redTeamClients   map[*Client]bool // Non-captain red team members
 
+	// Outbound event sequencing and replay
+	events *EventLog
+
+	// Delayed broadcasts for spectators
+	spectatorFeed *SpectatorFeed
+
 	// Managers
-	emitter  *EventEmitter
-	timerMgr *TimerManager
-	pauseMgr *PauseManager
-	editMgr  *EditManager
-	draftMgr *DraftStateManager
+	emitter       *EventEmitter
+	timerMgr      *TimerManager
+	pauseMgr      *PauseManager
+	editMgr       *EditManager
+	draftMgr      *DraftStateManager
+	suggestionMgr *SuggestionManager
+	tradeMgr      *TradeManager
+	chatMgr       *ChatManager
 
 	// Channels
 	join           chan *Client
@@ -54,6 +68,10 @@ type Room struct {
 	confirmEdit    chan *Client
 	rejectEdit     chan *Client
 	readyToResume  chan *ReadyToResumeRequest
+	suggest        chan *SuggestionRequest
+	voteSuggestion chan *SuggestionRequest
+	trade          chan *TradeRequest
+	chat           chan *ChatRequest
 	stop    chan struct{}
 	done    chan struct{} // closed when Run() exits
 
@@ -90,7 +108,33 @@ type ReadyToResumeRequest struct {
 	Ready  bool
 }
 
-func NewRoom(id uuid.UUID, shortCode string, timerDurationMs int, userRepo repository.UserRepository, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository) *Room {
+// TradeRequest is a team member's trade phase command
+type TradeRequest struct {
+	Client     *Client
+	Action     CommandAction
+	ChampionID string
+	OfferID    string
+	UserID     string
+	Role       string
+	Accept     bool
+}
+
+// ChatRequest is a player sending a chat message, or a ping when Ping is set
+type ChatRequest struct {
+	Client  *Client
+	Channel string
+	Body    string
+	Ping    *CmdSendPingPayload
+}
+
+// SuggestionRequest is a team member suggesting or voting on a champion
+type SuggestionRequest struct {
+	Client     *Client
+	ChampionID string
+	Vote       bool
+}
+
+func NewRoom(id uuid.UUID, shortCode string, timerDurationMs int, userRepo repository.UserRepository, championRepo repository.ChampionRepository, roomRepo repository.RoomRepository, draftActionRepo repository.DraftActionRepository, fearlessBanRepo repository.FearlessBanRepository, templateRepo repository.DraftTemplateRepository, draftStateRepo repository.DraftStateRepository, assignmentRepo repository.PickAssignmentRepository, chatRepo repository.RoomChatMessageRepository) *Room {
 	r := &Room{
 		id:               id,
 		shortCode:        shortCode,
@@ -103,6 +147,11 @@ func NewRoom(id uuid.UUID, shortCode string, timerDurationMs int, userRepo repos
 		championRepo:     championRepo,
 		roomRepo:         roomRepo,
 		draftActionRepo:  draftActionRepo,
+		fearlessBanRepo:  fearlessBanRepo,
+		templateRepo:     templateRepo,
+		assignmentRepo:   assignmentRepo,
+		chatRepo:         chatRepo,
+		persister:        NewStatePersister(id, draftStateRepo),
 		join:             make(chan *Client),
 		leave:              make(chan *Client),
 		broadcast:          make(chan *Message),
@@ -118,18 +167,29 @@ func NewRoom(id uuid.UUID, shortCode string, timerDurationMs int, userRepo repos
 		confirmEdit:        make(chan *Client),
 		rejectEdit:         make(chan *Client),
 		readyToResume:      make(chan *ReadyToResumeRequest),
+		suggest:            make(chan *SuggestionRequest),
+		voteSuggestion:     make(chan *SuggestionRequest),
+		trade:              make(chan *TradeRequest),
+		chat:               make(chan *ChatRequest),
 		stop:               make(chan struct{}),
 		done:               make(chan struct{}),
 	}
 
 	// Initialize managers
+	r.events = NewEventLog()
+	r.spectatorFeed = NewSpectatorFeed(r)
 	r.emitter = NewEventEmitter(r)
-	r.timerMgr = NewTimerManager(timerDurationMs, r.emitter, r.handleTimerExpired)
+	r.timerMgr = NewTimerManager(timerDurationMs, r.emitter, r.handleTimerExpired, func() string {
+		return r.draftMgr.GetCurrentSide()
+	})
 	r.pauseMgr = NewPauseManager(r)
 	r.editMgr = NewEditManager(r)
+	r.suggestionMgr = NewSuggestionManager(r)
+	r.tradeMgr = NewTradeManager(r)
+	r.chatMgr = NewChatManager(r)
 
 	// DraftStateManager
-	r.draftMgr = NewDraftStateManager(r, championRepo, roomRepo, draftActionRepo, timerDurationMs)
+	r.draftMgr = NewDraftStateManager(r, championRepo, roomRepo, draftActionRepo, fearlessBanRepo, templateRepo, timerDurationMs)
 
 	return r
 }
@@ -153,6 +213,75 @@ func (r *Room) InitializeTeamDraft(players []*domain.RoomPlayer) {
 	}
 }
 
+// SubstitutePlayer hands a team draft slot to a substitute, who keeps the slot's role and captaincy.
+// The leaving player's connections become spectators and the substitute's join the team.
+func (r *Room) SubstitutePlayer(outUserID uuid.UUID, substitute *domain.RoomPlayer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Rooms that have not set up their team draft yet load the updated players when they do
+	if !r.isTeamDraft {
+		return
+	}
+
+	side := string(substitute.Team)
+	delete(r.roomPlayers, outUserID)
+	r.roomPlayers[substitute.UserID] = substitute
+	if substitute.IsCaptain {
+		if substitute.Team == domain.SideBlue {
+			r.blueCaptainID = &substitute.UserID
+		} else if substitute.Team == domain.SideRed {
+			r.redCaptainID = &substitute.UserID
+		}
+	}
+	if r.tradeMgr.IsActive() {
+		r.tradeMgr.ReplacePlayer(side, outUserID, substitute.UserID)
+	}
+
+	for client := range r.clients {
+		if client.userID != outUserID && client.userID != substitute.UserID {
+			continue
+		}
+
+		delete(r.spectators, client)
+		delete(r.blueTeamClients, client)
+		delete(r.redTeamClients, client)
+		if r.blueClient == client {
+			r.blueClient = nil
+			r.getDraftState().BlueReady = false
+		}
+		if r.redClient == client {
+			r.redClient = nil
+			r.getDraftState().RedReady = false
+		}
+
+		if client.userID == outUserID {
+			client.side = "spectator"
+			r.spectators[client] = true
+			continue
+		}
+
+		client.side = side
+		switch {
+		case substitute.IsCaptain && side == "blue":
+			r.blueClient = client
+		case substitute.IsCaptain && side == "red":
+			r.redClient = client
+		case side == "blue":
+			r.blueTeamClients[client] = true
+		case side == "red":
+			r.redTeamClients[client] = true
+		}
+	}
+
+	log.Printf("Room %s: %s substituted in for %s on %s", r.id, substitute.UserID, outUserID, side)
+
+	if r.tradeMgr.IsActive() {
+		r.tradeMgr.Emit(side)
+	}
+	r.syncAllClients()
+}
+
 // IsTeamDraft returns whether the room is in team draft mode
 func (r *Room) IsTeamDraft() bool {
 	r.mu.RLock()
@@ -190,6 +319,26 @@ func (r *Room) GetPlayerTeam(userID uuid.UUID) string {
 	return ""
 }
 
+// sideUserID returns the user drafting for a side: the captain in team drafts, otherwise the connected player
+func (r *Room) sideUserID(side string) *uuid.UUID {
+	if r.isTeamDraft {
+		if side == "blue" {
+			return r.blueCaptainID
+		}
+		return r.redCaptainID
+	}
+
+	client := r.blueClient
+	if side == "red" {
+		client = r.redClient
+	}
+	if client == nil {
+		return nil
+	}
+	userID := client.userID
+	return &userID
+}
+
 func (r *Room) getUserDisplayName(userID uuid.UUID) string {
 	user, err := r.userRepo.GetByID(context.Background(), userID)
 	if err != nil {
@@ -210,14 +359,28 @@ func (r *Room) getDraftState() *DraftState {
 func (r *Room) Run() {
 	defer close(r.done) // Signal that Run() has exited
 
+	go r.persister.Run()
+
+	// Load room context and any saved draft before a client can join
+	r.mu.Lock()
+	r.draftMgr.loadRoomContext()
+	r.draftMgr.restoreState()
+	r.chatMgr.Load()
+	r.spectatorFeed.Start()
+	r.mu.Unlock()
+
 	for {
 		select {
 		case <-r.stop:
 			r.mu.Lock()
 			r.stopped = true
-			// Stop the timer to prevent callbacks
+			// Stop the timers to prevent callbacks
 			r.timerMgr.Stop()
+			r.tradeMgr.Stop()
+			r.spectatorFeed.Stop()
 			r.mu.Unlock()
+			// Write the last snapshot before exiting
+			r.persister.Close()
 			return
 
 		case client := <-r.join:
@@ -268,6 +431,18 @@ func (r *Room) Run() {
 
 		case req := <-r.readyToResume:
 			r.handleReadyToResume(req)
+
+		case req := <-r.suggest:
+			r.handleSuggestion(req, false)
+
+		case req := <-r.voteSuggestion:
+			r.handleSuggestion(req, true)
+
+		case req := <-r.trade:
+			r.handleTrade(req)
+
+		case req := <-r.chat:
+			r.handleChat(req)
 		}
 	}
 }
@@ -294,6 +469,7 @@ func (r *Room) handleJoin(client *Client) {
 	defer r.mu.Unlock()
 
 	r.clients[client] = true
+	requestedSide := client.side
 
 	// In team draft mode, only captains become blueClient/redClient
 	// Other team members are tracked separately
@@ -331,6 +507,10 @@ func (r *Room) handleJoin(client *Client) {
 	} else {
 		// Original 1v1 behavior
 		log.Printf("Room %s: Using 1v1 mode for user %s (side: %s)", r.id, client.userID, client.side)
+		if r.draftMgr.IsBotSide(client.side) {
+			client.sendError("SIDE_TAKEN", "Side is played by the bot")
+			client.side = "spectator"
+		}
 		switch client.side {
 		case "blue":
 			if r.blueClient != nil && r.blueClient != client {
@@ -353,8 +533,13 @@ func (r *Room) handleJoin(client *Client) {
 		}
 	}
 
-	// Send state sync to joining client
-	r.sendStateSyncLocked(client)
+	// A reconnecting client only needs the events it missed; fall back to a
+	// full state sync when the gap is too old or its side changed
+	lastSeq := client.lastSeq
+	client.lastSeq = nil
+	if lastSeq == nil || client.side != requestedSide || !r.emitter.Replay(client, *lastSeq) {
+		r.sendStateSyncLocked(client)
+	}
 
 	// Notify others
 	r.emitter.PlayerUpdate(client.side, &PlayerInfo{
@@ -394,7 +579,7 @@ func (r *Room) handleSelectChampion(req *SelectChampionRequest) {
 		return
 	}
 
-	phase := domain.GetPhase(r.getDraftState().CurrentPhase)
+	phase := r.draftMgr.GetPhase(r.getDraftState().CurrentPhase)
 	if phase == nil {
 		return
 	}
@@ -417,9 +602,9 @@ func (r *Room) handleSelectChampion(req *SelectChampionRequest) {
 		}
 	}
 
-	// Check if champion is already picked/banned
-	if r.draftMgr.IsChampionUsed(req.ChampionID) {
-		req.Client.sendError("CHAMPION_UNAVAILABLE", "Champion is already picked or banned")
+	// Check fearless bans, used champions and the team's random pool
+	if err := r.draftMgr.CheckChampionAvailable(currentSide, req.ChampionID); err != nil {
+		req.Client.sendError("CHAMPION_UNAVAILABLE", err.Message)
 		return
 	}
 
@@ -427,11 +612,7 @@ func (r *Room) handleSelectChampion(req *SelectChampionRequest) {
 	r.draftMgr.SetCurrentHover(currentSide, &req.ChampionID)
 
 	// Broadcast hover
-	msg, _ := NewMessage(MessageTypeChampionHovered, ChampionHoveredPayload{
-		Side:       currentSide,
-		ChampionID: &req.ChampionID,
-	})
-	r.emitter.Broadcast(msg)
+	r.draftMgr.emitHover(currentSide, &req.ChampionID)
 }
 
 func (r *Room) handleLockIn(client *Client) {
@@ -448,7 +629,7 @@ func (r *Room) handleLockIn(client *Client) {
 		return
 	}
 
-	phase := domain.GetPhase(r.getDraftState().CurrentPhase)
+	phase := r.draftMgr.GetPhase(r.getDraftState().CurrentPhase)
 	if phase == nil {
 		return
 	}
@@ -484,13 +665,7 @@ func (r *Room) handleLockIn(client *Client) {
 	r.timerMgr.Stop()
 
 	// Broadcast selection
-	msg, _ := NewMessage(MessageTypeChampionSelected, ChampionSelectedPayload{
-		Phase:      r.getDraftState().CurrentPhase,
-		Team:       string(phase.Team),
-		ActionType: string(phase.ActionType),
-		ChampionID: *championID,
-	})
-	r.emitter.Broadcast(msg)
+	r.draftMgr.emitSelection(phase, *championID)
 
 	// Move to next phase
 	r.draftMgr.advancePhase()
@@ -504,11 +679,12 @@ func (r *Room) handleHoverChampion(req *HoverChampionRequest) {
 		return
 	}
 
-	msg, _ := NewMessage(MessageTypeChampionHovered, ChampionHoveredPayload{
-		Side:       req.Client.side,
-		ChampionID: req.ChampionID,
-	})
-	r.emitter.Broadcast(msg)
+	// Only the side's drafter hovers; team members suggest instead
+	if !r.isCaptain(req.Client.userID, req.Client.side) {
+		return
+	}
+
+	r.draftMgr.emitHover(req.Client.side, req.ChampionID)
 }
 
 func (r *Room) handleReady(req *ReadyRequest) {
@@ -527,6 +703,7 @@ func (r *Room) handleReady(req *ReadyRequest) {
 	}
 
 	req.Client.ready = req.Ready
+	r.draftMgr.persistState()
 
 	r.emitter.PlayerUpdate(req.Client.side, &PlayerInfo{
 		UserID:      req.Client.userID.String(),
@@ -544,7 +721,7 @@ func (r *Room) handleStartDraft(client *Client) {
 		return
 	}
 
-	if r.blueClient == nil || r.redClient == nil {
+	if (r.blueClient == nil && !r.draftMgr.IsBotSide("blue")) || (r.redClient == nil && !r.draftMgr.IsBotSide("red")) {
 		client.sendError("MISSING_PLAYERS", "Both sides must have a player")
 		return
 	}
@@ -555,8 +732,9 @@ func (r *Room) handleStartDraft(client *Client) {
 	}
 
 	r.getDraftState().Started = true
+	r.draftMgr.rollChampionPools()
 
-	phase := domain.GetPhase(0)
+	phase := r.draftMgr.GetPhase(0)
 
 	msg, _ := NewMessage(MessageTypeDraftStarted, DraftStartedPayload{
 		CurrentPhase:     0,
@@ -572,9 +750,28 @@ func (r *Room) handleStartDraft(client *Client) {
 	}
 
 	r.timerMgr.Start()
+
+	r.draftMgr.persistState()
+	r.draftMgr.persistRoomStatus(domain.RoomStatusInProgress)
+	r.draftMgr.scheduleBotTurn()
+}
+
+func (r *Room) handleBotTurn(phaseIndex int) {
+	r.loadBotContext()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped || r.pauseMgr.IsPaused() {
+		return
+	}
+
+	r.draftMgr.PlayBotTurn(phaseIndex)
 }
 
 func (r *Room) handleTimerExpired() {
+	r.loadBotContext()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -585,6 +782,37 @@ func (r *Room) handleTimerExpired() {
 	r.draftMgr.HandleTimerExpired()
 }
 
+// loadBotContext loads the champion list and the opponent's pick history the bot drafts
+// with for the current turn. The repositories are queried without the room lock held,
+// so commands are never blocked on them.
+func (r *Room) loadBotContext() {
+	r.mu.RLock()
+	side, userID := r.draftMgr.opponentToLoad()
+	needChampions := r.draftMgr.needsBotChampions()
+	r.mu.RUnlock()
+	if userID == nil && !needChampions {
+		return
+	}
+
+	var counts map[string]int
+	if userID != nil {
+		counts = r.draftMgr.fetchOpponentPicks(*userID)
+	}
+	var champions []*domain.Champion
+	if needChampions {
+		champions = r.draftMgr.fetchBotChampions()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if userID != nil {
+		r.draftMgr.setOpponentPicks(side, counts)
+	}
+	if len(r.draftMgr.botChampions) == 0 {
+		r.draftMgr.botChampions = champions
+	}
+}
+
 func (r *Room) sendStateSync(client *Client) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -601,10 +829,21 @@ func (r *Room) syncAllClients() {
 }
 
 func (r *Room) sendStateSyncLocked(client *Client) {
+	// Delayed spectators get the state from before the delay instead of the live one
+	if r.spectatorFeed.Delays(client) {
+		client.Send(r.spectatorFeed.View())
+		return
+	}
+	client.Send(r.buildStateSync(client.userID, client.side))
+}
+
+// buildStateSync builds the STATE_SYNC a client with the given user and side would see.
+// Must be called with room lock held.
+func (r *Room) buildStateSync(userID uuid.UUID, side string) *Message {
 	var currentTeam, actionType string
 	timerRemaining := r.timerDurationMs
 
-	if phase := domain.GetPhase(r.getDraftState().CurrentPhase); phase != nil {
+	if phase := r.draftMgr.GetPhase(r.getDraftState().CurrentPhase); phase != nil {
 		currentTeam = string(phase.Team)
 		actionType = string(phase.ActionType)
 	}
@@ -619,14 +858,18 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 	}
 
 	var bluePlayer, redPlayer *PlayerInfo
-	if r.blueClient != nil {
+	if r.draftMgr.IsBotSide("blue") {
+		bluePlayer = botPlayerInfo()
+	} else if r.blueClient != nil {
 		bluePlayer = &PlayerInfo{
 			UserID:      r.blueClient.userID.String(),
 			DisplayName: r.getUserDisplayName(r.blueClient.userID),
 			Ready:       r.getDraftState().BlueReady,
 		}
 	}
-	if r.redClient != nil {
+	if r.draftMgr.IsBotSide("red") {
+		redPlayer = botPlayerInfo()
+	} else if r.redClient != nil {
 		redPlayer = &PlayerInfo{
 			UserID:      r.redClient.userID.String(),
 			DisplayName: r.getUserDisplayName(r.redClient.userID),
@@ -646,19 +889,19 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 	// Determine if this client is a captain
 	isCaptain := false
 	if r.isTeamDraft {
-		if r.blueCaptainID != nil && client.userID == *r.blueCaptainID {
+		if r.blueCaptainID != nil && userID == *r.blueCaptainID {
 			isCaptain = true
 		}
-		if r.redCaptainID != nil && client.userID == *r.redCaptainID {
+		if r.redCaptainID != nil && userID == *r.redCaptainID {
 			isCaptain = true
 		}
 		log.Printf("STATE_SYNC: isTeamDraft=true, client=%s, side=%s, blueCaptainID=%v, redCaptainID=%v, isCaptain=%v",
-			client.userID, client.side, r.blueCaptainID, r.redCaptainID, isCaptain)
+			userID, side, r.blueCaptainID, r.redCaptainID, isCaptain)
 	} else {
 		// In 1v1 mode, both players are effectively "captains"
-		isCaptain = client.side == "blue" || client.side == "red"
+		isCaptain = side == "blue" || side == "red"
 		log.Printf("STATE_SYNC: isTeamDraft=false (1v1 mode), client=%s, side=%s, isCaptain=%v",
-			client.userID, client.side, isCaptain)
+			userID, side, isCaptain)
 	}
 
 	// Build team players list for team draft mode
@@ -679,6 +922,18 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 	// Build pending edit info from EditManager
 	pendingEditInfo := r.editMgr.BuildPendingEditInfo()
 
+	// Blind drafts hide the other team's picks until the draft completes
+	bluePicks, redPicks := r.draftMgr.VisiblePicks(side)
+
+	// Team suggestions are only shown to the suggesting team
+	var suggestions []SuggestionInfo
+	if r.isTeamDraft && (side == "blue" || side == "red") {
+		suggestions = r.suggestionMgr.Ranked(side)
+	}
+
+	// Team channel chat is only shown to that team
+	chat := r.chatMgr.History(side)
+
 	// Get paused by display name
 	pausedByName := ""
 	if pausedByID := r.pauseMgr.GetPausedBy(); pausedByID != nil {
@@ -689,7 +944,7 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 		Room: RoomInfo{
 			ID:            r.id.String(),
 			ShortCode:     r.shortCode,
-			DraftMode:     "pro_play",
+			DraftMode:     r.draftMgr.GetDraftMode(),
 			Status:        status,
 			TimerDuration: r.timerDurationMs,
 		},
@@ -700,8 +955,8 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 			TimerRemainingMs: timerRemaining,
 			BlueBans:         r.getDraftState().BlueBans,
 			RedBans:          r.getDraftState().RedBans,
-			BluePicks:        r.getDraftState().BluePicks,
-			RedPicks:         r.getDraftState().RedPicks,
+			BluePicks:        bluePicks,
+			RedPicks:         redPicks,
 			IsComplete:       r.getDraftState().IsComplete,
 			IsPaused:         r.pauseMgr.IsPaused(),
 			PausedBy:         pausedByName,
@@ -710,19 +965,28 @@ func (r *Room) sendStateSyncLocked(client *Client) {
 			BlueResumeReady:  func() bool { b, _ := r.pauseMgr.GetResumeReady(); return b }(),
 			RedResumeReady:   func() bool { _, r := r.pauseMgr.GetResumeReady(); return r }(),
 			ResumeCountdown:  r.pauseMgr.GetResumeCountdown(),
+			Reserve:          r.timerMgr.GetReserve(),
+			Trade:            r.tradeMgr.Info(side),
 		},
 		Players: PlayersInfo{
 			Blue: bluePlayer,
 			Red:  redPlayer,
 		},
-		YourSide:       client.side,
+		YourSide:       side,
 		IsCaptain:      isCaptain,
 		IsTeamDraft:    r.isTeamDraft,
 		TeamPlayers:    teamPlayers,
 		SpectatorCount: len(r.spectators),
+		FearlessBans:   r.draftMgr.GetFearlessBans(),
+		Template:       r.draftMgr.GetTemplateInfo(),
+		ChampionPool:   r.draftMgr.GetChampionPool(side),
+		Suggestions:    suggestions,
+		Chat:           chat,
 	})
 
-	client.Send(msg)
+	// Tell the client where the event stream stands so it can resume from here
+	msg.Seq = r.events.LastSeq()
+	return msg
 }
 
 // isCaptain checks if a user is a captain for the given side
@@ -740,13 +1004,149 @@ func (r *Room) isCaptain(userID uuid.UUID, side string) bool {
 	return side == "blue" || side == "red"
 }
 
+// handleSuggestion handles a team member suggesting a champion or voting on a suggestion
+func (r *Room) handleSuggestion(req *SuggestionRequest, isVote bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client := req.Client
+
+	if !r.isTeamDraft {
+		client.sendError("INVALID_STATE", "Suggestions are only available in team drafts")
+		return
+	}
+
+	if !r.getDraftState().Started || r.getDraftState().IsComplete {
+		client.sendError("INVALID_STATE", "Draft not in progress")
+		return
+	}
+
+	// Captains draft; the rest of the team suggests
+	if !r.blueTeamClients[client] && !r.redTeamClients[client] {
+		client.sendError("UNAUTHORIZED", "Only non-captain team members can suggest champions")
+		return
+	}
+
+	var err error
+	if isVote {
+		err = r.suggestionMgr.Vote(client.userID, client.side, req.ChampionID, req.Vote)
+	} else {
+		err = r.suggestionMgr.Suggest(client.userID, client.side, req.ChampionID)
+	}
+	if err != nil {
+		if suggestionErr, ok := err.(*SuggestionError); ok {
+			client.sendError(suggestionErr.Code, suggestionErr.Message)
+		} else {
+			client.sendError("SUGGESTION_ERROR", err.Error())
+		}
+		return
+	}
+
+	r.suggestionMgr.Emit(client.side)
+}
+
+// handleTrade handles a team member's command during the trade phase
+func (r *Room) handleTrade(req *TradeRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client := req.Client
+
+	if !r.tradeMgr.IsActive() {
+		client.sendError("INVALID_STATE", "Trades are not open")
+		return
+	}
+
+	// Only the team's players trade; captains settle the assignments
+	player, ok := r.roomPlayers[client.userID]
+	if !ok || string(player.Team) != client.side {
+		client.sendError("UNAUTHORIZED", "Only team members can trade")
+		return
+	}
+
+	var err error
+	switch req.Action {
+	case CmdProposeTrade:
+		err = r.tradeMgr.Propose(client.userID, client.side, req.ChampionID)
+	case CmdRespondTrade:
+		err = r.tradeMgr.Respond(client.userID, client.side, req.OfferID, req.Accept)
+	case CmdAssignPick, CmdFinishTrades:
+		if !r.isCaptain(client.userID, client.side) {
+			client.sendError("UNAUTHORIZED", "Only captains can assign picks")
+			return
+		}
+		if req.Action == CmdFinishTrades {
+			if r.tradeMgr.Finish(client.side) {
+				r.draftMgr.completeDraft()
+				return
+			}
+			break
+		}
+		userID, parseErr := uuid.Parse(req.UserID)
+		if parseErr != nil {
+			client.sendError("INVALID_PAYLOAD", "Invalid user ID")
+			return
+		}
+		err = r.tradeMgr.Assign(client.side, req.ChampionID, userID, domain.Role(req.Role))
+	}
+	if err != nil {
+		if tradeErr, ok := err.(*TradeError); ok {
+			client.sendError(tradeErr.Code, tradeErr.Message)
+		} else {
+			client.sendError("TRADE_ERROR", err.Error())
+		}
+		return
+	}
+
+	r.tradeMgr.Emit(client.side)
+}
+
+// handleChat handles a player's chat message or ping
+func (r *Room) handleChat(req *ChatRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client := req.Client
+
+	if !client.chatLimiter.Allow() {
+		client.sendError("RATE_LIMITED", "You are sending messages too quickly")
+		return
+	}
+
+	var err error
+	if req.Ping != nil {
+		err = r.chatMgr.Ping(client, *req.Ping)
+	} else {
+		err = r.chatMgr.Send(client, domain.ChatChannel(req.Channel), req.Body)
+	}
+	if err != nil {
+		if chatErr, ok := err.(*ChatError); ok {
+			client.sendError(chatErr.Code, chatErr.Message)
+		} else {
+			client.sendError("CHAT_ERROR", err.Error())
+		}
+	}
+}
+
+// handleTradeTimeout completes the draft when the trade phase runs out
+func (r *Room) handleTradeTimeout() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped || !r.tradeMgr.IsActive() {
+		return
+	}
+
+	r.draftMgr.completeDraft()
+}
+
 // handlePauseDraft handles a pause request from a client
 func (r *Room) handlePauseDraft(client *Client) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Validate: draft started, not complete, not already paused
-	if !r.getDraftState().Started || r.getDraftState().IsComplete || r.pauseMgr.IsPaused() {
+	// Validate: draft started, not complete, not trading, not already paused
+	if !r.getDraftState().Started || r.getDraftState().IsComplete || r.tradeMgr.IsActive() || r.pauseMgr.IsPaused() {
 		client.sendError("INVALID_STATE", "Cannot pause at this time")
 		return
 	}
@@ -895,11 +1295,63 @@ func (r *Room) GetID() uuid.UUID {
 	return r.id
 }
 
+// setSeries links the room to a series it was added to after loading its context.
+// A draft that already completed records its fearless bans now.
+func (r *Room) setSeries(seriesID uuid.UUID, gameNumber int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.draftMgr.seriesID = &seriesID
+	r.draftMgr.gameNumber = gameNumber
+	if r.draftMgr.IsComplete() {
+		r.draftMgr.recordFearlessBans()
+	}
+}
+
+// getClientSides returns a snapshot of the connected clients and their sides
+func (r *Room) getClientSides() map[*Client]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sides := make(map[*Client]string, len(r.clients))
+	for client := range r.clients {
+		sides[client] = client.side
+	}
+	return sides
+}
+
 // GetShortCode returns the room's short code
 func (r *Room) GetShortCode() string {
 	return r.shortCode
 }
 
+// Summary returns the room's current status for the admin room list
+func (r *Room) Summary() ActiveRoomInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state := r.getDraftState()
+	status := "waiting"
+	if state.Started {
+		if state.IsComplete {
+			status = "completed"
+		} else {
+			status = "in_progress"
+		}
+	}
+
+	return ActiveRoomInfo{
+		RoomID:         r.id.String(),
+		ShortCode:      r.shortCode,
+		Status:         status,
+		IsTeamDraft:    r.isTeamDraft,
+		IsPaused:       r.pauseMgr.IsPaused(),
+		CurrentPhase:   state.CurrentPhase,
+		ClientCount:    len(r.clients),
+		SpectatorCount: len(r.spectators),
+	}
+}
+
 // GetPendingActionForUser returns the pending action for a user in this room, if any
 func (r *Room) GetPendingActionForUser(userID uuid.UUID) *DraftPendingAction {
 	r.mu.RLock()
@@ -975,7 +1427,7 @@ func (r *Room) GetPendingActionForUser(userID uuid.UUID) *DraftPendingAction {
 	}
 
 	// Check if it's user's turn for pick/ban
-	phase := domain.GetPhase(state.CurrentPhase)
+	phase := r.draftMgr.GetPhase(state.CurrentPhase)
 	if phase == nil {
 		return nil
 	}
@@ -0,0 +1,163 @@
+package websocket
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// delayedEvent is a broadcast waiting to be released to spectators.
+type delayedEvent struct {
+	releaseAt time.Time
+	frames    frames
+	// view is the spectator STATE_SYNC as of this event; nil for transient messages
+	view *Message
+}
+
+// SpectatorFeed holds back what spectators see by the room's spectator delay,
+// so a streamed draft cannot be ghosted by watching it live.
+//
+// Broadcasts are queued and released to spectators once the delay has passed,
+// hovers are never shown to them (they see a champion at lock-in), and their
+// STATE_SYNC is the snapshot taken with the last released event.
+//
+// It is used with the room lock held, but has its own lock because timer ticks
+// are broadcast under the read lock.
+type SpectatorFeed struct {
+	room    *Room
+	delay   time.Duration
+	pending []delayedEvent
+	view    *Message
+	timer   *time.Timer
+	stopped bool
+
+	mu sync.Mutex
+}
+
+// NewSpectatorFeed creates a spectator feed with no delay.
+func NewSpectatorFeed(room *Room) *SpectatorFeed {
+	return &SpectatorFeed{room: room}
+}
+
+// Enable delays everything spectators see by delayMs.
+func (f *SpectatorFeed) Enable(delayMs int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delay = time.Duration(delayMs) * time.Millisecond
+	log.Printf("Room %s spectator delay set to %dms", f.room.id, delayMs)
+}
+
+// IsEnabled returns whether spectators are delayed.
+func (f *SpectatorFeed) IsEnabled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.delay > 0
+}
+
+// Delays returns whether the client is a spectator who should get the delayed feed.
+// Must be called with room lock held.
+func (f *SpectatorFeed) Delays(client *Client) bool {
+	return f.room.spectators[client] && f.IsEnabled()
+}
+
+// Start captures the view spectators are shown until the first delayed event is released.
+// Must be called with room lock held, after any saved draft has been restored.
+func (f *SpectatorFeed) Start() {
+	if !f.IsEnabled() {
+		return
+	}
+	view := f.room.buildStateSync(uuid.Nil, "spectator")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.view = view
+}
+
+// Push queues a broadcast for release to spectators once the delay has passed.
+// Hovers are dropped. Must be called with room lock (or read lock) held.
+func (f *SpectatorFeed) Push(msg *Message, encoded frames) {
+	if !f.IsEnabled() || msg.Type == MessageTypeChampionHovered {
+		return
+	}
+
+	// Snapshot the state spectators will see once this event is released. With no spectator
+	// in the room the snapshot is skipped, so one who joins later is shown an older view
+	// until the next snapshot is released, never a newer one.
+	var view *Message
+	if !isTransientMessage(msg.Type) && len(f.room.spectators) > 0 {
+		view = f.room.buildStateSync(uuid.Nil, "spectator")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stopped {
+		return
+	}
+	f.pending = append(f.pending, delayedEvent{
+		releaseAt: time.Now().Add(f.delay),
+		frames:    encoded,
+		view:      view,
+	})
+	f.scheduleLocked()
+}
+
+// View returns the STATE_SYNC delayed spectators are shown.
+func (f *SpectatorFeed) View() *Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.view
+}
+
+// Stop drops anything not yet released.
+func (f *SpectatorFeed) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stopped = true
+	f.pending = nil
+	if f.timer != nil {
+		f.timer.Stop()
+		f.timer = nil
+	}
+}
+
+// release sends spectators every queued event whose delay has passed.
+func (f *SpectatorFeed) release() {
+	f.room.mu.RLock()
+	defer f.room.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.timer = nil
+	if f.stopped {
+		return
+	}
+
+	now := time.Now()
+	for len(f.pending) > 0 && !f.pending[0].releaseAt.After(now) {
+		event := f.pending[0]
+		f.pending = f.pending[1:]
+
+		if event.view != nil {
+			f.view = event.view
+		}
+		for client := range f.room.spectators {
+			client.trySendFrames(event.frames)
+		}
+	}
+
+	f.scheduleLocked()
+}
+
+// scheduleLocked arms the timer for the oldest queued event.
+// A single timer keeps events in order. Must be called with f.mu held.
+func (f *SpectatorFeed) scheduleLocked() {
+	if f.timer != nil || len(f.pending) == 0 {
+		return
+	}
+	f.timer = time.AfterFunc(time.Until(f.pending[0].releaseAt), f.release)
+}
@@ -0,0 +1,151 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/dom/league-draft-website/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatePersister writes draft state snapshots to the database in order.
+// Snapshots are coalesced, so a slow write never queues more than one pending update.
+type StatePersister struct {
+	roomID  uuid.UUID
+	repo    repository.DraftStateRepository
+	stateID *uuid.UUID
+
+	pending *domain.DraftState
+	signal  chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu sync.Mutex
+}
+
+// NewStatePersister creates a persister for a room's draft state.
+func NewStatePersister(roomID uuid.UUID, repo repository.DraftStateRepository) *StatePersister {
+	return &StatePersister{
+		roomID: roomID,
+		repo:   repo,
+		signal: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Run writes snapshots until Close is called.
+func (p *StatePersister) Run() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.signal:
+			p.flush()
+		case <-p.stop:
+			// Write whatever was saved last before shutting down
+			p.flush()
+			return
+		}
+	}
+}
+
+// Close stops the persister after writing the latest pending snapshot.
+// It blocks until Run has exited.
+func (p *StatePersister) Close() {
+	close(p.stop)
+	<-p.done
+}
+
+// Save queues a snapshot for writing, replacing any snapshot not yet written.
+func (p *StatePersister) Save(snapshot *domain.DraftState) {
+	if p.repo == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.pending = snapshot
+	p.mu.Unlock()
+
+	select {
+	case p.signal <- struct{}{}:
+	default:
+		// A write is already signalled and will pick up this snapshot
+	}
+}
+
+// Load reads the room's saved draft state and remembers its row for later writes.
+func (p *StatePersister) Load(ctx context.Context) (*domain.DraftState, error) {
+	if p.repo == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	state, err := p.repo.GetByRoomID(ctx, p.roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.stateID = &state.ID
+	p.mu.Unlock()
+
+	return state, nil
+}
+
+// flush writes the pending snapshot, if any.
+func (p *StatePersister) flush() {
+	p.mu.Lock()
+	snapshot := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	if snapshot == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := p.write(ctx, snapshot); err != nil {
+		// Only log if not a context/connection error (likely test cleanup)
+		if ctx.Err() == nil {
+			log.Printf("Error persisting draft state for room %s phase %d: %v", p.roomID, snapshot.CurrentPhase, err)
+		}
+	}
+}
+
+// write updates the room's draft state row, creating it if the room never had one.
+func (p *StatePersister) write(ctx context.Context, snapshot *domain.DraftState) error {
+	p.mu.Lock()
+	stateID := p.stateID
+	p.mu.Unlock()
+
+	if stateID == nil {
+		existing, err := p.repo.GetByRoomID(ctx, p.roomID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if existing == nil {
+			snapshot.ID = uuid.New()
+			if err := p.repo.Create(ctx, snapshot); err != nil {
+				return err
+			}
+			p.mu.Lock()
+			p.stateID = &snapshot.ID
+			p.mu.Unlock()
+			return nil
+		}
+		stateID = &existing.ID
+		p.mu.Lock()
+		p.stateID = stateID
+		p.mu.Unlock()
+	}
+
+	snapshot.ID = *stateID
+	return p.repo.Update(ctx, snapshot)
+}
@@ -0,0 +1,193 @@
+package websocket
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Suggestion is a champion proposed to a captain by a teammate.
+type Suggestion struct {
+	ChampionID      string
+	SuggestedBy     uuid.UUID
+	SuggestedByName string      // looked up once, when the suggestion is made
+	Voters          []uuid.UUID // in vote order; the suggester is the first voter
+	order           int         // creation order, breaks ties between equally voted suggestions
+}
+
+// SuggestionManager tracks the pick/ban suggestions non-captain team members
+// make during a team draft. Suggestions only ever go to the suggesting team
+// and are cleared when the phase changes.
+// It has no lock of its own and must be used with the room lock held.
+type SuggestionManager struct {
+	room        *Room
+	suggestions map[string][]*Suggestion // side -> suggestions
+	nextOrder   int
+}
+
+// NewSuggestionManager creates a new suggestion manager.
+func NewSuggestionManager(room *Room) *SuggestionManager {
+	return &SuggestionManager{
+		room:        room,
+		suggestions: make(map[string][]*Suggestion),
+	}
+}
+
+// Suggest records a suggestion from userID for their side.
+// Each member backs one suggestion at a time, so suggesting moves their vote to the new one.
+// Suggesting a champion a teammate already suggested counts as an upvote.
+func (sm *SuggestionManager) Suggest(userID uuid.UUID, side, championID string) error {
+	if err := sm.room.draftMgr.CheckChampionAvailable(side, championID); err != nil {
+		return &SuggestionError{"champion_unavailable", err.Message}
+	}
+
+	if existing := sm.find(side, championID); existing != nil {
+		sm.backSuggestion(existing, userID, side)
+		return nil
+	}
+
+	sm.withdrawVotes(userID, side, nil)
+	sm.suggestions[side] = append(sm.suggestions[side], &Suggestion{
+		ChampionID:      championID,
+		SuggestedBy:     userID,
+		SuggestedByName: sm.displayName(userID),
+		Voters:          []uuid.UUID{userID},
+		order:           sm.nextOrder,
+	})
+	sm.nextOrder++
+	return nil
+}
+
+// Vote adds or removes userID's upvote on a suggestion. Upvoting moves the voter's vote
+// off whatever they backed before, and a suggestion nobody votes for any more is dropped.
+func (sm *SuggestionManager) Vote(userID uuid.UUID, side, championID string, vote bool) error {
+	suggestion := sm.find(side, championID)
+	if suggestion == nil {
+		return &SuggestionError{"suggestion_not_found", "No such suggestion"}
+	}
+
+	if vote {
+		sm.backSuggestion(suggestion, userID, side)
+		return nil
+	}
+
+	sm.withdrawVote(suggestion, userID, side)
+	return nil
+}
+
+// Ranked returns a side's suggestions, most voted first.
+func (sm *SuggestionManager) Ranked(side string) []SuggestionInfo {
+	suggestions := append([]*Suggestion(nil), sm.suggestions[side]...)
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if len(suggestions[i].Voters) != len(suggestions[j].Voters) {
+			return len(suggestions[i].Voters) > len(suggestions[j].Voters)
+		}
+		return suggestions[i].order < suggestions[j].order
+	})
+
+	ranked := make([]SuggestionInfo, 0, len(suggestions))
+	for _, s := range suggestions {
+		voterIDs := make([]string, 0, len(s.Voters))
+		for _, voter := range s.Voters {
+			voterIDs = append(voterIDs, voter.String())
+		}
+		ranked = append(ranked, SuggestionInfo{
+			ChampionID:      s.ChampionID,
+			SuggestedBy:     s.SuggestedBy.String(),
+			SuggestedByName: s.SuggestedByName,
+			Votes:           len(s.Voters),
+			VoterIDs:        voterIDs,
+		})
+	}
+	return ranked
+}
+
+// Clear drops every suggestion. Called when the draft moves to the next phase.
+func (sm *SuggestionManager) Clear() {
+	sm.suggestions = make(map[string][]*Suggestion)
+}
+
+// Emit sends a side's ranked suggestions to that side only.
+func (sm *SuggestionManager) Emit(side string) {
+	msg, _ := NewMessage(MessageTypeSuggestionsUpdated, SuggestionsUpdatedPayload{
+		Side:        side,
+		Suggestions: sm.Ranked(side),
+	})
+	sm.room.emitter.BroadcastToSide(side, msg)
+}
+
+func (sm *SuggestionManager) find(side, championID string) *Suggestion {
+	for _, s := range sm.suggestions[side] {
+		if s.ChampionID == championID {
+			return s
+		}
+	}
+	return nil
+}
+
+// backSuggestion upvotes a suggestion and withdraws the voter's vote from every other one,
+// so each member stands behind one suggestion at a time.
+func (sm *SuggestionManager) backSuggestion(suggestion *Suggestion, userID uuid.UUID, side string) {
+	sm.withdrawVotes(userID, side, suggestion)
+	sm.addVote(suggestion, userID)
+}
+
+// displayName names a suggester from the room's team players, which are already loaded,
+// only going to the database for someone who isn't one.
+func (sm *SuggestionManager) displayName(userID uuid.UUID) string {
+	if player, ok := sm.room.roomPlayers[userID]; ok && player.DisplayName != "" {
+		return player.DisplayName
+	}
+	return sm.room.getUserDisplayName(userID)
+}
+
+func (sm *SuggestionManager) addVote(suggestion *Suggestion, userID uuid.UUID) {
+	for _, voter := range suggestion.Voters {
+		if voter == userID {
+			return
+		}
+	}
+	suggestion.Voters = append(suggestion.Voters, userID)
+}
+
+// withdrawVotes removes userID's vote from every suggestion of the side except keep.
+func (sm *SuggestionManager) withdrawVotes(userID uuid.UUID, side string, keep *Suggestion) {
+	for _, s := range append([]*Suggestion(nil), sm.suggestions[side]...) {
+		if s != keep {
+			sm.withdrawVote(s, userID, side)
+		}
+	}
+}
+
+// withdrawVote removes userID's vote from a suggestion, dropping it once nobody backs it.
+func (sm *SuggestionManager) withdrawVote(suggestion *Suggestion, userID uuid.UUID, side string) {
+	for i, voter := range suggestion.Voters {
+		if voter == userID {
+			suggestion.Voters = append(suggestion.Voters[:i], suggestion.Voters[i+1:]...)
+			break
+		}
+	}
+	if len(suggestion.Voters) == 0 {
+		sm.remove(side, suggestion)
+	}
+}
+
+func (sm *SuggestionManager) remove(side string, suggestion *Suggestion) {
+	list := sm.suggestions[side]
+	for i, s := range list {
+		if s == suggestion {
+			sm.suggestions[side] = append(list[:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+// SuggestionError represents a suggestion-related error.
+type SuggestionError struct {
+	Code    string
+	Message string
+}
+
+func (e *SuggestionError) Error() string {
+	return e.Message
+}
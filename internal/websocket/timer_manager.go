@@ -8,43 +8,104 @@ import (
 const bufferDurationMsConst = 5000 // 5 second buffer after timer hits 0
 
 // TimerManager handles draft timer logic including ticking, expiry, and pause/resume.
+//
+// With reserve time enabled each side also has a bank (chess clock). Once a phase's
+// own timer hits zero the acting side's reserve is drawn down, and the buffer period
+// and auto-pick only start after that reserve is used up.
 type TimerManager struct {
-	durationMs    int
-	timer         *time.Timer
-	timerStarted  time.Time
-	tickerStop    chan struct{}
-	onExpired     func()
-	emitter       *EventEmitter
+	durationMs   int
+	timer        *time.Timer
+	timerStarted time.Time
+	tickerStop   chan struct{}
+	onExpired    func()
+	currentSide  func() string
+	emitter      *EventEmitter
 
 	// For pause support
-	frozenMs      int
-	isPaused      bool
+	frozenMs int
+	isPaused bool
+
+	// Reserve (bank) time per side
+	reserveEnabled bool
+	reserveMs      map[string]int
+	phaseSide      string // side whose reserve the running phase draws from
+	reserveSettled bool   // whether the running phase's reserve use was already deducted
 
 	mu sync.RWMutex
 }
 
 // NewTimerManager creates a new timer manager.
-func NewTimerManager(durationMs int, emitter *EventEmitter, onExpired func()) *TimerManager {
+// currentSide reports which side acts in the current phase, for reserve time.
+func NewTimerManager(durationMs int, emitter *EventEmitter, onExpired func(), currentSide func() string) *TimerManager {
 	return &TimerManager{
-		durationMs: durationMs,
-		emitter:    emitter,
-		onExpired:  onExpired,
+		durationMs:  durationMs,
+		emitter:     emitter,
+		onExpired:   onExpired,
+		currentSide: currentSide,
+		reserveMs:   make(map[string]int),
 	}
 }
 
+// EnableReserve turns on chess-clock mode, giving each side reserveMs of bank time.
+func (tm *TimerManager) EnableReserve(reserveMs int) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.reserveEnabled = reserveMs > 0
+	tm.reserveMs = map[string]int{"blue": reserveMs, "red": reserveMs}
+}
+
+// RestoreReserve sets each side's remaining bank time, e.g. after a restart.
+func (tm *TimerManager) RestoreReserve(blueMs, redMs int) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if !tm.reserveEnabled {
+		return
+	}
+	tm.reserveMs = map[string]int{"blue": blueMs, "red": redMs}
+}
+
 // Start begins the timer for a new phase.
 func (tm *TimerManager) Start() {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	tm.timerStarted = time.Now()
+	tm.startLocked(tm.durationMs)
+}
+
+// StartFrom begins the timer for a phase that already has remainingMs left,
+// e.g. when a draft is resumed or rehydrated after a server restart. A negative
+// value resumes inside the reserve or buffer period.
+func (tm *TimerManager) StartFrom(remainingMs int) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if remainingMs > tm.durationMs {
+		remainingMs = tm.durationMs
+	}
+	tm.startLocked(remainingMs)
+}
+
+// startLocked starts the expiry timer and ticker with remainingMs left on the main timer.
+// Must be called with lock held.
+func (tm *TimerManager) startLocked(remainingMs int) {
+	elapsed := time.Duration(tm.durationMs-remainingMs) * time.Millisecond
+	tm.timerStarted = time.Now().Add(-elapsed)
 	tm.isPaused = false
 
-	// Timer fires after main duration + buffer period
-	totalDuration := tm.durationMs + bufferDurationMsConst
-	tm.timer = time.AfterFunc(time.Duration(totalDuration)*time.Millisecond, func() {
-		tm.onExpired()
-	})
+	tm.phaseSide = ""
+	if tm.currentSide != nil {
+		tm.phaseSide = tm.currentSide()
+	}
+	tm.reserveSettled = false
+
+	// Timer fires after main duration + the side's reserve + buffer period
+	totalDuration := remainingMs + tm.phaseReserveLocked() + bufferDurationMsConst
+	if totalDuration < 0 {
+		totalDuration = 0
+	}
+	tm.timer = time.AfterFunc(time.Duration(totalDuration)*time.Millisecond, tm.handleExpired)
 
 	// Start ticker for timer updates - pass the stop channel to avoid race
 	tm.tickerStop = make(chan struct{})
@@ -52,6 +113,15 @@ func (tm *TimerManager) Start() {
 	go tm.runTicker(stopChan)
 }
 
+// handleExpired uses up the acting side's reserve before handing off to the auto-pick.
+func (tm *TimerManager) handleExpired() {
+	tm.mu.Lock()
+	tm.settleReserveLocked()
+	tm.mu.Unlock()
+
+	tm.onExpired()
+}
+
 // Stop stops the timer and ticker.
 func (tm *TimerManager) Stop() {
 	tm.mu.Lock()
@@ -65,9 +135,15 @@ func (tm *TimerManager) Stop() {
 		close(tm.tickerStop)
 		tm.tickerStop = nil
 	}
+
+	// A lock-in ends the phase: charge any reserve it used
+	if !tm.isPaused {
+		tm.settleReserveLocked()
+	}
 }
 
 // Pause pauses the timer and returns the remaining milliseconds.
+// Reserve used so far is deducted, so the frozen value only covers the phase timer.
 func (tm *TimerManager) Pause() int {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
@@ -80,6 +156,8 @@ func (tm *TimerManager) Pause() int {
 		tm.tickerStop = nil
 	}
 
+	tm.settleReserveLocked()
+
 	elapsed := time.Since(tm.timerStarted)
 	tm.frozenMs = tm.durationMs - int(elapsed.Milliseconds())
 	if tm.frozenMs < 0 {
@@ -95,18 +173,7 @@ func (tm *TimerManager) Resume() {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	tm.durationMs = tm.frozenMs
-	tm.isPaused = false
-	tm.timerStarted = time.Now()
-
-	totalDuration := tm.durationMs + bufferDurationMsConst
-	tm.timer = time.AfterFunc(time.Duration(totalDuration)*time.Millisecond, func() {
-		tm.onExpired()
-	})
-
-	tm.tickerStop = make(chan struct{})
-	stopChan := tm.tickerStop // capture a copy for the goroutine
-	go tm.runTicker(stopChan)
+	tm.startLocked(tm.frozenMs)
 }
 
 // GetRemaining returns the remaining milliseconds.
@@ -126,6 +193,101 @@ func (tm *TimerManager) GetRemaining() int {
 	return remaining
 }
 
+// GetReserve returns each side's remaining reserve, or nil when reserve time is off.
+// The acting side's value counts down live once its phase timer has run out.
+func (tm *TimerManager) GetReserve() *ReserveInfo {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.reserveInfoLocked()
+}
+
+// GetBankedReserve returns each side's reserve without the running phase's live usage.
+// Used for persistence, where the timer deadline already captures the live part.
+func (tm *TimerManager) GetBankedReserve() (blueMs, redMs int) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.reserveMs["blue"], tm.reserveMs["red"]
+}
+
+// reserveInfoLocked builds the reserve payload.
+// Must be called with lock held (read or write).
+func (tm *TimerManager) reserveInfoLocked() *ReserveInfo {
+	if !tm.reserveEnabled {
+		return nil
+	}
+
+	info := &ReserveInfo{
+		BlueMs: tm.reserveMs["blue"],
+		RedMs:  tm.reserveMs["red"],
+	}
+
+	used := tm.reserveUsedLocked()
+	if used > 0 {
+		info.Active = true
+		switch tm.phaseSide {
+		case "blue":
+			info.BlueMs -= used
+		case "red":
+			info.RedMs -= used
+		}
+	}
+	return info
+}
+
+// phaseReserveLocked returns the reserve available to the running phase's side.
+// Must be called with lock held.
+func (tm *TimerManager) phaseReserveLocked() int {
+	if !tm.reserveEnabled {
+		return 0
+	}
+	return tm.reserveMs[tm.phaseSide]
+}
+
+// reserveUsedLocked returns how much reserve the running phase has drawn so far.
+// Must be called with lock held.
+func (tm *TimerManager) reserveUsedLocked() int {
+	if !tm.reserveEnabled || tm.reserveSettled || tm.isPaused || tm.timerStarted.IsZero() {
+		return 0
+	}
+
+	overtime := int(time.Since(tm.timerStarted).Milliseconds()) - tm.durationMs
+	if overtime <= 0 {
+		return 0
+	}
+	if available := tm.phaseReserveLocked(); overtime > available {
+		return available
+	}
+	return overtime
+}
+
+// settleReserveLocked deducts the running phase's reserve use from its side's bank.
+// Must be called with lock held.
+func (tm *TimerManager) settleReserveLocked() {
+	if !tm.reserveEnabled || tm.reserveSettled {
+		return
+	}
+	if used := tm.reserveUsedLocked(); used > 0 {
+		tm.reserveMs[tm.phaseSide] -= used
+	}
+	tm.reserveSettled = true
+}
+
+// GetDeadline returns when the current phase's main timer runs out.
+func (tm *TimerManager) GetDeadline() time.Time {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.timerStarted.Add(time.Duration(tm.durationMs) * time.Millisecond)
+}
+
+// Freeze puts a stopped timer into the paused state with frozenMs remaining,
+// used when rehydrating a draft that was paused before a restart.
+func (tm *TimerManager) Freeze(frozenMs int) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.frozenMs = frozenMs
+	tm.isPaused = true
+}
+
 // GetDuration returns the base timer duration.
 func (tm *TimerManager) GetDuration() int {
 	tm.mu.RLock()
@@ -174,20 +336,24 @@ func (tm *TimerManager) runTicker(stopChan <-chan struct{}) {
 			elapsed := time.Since(tm.timerStarted)
 			remaining := tm.durationMs - int(elapsed.Milliseconds())
 
-			// Check if we're in the buffer period (past main timer but before auto-lock)
-			isBufferPeriod := remaining <= 0
+			// Time left before auto-lock starts counting into the buffer
+			remainingWithReserve := remaining + tm.phaseReserveLocked()
+			reserve := tm.reserveInfoLocked()
+
+			// Check if we're in the buffer period (past main timer and reserve but before auto-lock)
+			isBufferPeriod := remainingWithReserve <= 0
 
-			// Display 0 during buffer period (don't show negative)
+			// Display 0 during reserve and buffer periods (don't show negative)
 			displayRemaining := remaining
 			if displayRemaining < 0 {
 				displayRemaining = 0
 			}
 			tm.mu.RUnlock()
 
-			tm.emitter.TimerTick(displayRemaining, isBufferPeriod)
+			tm.emitter.TimerTick(displayRemaining, isBufferPeriod, reserve)
 
 			// Stop ticker after buffer period expires
-			if remaining <= -bufferDurationMsConst {
+			if remainingWithReserve <= -bufferDurationMsConst {
 				return
 			}
 		}
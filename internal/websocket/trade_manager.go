@@ -0,0 +1,398 @@
+package websocket
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/dom/league-draft-website/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ChampionAssignment is the player, and the role they play, for one of a team's picks.
+type ChampionAssignment struct {
+	ChampionID string
+	UserID     uuid.UUID
+	Role       domain.Role
+}
+
+// TradeOffer is a player offering to swap their champion for a teammate's.
+type TradeOffer struct {
+	ID             string
+	FromUserID     uuid.UUID
+	ToUserID       uuid.UUID
+	FromChampionID string
+	ToChampionID   string
+}
+
+// TradeManager runs the optional trade phase after the last pick of a team draft.
+// Teammates swap champions among their team's picks and captains then settle
+// which player plays each pick in which role.
+// It has no lock of its own and must be used with the room lock held.
+type TradeManager struct {
+	room        *Room
+	active      bool
+	endsAt      time.Time
+	timer       *time.Timer
+	assignments map[string][]*ChampionAssignment // side -> one per pick, in pick order
+	offers      map[string][]*TradeOffer         // side -> open offers
+	finished    map[string]bool                  // side -> captain is done trading
+}
+
+// NewTradeManager creates a new trade manager.
+func NewTradeManager(room *Room) *TradeManager {
+	return &TradeManager{
+		room:     room,
+		offers:   make(map[string][]*TradeOffer),
+		finished: make(map[string]bool),
+	}
+}
+
+// IsActive returns whether the trade phase is running.
+func (tm *TradeManager) IsActive() bool {
+	return tm.active
+}
+
+// Start opens the trade phase for durationMs, beginning from each team's default assignments.
+// It fails without opening the phase if a team doesn't have a player for every pick.
+func (tm *TradeManager) Start(durationMs int) error {
+	if err := tm.buildAssignments(); err != nil {
+		return err
+	}
+	tm.active = true
+	tm.endsAt = time.Now().Add(time.Duration(durationMs) * time.Millisecond)
+	tm.timer = time.AfterFunc(time.Duration(durationMs)*time.Millisecond, tm.room.handleTradeTimeout)
+
+	log.Printf("Room %s trade phase started for %dms", tm.room.id, durationMs)
+
+	msg, _ := NewMessage(MessageTypeTradePhaseStarted, TradePhaseStartedPayload{
+		DurationMs: durationMs,
+		EndsAt:     tm.endsAt.UnixMilli(),
+	})
+	tm.room.emitter.Broadcast(msg)
+
+	tm.Emit("blue")
+	tm.Emit("red")
+	return nil
+}
+
+// Stop ends the trade phase.
+func (tm *TradeManager) Stop() {
+	if tm.timer != nil {
+		tm.timer.Stop()
+		tm.timer = nil
+	}
+	tm.active = false
+	tm.offers = make(map[string][]*TradeOffer)
+}
+
+// Propose offers userID's champion in exchange for a teammate's championID.
+// A player has one open offer at a time, so a new one replaces the previous one.
+func (tm *TradeManager) Propose(userID uuid.UUID, side, championID string) error {
+	if !tm.active {
+		return &TradeError{"invalid_state", "Trades are not open"}
+	}
+
+	own := tm.findByUser(side, userID)
+	if own == nil {
+		return &TradeError{"no_champion", "You have no champion to trade"}
+	}
+	target := tm.findByChampion(side, championID)
+	if target == nil {
+		return &TradeError{"champion_not_found", "Champion is not one of your team's picks"}
+	}
+	if target == own {
+		return &TradeError{"invalid_trade", "Cannot trade with yourself"}
+	}
+
+	tm.removeOffers(side, func(o *TradeOffer) bool { return o.FromUserID == userID })
+	tm.offers[side] = append(tm.offers[side], &TradeOffer{
+		ID:             uuid.New().String(),
+		FromUserID:     userID,
+		ToUserID:       target.UserID,
+		FromChampionID: own.ChampionID,
+		ToChampionID:   target.ChampionID,
+	})
+	return nil
+}
+
+// Respond accepts or declines an offer made to userID.
+// Accepting swaps the two champions and withdraws every other offer involving either player.
+func (tm *TradeManager) Respond(userID uuid.UUID, side, offerID string, accept bool) error {
+	if !tm.active {
+		return &TradeError{"invalid_state", "Trades are not open"}
+	}
+
+	var offer *TradeOffer
+	for _, o := range tm.offers[side] {
+		if o.ID == offerID {
+			offer = o
+			break
+		}
+	}
+	if offer == nil {
+		return &TradeError{"offer_not_found", "No such trade offer"}
+	}
+	if offer.ToUserID != userID {
+		return &TradeError{"unauthorized", "This offer was not made to you"}
+	}
+
+	if !accept {
+		tm.removeOffers(side, func(o *TradeOffer) bool { return o == offer })
+		return nil
+	}
+
+	from := tm.findByUser(side, offer.FromUserID)
+	to := tm.findByUser(side, offer.ToUserID)
+	if from == nil || to == nil || from.ChampionID != offer.FromChampionID || to.ChampionID != offer.ToChampionID {
+		tm.removeOffers(side, func(o *TradeOffer) bool { return o == offer })
+		return &TradeError{"offer_expired", "The champions in this offer have changed hands"}
+	}
+
+	from.ChampionID, to.ChampionID = to.ChampionID, from.ChampionID
+	tm.removeOffers(side, func(o *TradeOffer) bool {
+		return o.FromUserID == from.UserID || o.ToUserID == from.UserID ||
+			o.FromUserID == to.UserID || o.ToUserID == to.UserID
+	})
+	return nil
+}
+
+// Assign has userID play championID in the given role.
+// The player and role are swapped with whichever picks held them before,
+// so every pick keeps exactly one player and one role.
+func (tm *TradeManager) Assign(side, championID string, userID uuid.UUID, role domain.Role) error {
+	if !tm.active {
+		return &TradeError{"invalid_state", "Trades are not open"}
+	}
+	if !role.IsValid() {
+		return &TradeError{"invalid_role", "Invalid role"}
+	}
+	if player, ok := tm.room.roomPlayers[userID]; !ok || string(player.Team) != side {
+		return &TradeError{"invalid_player", "Player is not on your team"}
+	}
+
+	entry := tm.findByChampion(side, championID)
+	if entry == nil {
+		return &TradeError{"champion_not_found", "Champion is not one of your team's picks"}
+	}
+
+	if holder := tm.findByUser(side, userID); holder != nil && holder != entry {
+		holder.UserID = entry.UserID
+	}
+	entry.UserID = userID
+
+	for _, a := range tm.assignments[side] {
+		if a != entry && a.Role == role {
+			a.Role = entry.Role
+			break
+		}
+	}
+	entry.Role = role
+
+	// Offers were made against the old assignments
+	tm.offers[side] = nil
+	return nil
+}
+
+// Finish marks a team as done trading, tells both teams, and returns whether both are done.
+func (tm *TradeManager) Finish(side string) bool {
+	tm.finished[side] = true
+	done := tm.finished["blue"] && tm.finished["red"]
+
+	msg, _ := NewMessage(MessageTypeTradeFinished, TradeFinishedPayload{
+		Side:       side,
+		PhaseEnded: done,
+	})
+	tm.room.emitter.Broadcast(msg)
+	return done
+}
+
+// ReplacePlayer gives a substitute the champion and role of the player they replace,
+// withdrawing the leaving player's offers.
+func (tm *TradeManager) ReplacePlayer(side string, outUserID, inUserID uuid.UUID) {
+	if tm.assignments == nil {
+		return
+	}
+	if a := tm.findByUser(side, outUserID); a != nil {
+		a.UserID = inUserID
+	}
+	tm.removeOffers(side, func(o *TradeOffer) bool {
+		return o.FromUserID == outUserID || o.ToUserID == outUserID
+	})
+}
+
+// Emit sends a team its assignments and open offers.
+func (tm *TradeManager) Emit(side string) {
+	msg, _ := NewMessage(MessageTypeTradeUpdated, TradeUpdatedPayload{
+		Side:        side,
+		Assignments: tm.AssignmentInfo(side),
+		Offers:      tm.offerInfo(side),
+		Finished:    tm.finished[side],
+	})
+	tm.room.emitter.BroadcastToSide(side, msg)
+}
+
+// Info returns the trade phase for a client's STATE_SYNC, or nil outside of it.
+func (tm *TradeManager) Info(side string) *TradeInfo {
+	if !tm.active {
+		return nil
+	}
+	info := &TradeInfo{EndsAt: tm.endsAt.UnixMilli()}
+	if side == "blue" || side == "red" {
+		info.Assignments = tm.AssignmentInfo(side)
+		info.Offers = tm.offerInfo(side)
+		info.Finished = tm.finished[side]
+	}
+	return info
+}
+
+// AssignmentInfo returns who plays each of a team's picks.
+// Teams that never traded get their default assignments.
+func (tm *TradeManager) AssignmentInfo(side string) []PickAssignmentInfo {
+	if tm.assignments == nil {
+		if err := tm.buildAssignments(); err != nil {
+			log.Printf("Room %s has no pick assignments: %v", tm.room.id, err)
+			return nil
+		}
+	}
+
+	infos := make([]PickAssignmentInfo, 0, len(tm.assignments[side]))
+	for _, a := range tm.assignments[side] {
+		info := PickAssignmentInfo{
+			ChampionID: a.ChampionID,
+			UserID:     a.UserID.String(),
+			Role:       string(a.Role),
+		}
+		if player, ok := tm.room.roomPlayers[a.UserID]; ok {
+			info.DisplayName = player.DisplayName
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// DomainAssignments converts the final assignments for persistence.
+func (tm *TradeManager) DomainAssignments() []*domain.PickAssignment {
+	if tm.assignments == nil {
+		if err := tm.buildAssignments(); err != nil {
+			log.Printf("Room %s has no pick assignments to save: %v", tm.room.id, err)
+			return nil
+		}
+	}
+
+	var assignments []*domain.PickAssignment
+	for _, side := range []string{"blue", "red"} {
+		for i, a := range tm.assignments[side] {
+			assignments = append(assignments, &domain.PickAssignment{
+				ID:         uuid.New(),
+				RoomID:     tm.room.id,
+				Team:       domain.Side(side),
+				PickOrder:  i,
+				ChampionID: a.ChampionID,
+				UserID:     a.UserID,
+				Role:       a.Role,
+			})
+		}
+	}
+	return assignments
+}
+
+// buildAssignments pairs each team's picks, in pick order, with its players in role order.
+// Every pick needs exactly one player, so it fails if a team's picks and players don't match up.
+func (tm *TradeManager) buildAssignments() error {
+	assignments := make(map[string][]*ChampionAssignment)
+
+	for _, side := range []string{"blue", "red"} {
+		var players []*domain.RoomPlayer
+		for _, p := range tm.room.roomPlayers {
+			if string(p.Team) == side {
+				players = append(players, p)
+			}
+		}
+		sort.Slice(players, func(i, j int) bool {
+			ri, rj := roleOrder(players[i].AssignedRole), roleOrder(players[j].AssignedRole)
+			if ri != rj {
+				return ri < rj
+			}
+			return players[i].UserID.String() < players[j].UserID.String()
+		})
+
+		picks := tm.room.draftMgr.picksFor(side)
+		if len(picks) != len(players) {
+			return fmt.Errorf("%s team has %d picks for %d players", side, len(picks), len(players))
+		}
+		for i, championID := range picks {
+			assignments[side] = append(assignments[side], &ChampionAssignment{
+				ChampionID: championID,
+				UserID:     players[i].UserID,
+				Role:       players[i].AssignedRole,
+			})
+		}
+	}
+
+	tm.assignments = assignments
+	return nil
+}
+
+func (tm *TradeManager) offerInfo(side string) []TradeOfferInfo {
+	infos := make([]TradeOfferInfo, 0, len(tm.offers[side]))
+	for _, o := range tm.offers[side] {
+		infos = append(infos, TradeOfferInfo{
+			ID:             o.ID,
+			FromUserID:     o.FromUserID.String(),
+			ToUserID:       o.ToUserID.String(),
+			FromChampionID: o.FromChampionID,
+			ToChampionID:   o.ToChampionID,
+		})
+	}
+	return infos
+}
+
+func (tm *TradeManager) findByUser(side string, userID uuid.UUID) *ChampionAssignment {
+	for _, a := range tm.assignments[side] {
+		if a.UserID == userID {
+			return a
+		}
+	}
+	return nil
+}
+
+func (tm *TradeManager) findByChampion(side, championID string) *ChampionAssignment {
+	for _, a := range tm.assignments[side] {
+		if a.ChampionID == championID {
+			return a
+		}
+	}
+	return nil
+}
+
+func (tm *TradeManager) removeOffers(side string, match func(*TradeOffer) bool) {
+	kept := tm.offers[side][:0]
+	for _, o := range tm.offers[side] {
+		if !match(o) {
+			kept = append(kept, o)
+		}
+	}
+	tm.offers[side] = kept
+}
+
+// roleOrder returns a role's position in the standard top-to-support order.
+func roleOrder(role domain.Role) int {
+	for i, r := range domain.AllRoles {
+		if r == role {
+			return i
+		}
+	}
+	return len(domain.AllRoles)
+}
+
+// TradeError represents a trade-related error.
+type TradeError struct {
+	Code    string
+	Message string
+}
+
+func (e *TradeError) Error() string {
+	return e.Message
+}
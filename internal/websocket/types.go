@@ -59,6 +59,14 @@ const (
 	CmdResumeReady     CommandAction = "resume_ready"
 	CmdProposeEdit     CommandAction = "propose_edit"
 	CmdRespondEdit     CommandAction = "respond_edit"
+	CmdSuggestChampion CommandAction = "suggest_champion"
+	CmdVoteSuggestion  CommandAction = "vote_suggestion"
+	CmdProposeTrade    CommandAction = "propose_trade"
+	CmdRespondTrade    CommandAction = "respond_trade"
+	CmdAssignPick      CommandAction = "assign_pick"
+	CmdFinishTrades    CommandAction = "finish_trades"
+	CmdSendChat        CommandAction = "send_chat"
+	CmdSendPing        CommandAction = "send_ping"
 )
 
 // Command is the envelope for all client→server actions
@@ -72,6 +80,9 @@ type Command struct {
 type CmdJoinRoomPayload struct {
 	RoomID string `json:"roomId"`
 	Side   string `json:"side"`
+	// LastSeq is the last event seq a reconnecting client saw; missed events are replayed
+	// instead of a full STATE_SYNC when the room still has them
+	LastSeq *int `json:"lastSeq,omitempty"`
 }
 
 type CmdSelectChampionPayload struct {
@@ -101,6 +112,44 @@ type CmdRespondEditPayload struct {
 	Accept bool `json:"accept"`
 }
 
+type CmdSuggestChampionPayload struct {
+	ChampionID string `json:"championId"`
+}
+
+type CmdVoteSuggestionPayload struct {
+	ChampionID string `json:"championId"`
+	Vote       bool   `json:"vote"` // false withdraws the vote
+}
+
+type CmdProposeTradePayload struct {
+	ChampionID string `json:"championId"` // Teammate's champion to swap yours for
+}
+
+type CmdRespondTradePayload struct {
+	OfferID string `json:"offerId"`
+	Accept  bool   `json:"accept"`
+}
+
+type CmdAssignPickPayload struct {
+	ChampionID string `json:"championId"`
+	UserID     string `json:"userId"`
+	Role       string `json:"role"`
+}
+
+type CmdSendChatPayload struct {
+	Channel string `json:"channel"` // "all" or "team"
+	Body    string `json:"body"`
+}
+
+// CmdSendPingPayload pings either a champion or a pick/ban slot
+type CmdSendPingPayload struct {
+	Channel    string `json:"channel"` // "all" or "team"
+	ChampionID string `json:"championId,omitempty"`
+	SlotType   string `json:"slotType,omitempty"` // "ban" or "pick"
+	Team       string `json:"team,omitempty"`     // "blue" or "red"
+	SlotIndex  *int   `json:"slotIndex,omitempty"`
+}
+
 // ============================================================================
 // QUERY - Client → Server state requests
 // ============================================================================
@@ -146,6 +195,20 @@ const (
 	EvtEditProposed EventType = "edit_proposed"
 	EvtEditApplied  EventType = "edit_applied"
 	EvtEditRejected EventType = "edit_rejected"
+
+	// Series
+	EvtSeriesUpdated EventType = "series_updated"
+
+	// Team suggestions
+	EvtSuggestionsUpdated EventType = "suggestions_updated"
+
+	// Post-draft trades
+	EvtTradePhaseStarted EventType = "trade_phase_started"
+	EvtTradeUpdated      EventType = "trade_updated"
+
+	// Chat
+	EvtChatMessage EventType = "chat_message"
+	EvtPingMarker  EventType = "ping_marker"
 )
 
 // Event is the envelope for all server→client state changes
@@ -183,10 +246,12 @@ type EvtDraftCompletedPayload struct {
 }
 
 type DraftResult struct {
-	BlueBans  []string `json:"blueBans"`
-	RedBans   []string `json:"redBans"`
-	BluePicks []string `json:"bluePicks"`
-	RedPicks  []string `json:"redPicks"`
+	BlueBans        []string             `json:"blueBans"`
+	RedBans         []string             `json:"redBans"`
+	BluePicks       []string             `json:"bluePicks"`
+	RedPicks        []string             `json:"redPicks"`
+	BlueAssignments []PickAssignmentInfo `json:"blueAssignments,omitempty"`
+	RedAssignments  []PickAssignmentInfo `json:"redAssignments,omitempty"`
 }
 
 type EvtPhaseChangedPayload struct {
@@ -217,6 +282,7 @@ type EvtPlayerJoinedPayload struct {
 type PlayerData struct {
 	UserID      string `json:"userId"`
 	DisplayName string `json:"displayName"`
+	Ready       bool   `json:"ready"`
 }
 
 type EvtPlayerLeftPayload struct {
@@ -236,7 +302,8 @@ type EvtDraftPausedPayload struct {
 }
 
 type EvtDraftResumedPayload struct {
-	TimerRemaining int `json:"timerRemaining"`
+	TimerRemaining int    `json:"timerRemaining"`
+	ResumedBy      string `json:"resumedBy,omitempty"`
 }
 
 type EvtResumeReadyChangedPayload struct {
@@ -245,8 +312,9 @@ type EvtResumeReadyChangedPayload struct {
 }
 
 type EvtResumeCountdownPayload struct {
-	Seconds   int  `json:"seconds"`
-	Cancelled bool `json:"cancelled,omitempty"`
+	Seconds     int    `json:"seconds"`
+	Cancelled   bool   `json:"cancelled,omitempty"`
+	CancelledBy string `json:"cancelledBy,omitempty"`
 }
 
 type EditProposal struct {
@@ -313,6 +381,12 @@ type DraftStateV2 struct {
 	PendingEdit      *PendingEditState `json:"pendingEdit,omitempty"`
 	ResumeState      *ResumeState    `json:"resumeState,omitempty"`
 	FearlessBans     []string        `json:"fearlessBans,omitempty"`
+	Reserve          *ReserveInfo       `json:"reserve,omitempty"`
+	Template         *DraftTemplateInfo `json:"template,omitempty"`
+	ChampionPool     []string           `json:"championPool,omitempty"`
+	Suggestions      []SuggestionInfo   `json:"suggestions,omitempty"`
+	Trade            *TradeInfo         `json:"trade,omitempty"`
+	Chat             []ChatMessageInfo  `json:"chat,omitempty"`
 }
 
 type PauseState struct {
@@ -377,8 +451,9 @@ type Timer struct {
 }
 
 type TimerTickPayloadV2 struct {
-	Remaining      int  `json:"remaining"`
-	IsBufferPeriod bool `json:"isBufferPeriod"`
+	Remaining      int          `json:"remaining"`
+	IsBufferPeriod bool         `json:"isBufferPeriod"`
+	Reserve        *ReserveInfo `json:"reserve,omitempty"`
 }
 
 type TimerExpiredPayloadV2 struct {